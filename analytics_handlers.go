@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+func (api *APIServer) listTrending(c echo.Context) error {
+	windowDays, limit, err := moversQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.ListTrending(ctx, &analysispb.ListTrendingRequest{
+		CategoryId: c.QueryParam("category_id"),
+		WindowDays: windowDays,
+		Metric:     c.QueryParam("metric"),
+		Limit:      limit,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) listTopMovers(c echo.Context) error {
+	windowDays, limit, err := moversQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.ListTopMovers(ctx, &analysispb.ListTopMoversRequest{
+		CategoryId: c.QueryParam("category_id"),
+		WindowDays: windowDays,
+		Direction:  c.QueryParam("direction"),
+		Limit:      limit,
+		SellerId:   c.QueryParam("seller_id"),
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getPriceForecast(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.GetPriceForecast(ctx, &analysispb.GetPriceForecastRequest{
+		ProductId: c.Param("id"),
+		VariantId: c.QueryParam("variant_id"),
+		SellerId:  c.QueryParam("seller_id"),
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp.Forecasts)
+}
+
+func (api *APIServer) getBuyBoxHistory(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.GetBuyBoxHistory(ctx, &analysispb.GetBuyBoxHistoryRequest{
+		ProductId: c.Param("id"),
+		VariantId: c.QueryParam("variant_id"),
+		From:      c.QueryParam("from"),
+		To:        c.QueryParam("to"),
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp.Variants)
+}
+
+func (api *APIServer) listReviews(c echo.Context) error {
+	page, perPage, err := pageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	var rating int64
+	if value := c.QueryParam("rating"); value != "" {
+		if rating, err = strconv.ParseInt(value, 10, 32); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid rating"})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.ListReviews(ctx, &analysispb.ListReviewsRequest{
+		ProductId:     c.Param("id"),
+		Page:          page,
+		PerPage:       perPage,
+		Rating:        int32(rating),
+		TrendInterval: c.QueryParam("trend_interval"),
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getSimilarProducts(c echo.Context) error {
+	var limit int64
+	var err error
+	if value := c.QueryParam("limit"); value != "" {
+		if limit, err = strconv.ParseInt(value, 10, 32); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+	var cheaperOnly bool
+	if value := c.QueryParam("cheaper_only"); value != "" {
+		if cheaperOnly, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid cheaper_only"})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.GetSimilarProducts(ctx, &analysispb.GetSimilarProductsRequest{
+		ProductId:   c.Param("id"),
+		Limit:       int32(limit),
+		CheaperOnly: cheaperOnly,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// moversQuery parses the optional window_days and limit query parameters.
+func moversQuery(c echo.Context) (int32, int32, error) {
+	var windowDays, limit int32
+	for _, p := range []struct {
+		name   string
+		target *int32
+	}{
+		{"window_days", &windowDays},
+		{"limit", &limit},
+	} {
+		value := c.QueryParam(p.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s", p.name)
+		}
+		*p.target = int32(n)
+	}
+	return windowDays, limit, nil
+}
+
+// grpcError maps a backend gRPC error to an HTTP response.
+func grpcError(c echo.Context, err error) error {
+	st := status.Convert(err)
+	code := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.Unavailable, codes.DeadlineExceeded:
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, map[string]string{"error": st.Message()})
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/faisaloncode/ecommerce-crawler/identity"
+)
+
+const (
+	// APIKeyHeader carries API keys for machine clients.
+	APIKeyHeader = "X-API-Key"
+
+	userIDKey = "user_id"
+)
+
+// Middleware authenticates a request by bearer access token or API key.
+//
+// The caller's user id is stored on the echo context, attached to the
+// request context for outgoing gRPC calls, and set as the X-User-ID header
+// for HTTP services behind the gateway. Any X-User-ID header sent by the
+// client is discarded first.
+func Middleware(service *AuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			req.Header.Del(identity.Header)
+
+			var (
+				userID uint
+				err    error
+			)
+			switch {
+			case req.Header.Get(APIKeyHeader) != "":
+				userID, err = service.AuthenticateAPIKey(req.Context(), req.Header.Get(APIKeyHeader))
+			case strings.HasPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer "):
+				token := strings.TrimPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer ")
+				userID, err = service.AuthenticateAccessToken(token)
+			default:
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+			}
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			}
+
+			c.Set(userIDKey, userID)
+			req.Header.Set(identity.Header, strconv.FormatUint(uint64(userID), 10))
+			c.SetRequest(req.WithContext(identity.NewContext(req.Context(), userID)))
+
+			return next(c)
+		}
+	}
+}
+
+// RequireAdmin lets only administrators through. It must run after
+// Middleware.
+func RequireAdmin(service *AuthService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := service.RequireAdmin(c.Request().Context(), UserID(c))
+			if errors.Is(err, ErrNotAdmin) {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+			}
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			}
+			return next(c)
+		}
+	}
+}
+
+// UserID returns the authenticated user id set by Middleware.
+func UserID(c echo.Context) uint {
+	userID, _ := c.Get(userIDKey).(uint)
+	return userID
+}
@@ -0,0 +1,309 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/models"
+)
+
+const (
+	minPasswordLength = 8
+	apiKeyPrefix      = "eck_"
+)
+
+var (
+	ErrEmailTaken         = errors.New("email is already registered")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrInvalidAPIKey      = errors.New("invalid or revoked api key")
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrNotAdmin           = errors.New("administrator access required")
+)
+
+// dummyHash is compared against when a login names an unknown email so the
+// response time does not reveal which emails are registered.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+
+// ValidationError reports a request field that failed validation.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+type AuthService struct {
+	db     *gorm.DB
+	tokens *tokenIssuer
+}
+
+func NewAuthService(db *gorm.DB, jwtSecret string, accessTTL, refreshTTL time.Duration) *AuthService {
+	return &AuthService{
+		db: db,
+		tokens: &tokenIssuer{
+			secret:     []byte(jwtSecret),
+			accessTTL:  accessTTL,
+			refreshTTL: refreshTTL,
+		},
+	}
+}
+
+// Register creates a user with a bcrypt-hashed password.
+func (s *AuthService) Register(ctx context.Context, username, email, password string) (*models.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, &ValidationError{Field: "username", Message: "is required"}
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < minPasswordLength {
+		return nil, &ValidationError{Field: "password", Message: fmt.Sprintf("must be at least %d characters", minPasswordLength)}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	passwordHash := string(hash)
+
+	user := &models.User{Username: username, Email: email, PasswordHash: &passwordHash}
+	result := s.db.WithContext(ctx).Create(user)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, ErrEmailTaken
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to create user: %w", result.Error)
+	}
+	return user, nil
+}
+
+// Login checks the user's password and issues a new token pair.
+func (s *AuthService) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var user models.User
+	result := s.db.WithContext(ctx).Where("email = ?", email).First(&user)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up user: %w", result.Error)
+	}
+
+	if result.Error != nil || user.PasswordHash == nil {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issueTokens(s.db.WithContext(ctx), user.ID)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token
+// can be used once; the one presented is revoked.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	userID, claims, err := s.tokens.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	var pair *TokenPair
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var stored models.RefreshToken
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_id = ? AND user_id = ?", claims.ID, userID).
+			First(&stored)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ErrInvalidToken
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to look up refresh token: %w", result.Error)
+		}
+		if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+			return ErrInvalidToken
+		}
+
+		now := time.Now()
+		if err := tx.Model(&stored).Update("revoked_at", now).Error; err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+
+		pair, err = s.issueTokens(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// Logout revokes a refresh token. Access tokens stay valid until they expire.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	userID, claims, err := s.tokens.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("token_id = ? AND user_id = ? AND revoked_at IS NULL", claims.ID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+	}
+	return nil
+}
+
+// AuthenticateAccessToken returns the user id an access token was issued to.
+func (s *AuthService) AuthenticateAccessToken(token string) (uint, error) {
+	userID, _, err := s.tokens.parse(token, accessTokenType)
+	return userID, err
+}
+
+// AuthenticateAPIKey returns the owner of an API key and records its use.
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (uint, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return 0, ErrInvalidAPIKey
+	}
+
+	var apiKey models.APIKey
+	result := s.db.WithContext(ctx).Where("key_hash = ? AND revoked_at IS NULL", hashKey(key)).First(&apiKey)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return 0, ErrInvalidAPIKey
+	}
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to look up api key: %w", result.Error)
+	}
+
+	s.db.WithContext(ctx).Model(&apiKey).Update("last_used_at", time.Now())
+	return apiKey.UserID, nil
+}
+
+// CreateAPIKey issues a new API key for the user. The returned key is the
+// only copy; just its hash is stored.
+func (s *AuthService) CreateAPIKey(ctx context.Context, userID uint, name string) (string, *models.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, &ValidationError{Field: "name", Message: "is required"}
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	prefix := apiKeyPrefix + secret[:8]
+	key := prefix + "_" + secret[8:]
+
+	apiKey := &models.APIKey{
+		UserID:  userID,
+		Name:    name,
+		Prefix:  prefix,
+		KeyHash: hashKey(key),
+	}
+	if err := s.db.WithContext(ctx).Create(apiKey).Error; err != nil {
+		return "", nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return key, apiKey, nil
+}
+
+// ListAPIKeys returns the user's API keys, including revoked ones.
+func (s *AuthService) ListAPIKeys(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	result := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&keys)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", result.Error)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes one of the user's API keys.
+func (s *AuthService) RevokeAPIKey(ctx context.Context, userID, id uint) error {
+	result := s.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// GetUser returns a user by id.
+func (s *AuthService) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	result := s.db.WithContext(ctx).First(&user, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user: %w", result.Error)
+	}
+	return &user, nil
+}
+
+// RequireAdmin fails with ErrNotAdmin unless the user is an
+// administrator.
+func (s *AuthService) RequireAdmin(ctx context.Context, userID uint) error {
+	var isAdmin bool
+	result := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Select("is_admin").Scan(&isAdmin)
+	if result.Error != nil {
+		return fmt.Errorf("failed to get user: %w", result.Error)
+	}
+	if !isAdmin {
+		return ErrNotAdmin
+	}
+	return nil
+}
+
+func (s *AuthService) issueTokens(db *gorm.DB, userID uint) (*TokenPair, error) {
+	now := time.Now()
+
+	accessToken, accessExpiresAt, err := s.tokens.sign(userID, accessTokenType, "", s.tokens.accessTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshExpiresAt, err := s.tokens.sign(userID, refreshTokenType, tokenID, s.tokens.refreshTTL, now)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &models.RefreshToken{UserID: userID, TokenID: tokenID, ExpiresAt: refreshExpiresAt}
+	if err := db.Create(stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    accessExpiresAt,
+	}, nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", &ValidationError{Field: "email", Message: "is not a valid email address"}
+	}
+	return email, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/faisaloncode/ecommerce-crawler/auth"
+)
+
+type registerRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type createAPIKeyRequest struct {
+	Name string `json:"name"`
+}
+
+func (api *APIServer) register(c echo.Context) error {
+	var req registerRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	user, err := api.authService.Register(c.Request().Context(), req.Username, req.Email, req.Password)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, user)
+}
+
+func (api *APIServer) login(c echo.Context) error {
+	var req loginRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	tokens, err := api.authService.Login(c.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (api *APIServer) refresh(c echo.Context) error {
+	var req refreshRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	tokens, err := api.authService.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (api *APIServer) logout(c echo.Context) error {
+	var req refreshRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if err := api.authService.Logout(c.Request().Context(), req.RefreshToken); err != nil {
+		return authError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *APIServer) me(c echo.Context) error {
+	user, err := api.authService.GetUser(c.Request().Context(), auth.UserID(c))
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+func (api *APIServer) listAPIKeys(c echo.Context) error {
+	keys, err := api.authService.ListAPIKeys(c.Request().Context(), auth.UserID(c))
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (api *APIServer) createAPIKey(c echo.Context) error {
+	var req createAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	key, apiKey, err := api.authService.CreateAPIKey(c.Request().Context(), auth.UserID(c), req.Name)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	// The key itself is only ever returned here.
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"key":     key,
+		"api_key": apiKey,
+	})
+}
+
+func (api *APIServer) revokeAPIKey(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid api key id"})
+	}
+
+	if err := api.authService.RevokeAPIKey(c.Request().Context(), auth.UserID(c), uint(id)); err != nil {
+		return authError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// authError maps auth service errors to HTTP responses.
+func authError(c echo.Context, err error) error {
+	var validationErr *auth.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, auth.ErrEmailTaken):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, auth.ErrInvalidCredentials), errors.Is(err, auth.ErrInvalidToken):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, auth.ErrAPIKeyNotFound), errors.Is(err, auth.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
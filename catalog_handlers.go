@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+func (api *APIServer) listBrands(c echo.Context) error {
+	page, perPage, err := pageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.ListBrands(ctx, &pb.ListBrandsRequest{
+		Query:   c.QueryParam("q"),
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getBrand(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.GetBrand(ctx, &pb.GetBrandRequest{Id: c.Param("id")})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp.Brand)
+}
+
+func (api *APIServer) getBrandSentiment(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.GetBrandSentiment(ctx, &analysispb.GetBrandSentimentRequest{BrandId: c.Param("id")})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp.Sentiment)
+}
+
+func (api *APIServer) listSellers(c echo.Context) error {
+	page, perPage, err := pageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.ListSellers(ctx, &pb.ListSellersRequest{
+		Query:   c.QueryParam("q"),
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getSeller(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.GetSeller(ctx, &pb.GetSellerRequest{Id: c.Param("id")})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// canonicalListingsRequest is the body of the confirm and split endpoints.
+type canonicalListingsRequest struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+func (api *APIServer) getCanonicalProduct(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.GetCanonicalProduct(ctx, &analysispb.GetCanonicalProductRequest{
+		CanonicalProductId: c.Param("id"),
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) confirmCanonicalProduct(c echo.Context) error {
+	var req canonicalListingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.ConfirmCanonicalProduct(ctx, &analysispb.ConfirmCanonicalProductRequest{
+		CanonicalProductId: c.Param("id"),
+		ProductIds:         req.ProductIDs,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) splitCanonicalProduct(c echo.Context) error {
+	var req canonicalListingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.analysisClient.SplitCanonicalProduct(ctx, &analysispb.SplitCanonicalProductRequest{
+		CanonicalProductId: c.Param("id"),
+		ProductIds:         req.ProductIDs,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// pageQuery parses the optional page and per_page query parameters.
+func pageQuery(c echo.Context) (int32, int32, error) {
+	var page, perPage int32
+	for _, p := range []struct {
+		name   string
+		target *int32
+	}{
+		{"page", &page},
+		{"per_page", &perPage},
+	} {
+		value := c.QueryParam(p.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid %s", p.name)
+		}
+		*p.target = int32(n)
+	}
+	return page, perPage, nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+type Config struct {
+	ServerPort                 string
+	DBHost                     string
+	DBPort                     string
+	DBUser                     string
+	DBPass                     string
+	DBName                     string
+	CrawlerServiceAddr         string
+	ProductAnalysisServiceAddr string
+	NotificationServiceURL     string
+	JWTSecret                  string
+	AccessTokenTTL             time.Duration
+	RefreshTokenTTL            time.Duration
+}
+
+func LoadConfig() (*Config, error) {
+	accessTTL, err := time.ParseDuration(getEnv("ACCESS_TOKEN_TTL", "15m"))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL, err := time.ParseDuration(getEnv("REFRESH_TOKEN_TTL", "720h"))
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if len(jwtSecret) < 32 {
+		return nil, errors.New("JWT_SECRET must be set to at least 32 characters")
+	}
+
+	return &Config{
+		ServerPort:                 getEnv("SERVER_PORT", "8082"),
+		DBHost:                     getEnv("DB_HOST", "localhost"),
+		DBPort:                     getEnv("DB_PORT", "5432"),
+		DBUser:                     getEnv("DB_USER", "postgres"),
+		DBPass:                     getEnv("DB_PASS", "postgres"),
+		DBName:                     getEnv("DB_NAME", "ecommerce"),
+		CrawlerServiceAddr:         getEnv("CRAWLER_SERVICE_ADDR", "localhost:50051"),
+		ProductAnalysisServiceAddr: getEnv("PRODUCT_ANALYSIS_SERVICE_ADDR", "localhost:50052"),
+		NotificationServiceURL:     getEnv("NOTIFICATION_SERVICE_URL", "http://localhost:50053"),
+		JWTSecret:                  jwtSecret,
+		AccessTokenTTL:             accessTTL,
+		RefreshTokenTTL:            refreshTTL,
+	}, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -15,7 +16,27 @@ type Config struct {
 	DBName               string
 	CrawlerServiceAddr   string
 	ProductAnalysisServiceAddr string
+	// BaseURL is the site crawled. Left empty, crawls use mock data for
+	// development.
 	BaseURL              string
+	// ImageStore is where archived images are kept: "file" for a local
+	// directory or "s3" for an S3-compatible bucket.
+	ImageStore    string
+	ImageStoreDir string
+	S3Endpoint    string
+	S3Bucket      string
+	S3Region      string
+	S3AccessKey   string
+	S3SecretKey   string
+	// ProductChangeEvents enables publishing product change events to
+	// Kafka for the notification service.
+	ProductChangeEvents bool
+	KafkaBrokers        string
+	// SnapshotRetention is how long product snapshots are kept after they
+	// were last seen.
+	SnapshotRetention time.Duration
+	// PageArchiveDir is where the pages the scrapers fetch are archived.
+	PageArchiveDir string
 }
 
 func LoadConfig() *Config {
@@ -49,6 +70,38 @@ func LoadConfig() *Config {
 		dbName = "ecommerce_crawler"
 	}
 
+	analysisAddr := os.Getenv("PRODUCT_ANALYSIS_SERVICE_ADDR")
+	if analysisAddr == "" {
+		analysisAddr = "localhost:50052"
+	}
+
+	imageStore := os.Getenv("IMAGE_STORE")
+	if imageStore == "" {
+		imageStore = "file"
+	}
+
+	imageStoreDir := os.Getenv("IMAGE_STORE_DIR")
+	if imageStoreDir == "" {
+		imageStoreDir = "/var/lib/crawler/images"
+	}
+
+	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
+	if kafkaBrokers == "" {
+		kafkaBrokers = "localhost:9092"
+	}
+
+	productChangeEvents, _ := strconv.ParseBool(os.Getenv("PRODUCT_CHANGE_EVENTS"))
+
+	snapshotRetention, err := time.ParseDuration(os.Getenv("SNAPSHOT_RETENTION"))
+	if err != nil || snapshotRetention <= 0 {
+		snapshotRetention = 365 * 24 * time.Hour
+	}
+
+	pageArchiveDir := os.Getenv("PAGE_ARCHIVE_DIR")
+	if pageArchiveDir == "" {
+		pageArchiveDir = "/var/lib/crawler/pages"
+	}
+
 	return &Config{
 		ServerPort:           os.Getenv("SERVER_PORT"),
 		DBHost:               dbHost,
@@ -57,7 +110,18 @@ func LoadConfig() *Config {
 		DBPass:               dbPass,
 		DBName:               dbName,
 		CrawlerServiceAddr:   fmt.Sprintf(":%d", crawlerPort),
-		ProductAnalysisServiceAddr: os.Getenv("PRODUCT_ANALYSIS_SERVICE_ADDR"),
+		ProductAnalysisServiceAddr: analysisAddr,
 		BaseURL:              os.Getenv("BASE_URL"),
+		ImageStore:           imageStore,
+		ImageStoreDir:        imageStoreDir,
+		S3Endpoint:           os.Getenv("S3_ENDPOINT"),
+		S3Bucket:             os.Getenv("S3_BUCKET"),
+		S3Region:             os.Getenv("S3_REGION"),
+		S3AccessKey:          os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:          os.Getenv("S3_SECRET_KEY"),
+		ProductChangeEvents:  productChangeEvents,
+		KafkaBrokers:         kafkaBrokers,
+		SnapshotRetention:    snapshotRetention,
+		PageArchiveDir:       pageArchiveDir,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,293 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+)
+
+const (
+	defaultCatalogPageSize = 50
+	maxCatalogPageSize     = 200
+	// maxRatingHistory bounds the rating changes GetSeller returns.
+	maxRatingHistory = 500
+)
+
+// brandPriceStatsQuery and sellerPriceStatsQuery summarise active offers per
+// brand or seller and currency.
+const brandPriceStatsQuery = `
+SELECT p.brand_id AS owner_id, o.currency, COUNT(*) AS offer_count,
+    MIN(o.price) AS min_price, AVG(o.price) AS avg_price, MAX(o.price) AS max_price
+FROM offers o
+JOIN product_variants v ON v.id = o.variant_id
+JOIN products p ON p.id = v.product_id
+WHERE o.is_active AND p.brand_id IN @ids
+GROUP BY p.brand_id, o.currency
+ORDER BY p.brand_id, o.currency`
+
+const sellerPriceStatsQuery = `
+SELECT o.seller_id AS owner_id, o.currency, COUNT(*) AS offer_count,
+    MIN(o.price) AS min_price, AVG(o.price) AS avg_price, MAX(o.price) AS max_price
+FROM offers o
+WHERE o.is_active AND o.seller_id IN @ids
+GROUP BY o.seller_id, o.currency
+ORDER BY o.seller_id, o.currency`
+
+// ListBrands implements the ListBrands RPC method
+func (s *CrawlerService) ListBrands(ctx context.Context, req *pb.ListBrandsRequest) (*pb.ListBrandsResponse, error) {
+	offset, limit, err := catalogPage(req.Page, req.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	query := db.Model(&models.Brand{})
+	if req.Query != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(req.Query)+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count brands: %v", err)
+	}
+	var brands []models.Brand
+	if err := query.Order("name, id").Offset(offset).Limit(limit).Find(&brands).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch brands: %v", err)
+	}
+
+	pbBrands, err := s.brandsToProto(db, brands)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListBrandsResponse{Brands: pbBrands, Total: int32(total)}, nil
+}
+
+// GetBrand implements the GetBrand RPC method
+func (s *CrawlerService) GetBrand(ctx context.Context, req *pb.GetBrandRequest) (*pb.GetBrandResponse, error) {
+	brandID, err := strconv.ParseUint(req.Id, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid brand ID: %v", req.Id)
+	}
+
+	db := s.db.WithContext(ctx)
+	var brand models.Brand
+	result := db.First(&brand, brandID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "brand %d not found", brandID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch brand: %v", result.Error)
+	}
+
+	pbBrands, err := s.brandsToProto(db, []models.Brand{brand})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBrandResponse{Brand: pbBrands[0]}, nil
+}
+
+// ListSellers implements the ListSellers RPC method
+func (s *CrawlerService) ListSellers(ctx context.Context, req *pb.ListSellersRequest) (*pb.ListSellersResponse, error) {
+	offset, limit, err := catalogPage(req.Page, req.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	query := db.Model(&models.Seller{})
+	if req.Query != "" {
+		query = query.Where("name ILIKE ?", "%"+escapeLike(req.Query)+"%")
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count sellers: %v", err)
+	}
+	var sellers []models.Seller
+	if err := query.Order("name, id").Offset(offset).Limit(limit).Find(&sellers).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sellers: %v", err)
+	}
+
+	pbSellers, err := s.sellersToProto(db, sellers)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListSellersResponse{Sellers: pbSellers, Total: int32(total)}, nil
+}
+
+// GetSeller implements the GetSeller RPC method
+func (s *CrawlerService) GetSeller(ctx context.Context, req *pb.GetSellerRequest) (*pb.GetSellerResponse, error) {
+	sellerID, err := strconv.ParseUint(req.Id, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid seller ID: %v", req.Id)
+	}
+
+	db := s.db.WithContext(ctx)
+	var seller models.Seller
+	result := db.First(&seller, sellerID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "seller %d not found", sellerID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch seller: %v", result.Error)
+	}
+
+	pbSellers, err := s.sellersToProto(db, []models.Seller{seller})
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest changes, returned oldest first
+	var ratings []models.SellerRating
+	if err := db.Where("seller_id = ?", seller.ID).
+		Order("recorded_at DESC, id DESC").
+		Limit(maxRatingHistory).
+		Find(&ratings).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch seller ratings: %v", err)
+	}
+	history := make([]*pb.SellerRating, len(ratings))
+	for i, r := range ratings {
+		history[len(ratings)-1-i] = &pb.SellerRating{
+			Rating:     float32(r.Rating),
+			RecordedAt: r.RecordedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &pb.GetSellerResponse{Seller: pbSellers[0], RatingHistory: history}, nil
+}
+
+func (s *CrawlerService) brandsToProto(db *gorm.DB, brands []models.Brand) ([]*pb.Brand, error) {
+	ids := make([]uint, len(brands))
+	for i, b := range brands {
+		ids[i] = b.ID
+	}
+	counts, err := catalogCounts(db.Model(&models.Product{}).
+		Select("brand_id AS owner_id, COUNT(*) AS product_count").
+		Where("brand_id IN ? AND is_active", ids).
+		Group("brand_id"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count brand products: %v", err)
+	}
+	prices, err := catalogPriceStats(db, brandPriceStatsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand prices: %v", err)
+	}
+
+	pbBrands := make([]*pb.Brand, len(brands))
+	for i, b := range brands {
+		pbBrands[i] = &pb.Brand{
+			Id:           fmt.Sprint(b.ID),
+			ExternalId:   b.ExternalID,
+			Name:         b.Name,
+			ProductCount: int32(counts[b.ID]),
+			Prices:       prices[b.ID],
+		}
+	}
+	return pbBrands, nil
+}
+
+func (s *CrawlerService) sellersToProto(db *gorm.DB, sellers []models.Seller) ([]*pb.Seller, error) {
+	ids := make([]uint, len(sellers))
+	for i, seller := range sellers {
+		ids[i] = seller.ID
+	}
+	counts, err := catalogCounts(db.Table("offers o").
+		Joins("JOIN product_variants v ON v.id = o.variant_id").
+		Select("o.seller_id AS owner_id, COUNT(DISTINCT v.product_id) AS product_count").
+		Where("o.seller_id IN ? AND o.is_active", ids).
+		Group("o.seller_id"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count seller products: %v", err)
+	}
+	prices, err := catalogPriceStats(db, sellerPriceStatsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seller prices: %v", err)
+	}
+
+	pbSellers := make([]*pb.Seller, len(sellers))
+	for i, seller := range sellers {
+		pbSellers[i] = &pb.Seller{
+			Id:           fmt.Sprint(seller.ID),
+			ExternalId:   seller.ExternalID,
+			Name:         seller.Name,
+			ProductCount: int32(counts[seller.ID]),
+			Prices:       prices[seller.ID],
+		}
+		if seller.Rating != nil {
+			pbSellers[i].Rating = float32(*seller.Rating)
+		}
+	}
+	return pbSellers, nil
+}
+
+// catalogCounts runs a query selecting owner_id and product_count.
+func catalogCounts(query *gorm.DB) (map[uint]int64, error) {
+	var rows []struct {
+		OwnerID      uint
+		ProductCount int64
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[uint]int64, len(rows))
+	for _, r := range rows {
+		counts[r.OwnerID] = r.ProductCount
+	}
+	return counts, nil
+}
+
+func catalogPriceStats(db *gorm.DB, query string, ids []uint) (map[uint][]*pb.PriceStats, error) {
+	var rows []struct {
+		OwnerID    uint
+		Currency   string
+		OfferCount int64
+		MinPrice   decimal.Decimal
+		AvgPrice   decimal.Decimal
+		MaxPrice   decimal.Decimal
+	}
+	if err := db.Raw(query, map[string]interface{}{"ids": ids}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	stats := make(map[uint][]*pb.PriceStats)
+	for _, r := range rows {
+		stats[r.OwnerID] = append(stats[r.OwnerID], &pb.PriceStats{
+			Currency:   strings.TrimSpace(r.Currency),
+			OfferCount: int32(r.OfferCount),
+			MinPrice:   r.MinPrice.StringFixed(2),
+			AvgPrice:   r.AvgPrice.StringFixed(2),
+			MaxPrice:   r.MaxPrice.StringFixed(2),
+		})
+	}
+	return stats, nil
+}
+
+// catalogPage turns a zero-based page and a page size into an offset and a
+// limit.
+func catalogPage(page, perPage int32) (int, int, error) {
+	if page < 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid page %d", page)
+	}
+	switch {
+	case perPage < 0:
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid per_page %d", perPage)
+	case perPage == 0:
+		perPage = defaultCatalogPageSize
+	case perPage > maxCatalogPageSize:
+		perPage = maxCatalogPageSize
+	}
+	return int(page) * int(perPage), int(perPage), nil
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied search term.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
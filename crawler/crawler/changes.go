@@ -0,0 +1,239 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// productChangeEvent is the event type published for a product whose
+// details changed.
+const productChangeEvent = "product_change"
+
+// storedProduct is what a product looked like before a crawl is saved.
+type storedProduct struct {
+	Product    models.Product
+	Attributes map[string]string
+	ImageURLs  map[string]bool
+}
+
+// loadStoredProduct returns the stored state of a product, or nil for a
+// product crawled for the first time.
+func loadStoredProduct(tx *gorm.DB, externalID string) (*storedProduct, error) {
+	var product models.Product
+	result := tx.Where("external_id = ?", externalID).Take(&product)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get stored product: %v", result.Error)
+	}
+
+	var attributes []models.ProductAttribute
+	if err := tx.Where("product_id = ?", product.ID).Find(&attributes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stored attributes: %v", err)
+	}
+	var urls []string
+	if err := tx.Model(&models.ProductImage{}).Where("product_id = ?", product.ID).Pluck("url", &urls).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stored images: %v", err)
+	}
+
+	stored := &storedProduct{
+		Product:    product,
+		Attributes: make(map[string]string, len(attributes)),
+		ImageURLs:  make(map[string]bool, len(urls)),
+	}
+	for _, a := range attributes {
+		stored.Attributes[a.AttributeName] = a.AttributeValue
+	}
+	for _, url := range urls {
+		stored.ImageURLs[url] = true
+	}
+	return stored, nil
+}
+
+// diffProduct compares a crawled product with its stored state, the same
+// way saveProduct, saveAttributes and saveImages will store it.
+func diffProduct(stored *storedProduct, data *analysispb.ProductData, now time.Time) []models.ProductChange {
+	var changes []models.ProductChange
+	change := func(field, key string, oldValue, newValue *string) {
+		changes = append(changes, models.ProductChange{
+			ProductID: stored.Product.ID,
+			Field:     field,
+			Key:       key,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			ChangedAt: now,
+		})
+	}
+
+	for _, f := range []struct {
+		field         string
+		before, after string
+	}{
+		{models.ChangeName, stored.Product.Name, data.Name},
+		{models.ChangeDescription, stored.Product.Description, data.Description},
+		{models.ChangeSizeRecommendation, stored.Product.SizeRecommendation, data.SizeRecommendation},
+		{models.ChangeEstimatedDelivery, stored.Product.EstimatedDelivery, data.EstimatedDelivery},
+	} {
+		if f.before != f.after {
+			before, after := f.before, f.after
+			change(f.field, "", &before, &after)
+		}
+	}
+
+	crawled := make(map[string]string, len(data.Attributes))
+	for _, a := range data.Attributes {
+		if a.Name != "" && a.Value != "" {
+			crawled[a.Name] = a.Value
+		}
+	}
+	for _, name := range sortedKeys(stored.Attributes, crawled) {
+		before, hadBefore := stored.Attributes[name]
+		after, hasAfter := crawled[name]
+		switch {
+		case !hadBefore:
+			change(models.ChangeAttribute, name, nil, &after)
+		case !hasAfter:
+			change(models.ChangeAttribute, name, &before, nil)
+		case before != after:
+			change(models.ChangeAttribute, name, &before, &after)
+		}
+	}
+
+	crawledURLs := make(map[string]bool, len(data.Images))
+	for _, image := range data.Images {
+		if image.Url != "" {
+			crawledURLs[image.Url] = true
+		}
+	}
+	for _, url := range sortedKeys(stored.ImageURLs, crawledURLs) {
+		url := url
+		switch {
+		case !stored.ImageURLs[url]:
+			change(models.ChangeImage, url, nil, &url)
+		case !crawledURLs[url]:
+			change(models.ChangeImage, url, &url, nil)
+		}
+	}
+	return changes
+}
+
+// sortedKeys returns the keys of a and b, each once, in order.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// publishProductChanges tells subscribers which fields of a product
+// changed. It does nothing when change events are disabled.
+func (s *CrawlerService) publishProductChanges(productID uint, changes []models.ProductChange) error {
+	if s.eventWriter == nil || len(changes) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var fields []string
+	for _, c := range changes {
+		if !seen[c.Field] {
+			seen[c.Field] = true
+			fields = append(fields, c.Field)
+		}
+	}
+	event, err := json.Marshal(map[string]interface{}{
+		"type": productChangeEvent,
+		"data": map[string]interface{}{
+			"product_id": fmt.Sprint(productID),
+			"fields":     fields,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return s.eventWriter.WriteMessages(ctx, kafka.Message{Key: []byte(fmt.Sprint(productID)), Value: event})
+}
+
+// GetProductHistory implements the GetProductHistory RPC method
+func (s *CrawlerService) GetProductHistory(ctx context.Context, req *pb.GetProductHistoryRequest) (*pb.GetProductHistoryResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", req.ProductId)
+	}
+	offset, limit, err := catalogPage(req.Page, req.PerPage)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Field {
+	case "", models.ChangeName, models.ChangeDescription, models.ChangeSizeRecommendation,
+		models.ChangeEstimatedDelivery, models.ChangeAttribute, models.ChangeImage:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", req.Field)
+	}
+
+	db := s.db.WithContext(ctx)
+	var product models.Product
+	result := db.Select("id").First(&product, productID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch product: %v", result.Error)
+	}
+
+	query := db.Model(&models.ProductChange{}).Where("product_id = ?", productID)
+	if req.Field != "" {
+		query = query.Where("field = ?", req.Field)
+	}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since: %v", err)
+		}
+		query = query.Where("changed_at >= ?", since)
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count product changes: %v", err)
+	}
+	var changes []models.ProductChange
+	if err := query.Order("changed_at DESC, id DESC").Offset(offset).Limit(limit).Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch product changes: %v", err)
+	}
+
+	resp := &pb.GetProductHistoryResponse{Total: int32(total)}
+	for _, c := range changes {
+		change := &pb.ProductChange{
+			Field:     c.Field,
+			Key:       c.Key,
+			OldValue:  c.OldValue,
+			NewValue:  c.NewValue,
+			ChangedAt: c.ChangedAt.Format(time.RFC3339),
+		}
+		resp.Changes = append(resp.Changes, change)
+	}
+	return resp, nil
+}
@@ -0,0 +1,252 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/imagehash"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/imagestore"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+)
+
+const (
+	// maxImageSize bounds how much of an image is downloaded.
+	maxImageSize = 10 << 20
+	// maxImageChanges bounds the changes ListProductImages returns.
+	maxImageChanges = 100
+)
+
+// fetchedImage is an image download. NotModified is set when the server
+// confirmed the archived copy is still current; Data is empty then.
+type fetchedImage struct {
+	Data         []byte
+	ContentType  string
+	ETag         string
+	LastModified string
+	NotModified  bool
+}
+
+// archiveImages downloads a product's pictures into the image store and
+// records their perceptual hashes. Images archived before are revalidated
+// with their ETag or Last-Modified, and one that now serves different
+// content is recorded as an ImageChange. Videos are skipped. Downloads are
+// counted towards run; revalidations that download nothing are not.
+func (s *CrawlerService) archiveImages(run *crawlRun, productExternalID string) error {
+	ctx := context.Background()
+	var images []models.ProductImage
+	if err := s.db.Where("product_id = (?) AND NOT is_video",
+		s.db.Model(&models.Product{}).Select("id").Where("external_id = ?", productExternalID)).
+		Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to get images: %v", err)
+	}
+
+	var failed []string
+	for _, image := range images {
+		if err := s.archiveImage(ctx, run, image); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", image.URL, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to archive %d of %d images: %s", len(failed), len(images), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (s *CrawlerService) archiveImage(ctx context.Context, run *crawlRun, image models.ProductImage) error {
+	now := time.Now()
+	fetched, err := s.fetchImage(ctx, image)
+	if err != nil {
+		return err
+	}
+	if fetched.NotModified {
+		return s.db.Model(&image).Update("checked_at", now).Error
+	}
+	run.imageFetched(len(fetched.Data))
+
+	key, err := s.imageStore.Put(ctx, fetched.Data, fetched.ContentType)
+	if err != nil {
+		return err
+	}
+	// Formats the decoders do not know, such as WebP, are archived without
+	// a perceptual hash
+	var imageHash *int64
+	if hash, err := imagehash.Decode(bytes.NewReader(fetched.Data)); err == nil {
+		h := int64(hash)
+		imageHash = &h
+	}
+
+	updates := map[string]interface{}{
+		"content_hash":  key,
+		"content_type":  fetched.ContentType,
+		"etag":          fetched.ETag,
+		"last_modified": fetched.LastModified,
+		"image_hash":    imageHash,
+		"checked_at":    now,
+	}
+	if key == image.ContentHash {
+		return s.db.Model(&image).Updates(updates).Error
+	}
+	updates["archived_at"] = now
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if image.ContentHash != "" {
+			change := models.ImageChange{
+				ProductID:      image.ProductID,
+				URL:            image.URL,
+				OldContentHash: image.ContentHash,
+				NewContentHash: key,
+				OldImageHash:   image.ImageHash,
+				NewImageHash:   imageHash,
+				DetectedAt:     now,
+			}
+			if image.ImageHash != nil && imageHash != nil {
+				distance := imagehash.Distance(uint64(*image.ImageHash), uint64(*imageHash))
+				change.Distance = &distance
+			}
+			if err := tx.Create(&change).Error; err != nil {
+				return fmt.Errorf("failed to record image change: %v", err)
+			}
+		}
+		return tx.Model(&image).Updates(updates).Error
+	})
+}
+
+func (s *CrawlerService) fetchImage(ctx context.Context, image models.ProductImage) (*fetchedImage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, image.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build image request: %v", err)
+	}
+	if image.ContentHash != "" {
+		if image.ETag != "" {
+			req.Header.Set("If-None-Match", image.ETag)
+		}
+		if image.LastModified != "" {
+			req.Header.Set("If-Modified-Since", image.LastModified)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return &fetchedImage{NotModified: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %v", err)
+	}
+	if len(data) > maxImageSize {
+		return nil, fmt.Errorf("image is larger than %d bytes", maxImageSize)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return &fetchedImage{
+		Data:         data,
+		ContentType:  contentType,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// ListProductImages implements the ListProductImages RPC method
+func (s *CrawlerService) ListProductImages(ctx context.Context, req *pb.ListProductImagesRequest) (*pb.ListProductImagesResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", req.ProductId)
+	}
+
+	db := s.db.WithContext(ctx)
+	var product models.Product
+	result := db.Select("id").First(&product, productID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch product: %v", result.Error)
+	}
+
+	var images []models.ProductImage
+	if err := db.Where("product_id = ?", productID).Order("sort_order, id").Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch images: %v", err)
+	}
+	var changes []models.ImageChange
+	if err := db.Where("product_id = ?", productID).
+		Order("detected_at DESC, id DESC").
+		Limit(maxImageChanges).
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch image changes: %v", err)
+	}
+
+	resp := &pb.ListProductImagesResponse{}
+	for _, image := range images {
+		pbImage := &pb.ProductImage{
+			Url:         image.URL,
+			SortOrder:   int32(image.SortOrder),
+			IsVideo:     image.IsVideo,
+			ContentHash: strings.TrimSpace(image.ContentHash),
+			ContentType: image.ContentType,
+			ArchivedAt:  formatOptionalTime(image.ArchivedAt),
+			CheckedAt:   formatOptionalTime(image.CheckedAt),
+		}
+		if image.ImageHash != nil {
+			pbImage.ImageHash = fmt.Sprintf("%016x", uint64(*image.ImageHash))
+		}
+		resp.Images = append(resp.Images, pbImage)
+	}
+	for _, change := range changes {
+		distance := int32(-1)
+		if change.Distance != nil {
+			distance = int32(*change.Distance)
+		}
+		resp.Changes = append(resp.Changes, &pb.ImageChange{
+			Url:            change.URL,
+			OldContentHash: strings.TrimSpace(change.OldContentHash),
+			NewContentHash: strings.TrimSpace(change.NewContentHash),
+			Distance:       distance,
+			DetectedAt:     change.DetectedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// GetImage implements the GetImage RPC method
+func (s *CrawlerService) GetImage(ctx context.Context, req *pb.GetImageRequest) (*pb.GetImageResponse, error) {
+	if !imagestore.ValidKey(req.ContentHash) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid content hash: %q", req.ContentHash)
+	}
+	data, err := s.imageStore.Get(ctx, req.ContentHash)
+	if errors.Is(err, imagestore.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "image %s not found", req.ContentHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %v", err)
+	}
+	return &pb.GetImageResponse{Data: data, ContentType: http.DetectContentType(data)}, nil
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
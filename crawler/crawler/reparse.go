@@ -0,0 +1,230 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/pagearchive"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/scraper"
+)
+
+// RunReparseCommand handles `crawler-service reparse [flags]`. It replays
+// archived pages through the current parsers and upserts what they
+// extract, printing every field that changes. With -dry-run nothing is
+// stored; each page is then compared with the database as it is, so a page
+// does not see what an earlier page would have changed.
+func RunReparseCommand(ctx context.Context, db *gorm.DB, archive *pagearchive.Archive, args []string) error {
+	flags := flag.NewFlagSet("reparse", flag.ContinueOnError)
+	kind := flags.String("kind", "", "only replay pages of this kind: categories_html, categories_api, product or reviews")
+	urlPrefix := flags.String("url", "", "only replay pages whose URL starts with this prefix")
+	since := flags.String("since", "", "only replay pages fetched at or after this RFC3339 time")
+	until := flags.String("until", "", "only replay pages fetched before this RFC3339 time")
+	dryRun := flags.Bool("dry-run", false, "report field changes without storing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter := pagearchive.Filter{Kind: *kind, URLPrefix: *urlPrefix}
+	switch filter.Kind {
+	case "", pagearchive.KindCategoriesHTML, pagearchive.KindCategoriesAPI, pagearchive.KindProduct, pagearchive.KindReviews:
+	default:
+		return fmt.Errorf("unknown page kind %q", filter.Kind)
+	}
+	var err error
+	if *since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, *since); err != nil {
+			return fmt.Errorf("invalid -since: %v", err)
+		}
+	}
+	if *until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, *until); err != nil {
+			return fmt.Errorf("invalid -until: %v", err)
+		}
+	}
+
+	pages, err := archive.Pages(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var changed, failed int
+	for _, page := range pages {
+		diffs, err := reparsePage(ctx, db, archive, page, *dryRun)
+		if err != nil {
+			log.Printf("Failed to reparse %s fetched at %s: %v", page.URL, page.FetchedAt.Format(time.RFC3339), err)
+			failed++
+			continue
+		}
+		for _, d := range diffs {
+			fmt.Printf("%s\t%s %s %s: %q -> %q\n", page.URL, d.Entity, d.Key, d.Field, d.Old, d.New)
+		}
+		changed += len(diffs)
+	}
+
+	verb := "Changed"
+	if *dryRun {
+		verb = "Would change"
+	}
+	log.Printf("Reparsed %d page(s): %s %d field(s), %d page(s) failed", len(pages)-failed, verb, changed, failed)
+	return nil
+}
+
+// reparsePage replays one archived page in a transaction of its own.
+func reparsePage(ctx context.Context, db *gorm.DB, archive *pagearchive.Archive, page models.FetchedPage, dryRun bool) ([]scraper.FieldDiff, error) {
+	body, err := archive.Open(page)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := scraper.SaveUpdate
+	if dryRun {
+		mode = scraper.SaveDryRun
+	}
+	var diffs []scraper.FieldDiff
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		switch page.Kind {
+		case pagearchive.KindCategoriesHTML, pagearchive.KindCategoriesAPI:
+			parse := scraper.ParseCategoriesHTML
+			if page.Kind == pagearchive.KindCategoriesAPI {
+				parse = scraper.ParseCategoriesAPI
+			}
+			categories, err := parse(bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			diffs, err = scraper.SaveCategories(tx, categories, page.Kind == pagearchive.KindCategoriesAPI, mode)
+			return err
+
+		case pagearchive.KindProduct:
+			diffs, err = reparseProduct(tx, body, dryRun)
+			return err
+
+		case pagearchive.KindReviews:
+			diffs, err = reparseReviews(tx, page, body, dryRun)
+			return err
+
+		default:
+			return fmt.Errorf("no parser for pages of kind %q", page.Kind)
+		}
+	})
+	return diffs, err
+}
+
+// reparseProduct replays a product page, storing the product it shows as
+// a crawl would. Only products already crawled are replayed, as the page
+// does not say which of our categories the product is in. The diffs cover
+// the product's details; variant prices and stock are left to analysis.
+func reparseProduct(tx *gorm.DB, body []byte, dryRun bool) ([]scraper.FieldDiff, error) {
+	data, err := scraper.ParseProductPage(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := loadStoredProduct(tx, data.Id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("product %s not found", data.Id)
+	}
+
+	now := time.Now()
+	changes := diffProduct(stored, data, now)
+	if !dryRun {
+		saved, err := storeProduct(tx, stored.Product.CategoryID, body, data, now)
+		if err != nil {
+			return nil, err
+		}
+		changes = saved.Changes
+	}
+
+	diffs := make([]scraper.FieldDiff, len(changes))
+	for i, c := range changes {
+		field := c.Field
+		if c.Key != "" {
+			field += " " + c.Key
+		}
+		diffs[i] = scraper.FieldDiff{Entity: "product", Key: data.Id, Field: field, Old: optionalString(c.OldValue), New: optionalString(c.NewValue)}
+	}
+	return diffs, nil
+}
+
+// reparseReviews replays a page of a product's reviews, overwriting the
+// stored reviews it lists.
+func reparseReviews(tx *gorm.DB, page models.FetchedPage, body []byte, dryRun bool) ([]scraper.FieldDiff, error) {
+	externalID, ok := scraper.ReviewsProductID(page.URL)
+	if !ok {
+		return nil, fmt.Errorf("no product in reviews URL")
+	}
+	var product models.Product
+	result := tx.Select("id").Where("external_id = ?", externalID).Limit(1).Find(&product)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get product: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, fmt.Errorf("product %s not found", externalID)
+	}
+
+	scraped, err := scraper.ParseReviews(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(scraped) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(scraped))
+	for i, r := range scraped {
+		ids[i] = r.ExternalID
+	}
+	var stored []models.Review
+	if err := tx.Where("product_id = ? AND external_review_id IN ?", product.ID, ids).Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stored reviews: %v", err)
+	}
+	byID := make(map[string]models.Review, len(stored))
+	for _, r := range stored {
+		byID[r.ExternalReviewID] = r
+	}
+
+	var diffs []scraper.FieldDiff
+	for _, r := range scraped {
+		before, found := byID[r.ExternalID]
+		var beforeDate string
+		if before.ReviewDate != nil {
+			beforeDate = before.ReviewDate.UTC().Format(time.RFC3339)
+		}
+		for _, f := range []struct {
+			field         string
+			before, after string
+		}{
+			{"rating", strconv.Itoa(before.Rating), strconv.Itoa(r.Rating)},
+			{"comment", before.Comment, r.Comment},
+			{"reviewer_name", before.ReviewerName, r.ReviewerName},
+			{"review_date", beforeDate, r.Date.UTC().Format(time.RFC3339)},
+			{"is_top_review", strconv.FormatBool(before.IsTopReview), strconv.FormatBool(r.IsTopReview)},
+		} {
+			if !found {
+				f.before = ""
+			}
+			if !found || f.before != f.after {
+				diffs = append(diffs, scraper.FieldDiff{Entity: "review", Key: r.ExternalID, Field: f.field, Old: f.before, New: f.after})
+			}
+		}
+	}
+	if dryRun || len(diffs) == 0 {
+		return diffs, nil
+	}
+	return diffs, saveReviews(tx, product.ID, scraped, true)
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,166 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+)
+
+// maxCrawlRunErrors bounds the errors a crawl run keeps as samples.
+const maxCrawlRunErrors = 20
+
+// crawlRun records the progress of a crawl of one category as a
+// models.CrawlRun. It is used by the goroutine running the crawl only.
+type crawlRun struct {
+	db  *gorm.DB
+	run models.CrawlRun
+}
+
+func startCrawlRun(db *gorm.DB, categoryID uint, trigger string) (*crawlRun, error) {
+	r := &crawlRun{db: db, run: models.CrawlRun{
+		CategoryID: categoryID,
+		Trigger:    trigger,
+		Status:     models.CrawlRunning,
+		StartedAt:  time.Now(),
+	}}
+	if err := db.Create(&r.run).Error; err != nil {
+		return nil, fmt.Errorf("failed to record crawl run: %v", err)
+	}
+	return r, nil
+}
+
+// fetched counts a page downloaded by the run.
+func (r *crawlRun) fetched(size int) {
+	r.run.PagesFetched++
+	r.run.BytesTransferred += int64(size)
+}
+
+// imageFetched counts an image downloaded by the run. Images are counted
+// apart from pages, but their bytes are transferred all the same.
+func (r *crawlRun) imageFetched(size int) {
+	r.run.ImagesFetched++
+	r.run.BytesTransferred += int64(size)
+}
+
+// errorf logs an error the run hit and counts it, keeping the first ones
+// as samples.
+func (r *crawlRun) errorf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	log.Print(message)
+	r.run.ErrorCount++
+	if r.run.ErrorCount > maxCrawlRunErrors {
+		return
+	}
+	sample := models.CrawlRunError{CrawlRunID: r.run.ID, Message: message, OccurredAt: time.Now()}
+	if err := r.db.Create(&sample).Error; err != nil {
+		log.Printf("Failed to record error of crawl run %d: %v", r.run.ID, err)
+	}
+}
+
+// finish stores the run's statistics with its final status.
+func (r *crawlRun) finish(runStatus string) {
+	now := time.Now()
+	r.run.Status = runStatus
+	r.run.FinishedAt = &now
+	if err := r.db.Save(&r.run).Error; err != nil {
+		log.Printf("Failed to save crawl run %d: %v", r.run.ID, err)
+	}
+}
+
+// ListCrawlRuns implements the ListCrawlRuns RPC method
+func (s *CrawlerService) ListCrawlRuns(ctx context.Context, req *pb.ListCrawlRunsRequest) (*pb.ListCrawlRunsResponse, error) {
+	categoryID, err := strconv.ParseUint(req.CategoryId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid category ID: %v", req.CategoryId)
+	}
+	offset, limit, err := catalogPage(req.Page, req.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	var category models.Category
+	result := db.Select("id").First(&category, categoryID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "category %d not found", categoryID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch category: %v", result.Error)
+	}
+
+	query := db.Model(&models.CrawlRun{}).Where("category_id = ?", categoryID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count crawl runs: %v", err)
+	}
+	var runs []models.CrawlRun
+	if err := query.Order("started_at DESC, id DESC").Offset(offset).Limit(limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch crawl runs: %v", err)
+	}
+
+	resp := &pb.ListCrawlRunsResponse{Total: int32(total)}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, toPBCrawlRun(run))
+	}
+	return resp, nil
+}
+
+// GetCrawlRun implements the GetCrawlRun RPC method
+func (s *CrawlerService) GetCrawlRun(ctx context.Context, req *pb.GetCrawlRunRequest) (*pb.GetCrawlRunResponse, error) {
+	runID, err := strconv.ParseUint(req.Id, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid crawl run ID: %v", req.Id)
+	}
+
+	db := s.db.WithContext(ctx)
+	var run models.CrawlRun
+	result := db.First(&run, runID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "crawl run %d not found", runID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch crawl run: %v", result.Error)
+	}
+	var samples []models.CrawlRunError
+	if err := db.Where("crawl_run_id = ?", runID).Order("occurred_at, id").Find(&samples).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch crawl run errors: %v", err)
+	}
+
+	resp := &pb.GetCrawlRunResponse{Run: toPBCrawlRun(run)}
+	for _, sample := range samples {
+		resp.Errors = append(resp.Errors, &pb.CrawlRunError{
+			Message:    sample.Message,
+			OccurredAt: sample.OccurredAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+func toPBCrawlRun(run models.CrawlRun) *pb.CrawlRun {
+	return &pb.CrawlRun{
+		Id:                  fmt.Sprint(run.ID),
+		CategoryId:          fmt.Sprint(run.CategoryID),
+		Trigger:             run.Trigger,
+		Status:              run.Status,
+		StartedAt:           run.StartedAt.Format(time.RFC3339),
+		FinishedAt:          formatOptionalTime(run.FinishedAt),
+		PagesFetched:        int32(run.PagesFetched),
+		ImagesFetched:       int32(run.ImagesFetched),
+		BytesTransferred:    run.BytesTransferred,
+		ProductsFound:       int32(run.ProductsFound),
+		ProductsNew:         int32(run.ProductsNew),
+		ProductsUpdated:     int32(run.ProductsUpdated),
+		ProductsDeactivated: int32(run.ProductsDeactivated),
+		ErrorCount:          int32(run.ErrorCount),
+	}
+}
@@ -2,32 +2,56 @@ package crawler
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/segmentio/kafka-go"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/faisaloncode/ecommerce-crawler/crawler/imagestore"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
 	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/scraper"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
 )
 
+// analysisTimeout bounds streaming one category's products to Product
+// Analysis Service.
+const analysisTimeout = 5 * time.Minute
+
 type CrawlerService struct {
 	pb.UnimplementedCrawlerServiceServer
 	db                    *gorm.DB
-	productAnalysisClient pb.ProductAnalysisServiceClient
+	productAnalysisClient analysispb.ProductAnalysisServiceClient
 	categoryScraper      *scraper.CategoryScraper
+	productScraper        *scraper.ProductScraper
+	reviewScraper         *scraper.ReviewScraper
+	imageStore            imagestore.Store
+	eventWriter           *kafka.Writer
 	httpClient            *http.Client
 	baseURL               string
 }
 
-func NewCrawlerService(db *gorm.DB, productAnalysisClient pb.ProductAnalysisServiceClient, categoryScraper *scraper.CategoryScraper) *CrawlerService {
+func NewCrawlerService(db *gorm.DB, productAnalysisClient analysispb.ProductAnalysisServiceClient, categoryScraper *scraper.CategoryScraper, productScraper *scraper.ProductScraper, reviewScraper *scraper.ReviewScraper, imageStore imagestore.Store, eventWriter *kafka.Writer) *CrawlerService {
 	return &CrawlerService{
 		db:                    db,
 		productAnalysisClient: productAnalysisClient,
 		categoryScraper:      categoryScraper,
+		productScraper:        productScraper,
+		reviewScraper:         reviewScraper,
+		imageStore:            imageStore,
+		eventWriter:           eventWriter,
 		httpClient:            &http.Client{Timeout: 10 * time.Second},
 		baseURL:               "https://example.com",
 	}
@@ -41,7 +65,7 @@ func (s *CrawlerService) StartScheduler() {
 	for {
 		select {
 		case <-ticker.C:
-			s.CrawlAllCategories()
+			s.CrawlAllCategories(models.TriggerSchedule)
 		}
 	}
 }
@@ -73,7 +97,7 @@ func (s *CrawlerService) ListCategories(ctx context.Context, req *pb.ListCategor
 
 // RefreshCategories implements the RefreshCategories RPC method
 func (s *CrawlerService) RefreshCategories(ctx context.Context, req *pb.RefreshCategoriesRequest) (*pb.RefreshCategoriesResponse, error) {
-	go s.CrawlAllCategories()
+	go s.CrawlAllCategories(models.TriggerManual)
 	return &pb.RefreshCategoriesResponse{Status: "refresh started"}, nil
 }
 
@@ -103,8 +127,24 @@ func (s *CrawlerService) ListProducts(ctx context.Context, req *pb.ListProductsR
 
 // GetProduct implements the GetProduct RPC method
 func (s *CrawlerService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
+	productID, err := strconv.ParseUint(req.Id, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", req.Id)
+	}
+
+	if req.AsOf != "" {
+		asOf, err := time.Parse(time.RFC3339, req.AsOf)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid as_of: %v", err)
+		}
+		return s.getProductAsOf(ctx, productID, asOf, req.IncludeHtml)
+	}
+
 	var product models.Product
-	result := s.db.First(&product, req.Id)
+	result := s.db.First(&product, productID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productID)
+	}
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to fetch product: %v", result.Error)
 	}
@@ -119,7 +159,9 @@ func (s *CrawlerService) GetProduct(ctx context.Context, req *pb.GetProductReque
 	return &pb.GetProductResponse{Product: pbProduct}, nil
 }
 
-func (s *CrawlerService) CrawlAllCategories() {
+// CrawlAllCategories crawls every leaf category. trigger says what started
+// the crawl: models.TriggerSchedule or models.TriggerManual.
+func (s *CrawlerService) CrawlAllCategories(trigger string) {
 	var categories []models.Category
 	
 	// Get all leaf categories (those without children)
@@ -133,14 +175,16 @@ func (s *CrawlerService) CrawlAllCategories() {
 
 	log.Printf("Found %d categories to crawl", len(categories))
 	for _, category := range categories {
-		go s.CrawlCategory(fmt.Sprintf("%d", category.ID))
+		go s.CrawlCategory(fmt.Sprintf("%d", category.ID), trigger)
 		
 		// Add a small delay to avoid overwhelming the server
 		time.Sleep(500 * time.Millisecond)
 	}
 }
 
-func (s *CrawlerService) CrawlCategory(categoryID string) {
+// CrawlCategory crawls the products of a category and records the crawl
+// as a models.CrawlRun.
+func (s *CrawlerService) CrawlCategory(categoryID string, trigger string) {
 	log.Printf("Crawling category ID: %s", categoryID)
 
 	// Update crawl status
@@ -159,10 +203,18 @@ func (s *CrawlerService) CrawlCategory(categoryID string) {
 		return
 	}
 
+	run, err := startCrawlRun(s.db, category.ID, trigger)
+	if err != nil {
+		log.Printf("Failed to start crawl run of category %s: %v", categoryID, err)
+		status.Status = "failed"
+		s.db.Save(&status)
+		return
+	}
+
 	var products []models.Product
 
-	// Mock implementation for development
-	// Replace with real implementation in production
+	// Mock listing for development; the listed products' pages are
+	// fetched when a site is configured
 	products = append(products, models.Product{
 		ExternalID: "12345",
 		Name:       "Mock Product 1",
@@ -173,63 +225,710 @@ func (s *CrawlerService) CrawlCategory(categoryID string) {
 	})
 
 	log.Printf("Found %d products for category %s", len(products), categoryID)
+	run.run.ProductsFound = len(products)
+
+	// Mock products list each other as similar; an edge to itself is skipped
+	mockExternalIDs := make([]string, len(products))
+	for i, product := range products {
+		mockExternalIDs[i] = product.ExternalID
+	}
 
 	// Process each product
+	crawled := make([]*analysispb.ProductData, 0, len(products))
 	for _, product := range products {
-		// Mock implementation for development
-		productData := &pb.ProductData{
-			ExternalId:  product.ExternalID,
-			Name:        product.Name,
-			Description: "This is a mock product for testing",
-			Price:       99.99,
-			Stock:       10,
-			IsActive:    true,
-			CategoryId:  categoryID,
-			BrandId:     "45",
-			SellerId:    "67",
-			Images: []*pb.ProductImage{
-				{Url: "https://example.com/image1.jpg", IsVideo: false},
-			},
-			Variants: []*pb.ProductVariant{
-				{
-					ExternalVariantId: "variant-" + product.ExternalID + "-1",
-					Color:             "Red",
-					Size:              "M",
-					Price:             99.99,
-					Stock:             5,
-				},
-				{
-					ExternalVariantId: "variant-" + product.ExternalID + "-2",
-					Color:             "Blue",
-					Size:              "L",
-					Price:             109.99,
-					Stock:             5,
-				},
-			},
+		productData, page, err := s.crawlProduct(run, product, categoryID, mockExternalIDs)
+		if err != nil {
+			run.errorf("Failed to crawl product %s: %v", product.ExternalID, err)
+			continue
+		}
+		saved, err := s.saveProduct(category.ID, page, productData)
+		if err != nil {
+			run.errorf("Failed to save product %s: %v", productData.Id, err)
+			continue
+		}
+		switch {
+		case saved.Created:
+			run.run.ProductsNew++
+		case len(saved.Changes) > 0:
+			run.run.ProductsUpdated++
+		}
+		if err := s.publishProductChanges(saved.ID, saved.Changes); err != nil {
+			run.errorf("Failed to publish changes of product %s: %v", productData.Id, err)
+		}
+		if err := s.archiveImages(run, productData.Id); err != nil {
+			run.errorf("Failed to archive images of product %s: %v", productData.Id, err)
+		}
+		if err := s.crawlReviews(run, productData.Id); err != nil {
+			run.errorf("Failed to crawl reviews of product %s: %v", productData.Id, err)
+		}
+		crawled = append(crawled, productData)
+	}
+
+	// Products the category no longer lists are deactivated. An empty
+	// listing is more likely a failed crawl than an empty category, so it
+	// deactivates nothing.
+	if len(products) > 0 {
+		externalIDs := make([]string, len(products))
+		for i, product := range products {
+			externalIDs[i] = product.ExternalID
+		}
+		result := s.db.Model(&models.Product{}).
+			Where("category_id = ? AND is_active AND external_id NOT IN ?", category.ID, externalIDs).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()})
+		if result.Error != nil {
+			run.errorf("Failed to deactivate products of category %s: %v", categoryID, result.Error)
 		}
+		run.run.ProductsDeactivated = int(result.RowsAffected)
+	}
 
-		// Send to Product Analysis Service via gRPC
-		s.sendProductToAnalysis(productData)
-		
-		// Add a small delay to avoid overwhelming the server
-		time.Sleep(200 * time.Millisecond)
+	// Send the category's products to Product Analysis Service in one stream
+	if err := s.sendProductsToAnalysis(run, crawled); err != nil {
+		run.errorf("Failed to send products of category %s to analysis: %v", categoryID, err)
+	}
+
+	// A crawl that saved none of the products it found failed
+	if len(products) > 0 && len(crawled) == 0 {
+		status.Status = "failed"
+		s.db.Save(&status)
+		run.finish(models.CrawlFailed)
+		log.Printf("Failed crawling category ID: %s", categoryID)
+		return
 	}
 
 	// Update crawl status
 	status.Status = "completed"
 	s.db.Save(&status)
+	run.finish(models.CrawlCompleted)
 	log.Printf("Completed crawling category ID: %s", categoryID)
 }
 
-func (s *CrawlerService) sendProductToAnalysis(productData *pb.ProductData) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// crawlProduct fetches a listed product's page, counting it towards run,
+// and returns the product it shows with the page. Without a site to crawl
+// it returns mock data for development and no page.
+func (s *CrawlerService) crawlProduct(run *crawlRun, product models.Product, categoryID string, similarExternalIDs []string) (*analysispb.ProductData, []byte, error) {
+	if s.productScraper.Configured() {
+		data, page, err := s.productScraper.ScrapeProduct(context.Background(), product.ExternalID, run.fetched)
+		if err != nil {
+			return nil, nil, err
+		}
+		data.CategoryId = categoryID
+		return data, page, nil
+	}
+
+	return &analysispb.ProductData{
+		Id:           product.ExternalID,
+		Name:         product.Name,
+		Description:  "This is a mock product for testing",
+		IsActive:     true,
+		CategoryId:   categoryID,
+		BrandId:      "45",
+		BrandName:    "Mock Brand",
+		SellerId:     "67",
+		SellerName:   "Mock Seller",
+		SellerRating: 4.6,
+		Currency:     "TRY",
+		Images: []*analysispb.ProductImage{
+			{Url: "https://example.com/image1.jpg", IsVideo: false},
+		},
+		Variants: []*analysispb.ProductVariant{
+			{
+				Id:            "variant-" + product.ExternalID + "-1",
+				Color:         "Red",
+				Size:          "M",
+				Price:         99.99,
+				SalePrice:     "99.99",
+				ListPrice:     "119.99",
+				StockQuantity: 5,
+				IsActive:      true,
+			},
+			{
+				Id:            "variant-" + product.ExternalID + "-2",
+				Color:         "Blue",
+				Size:          "L",
+				Price:         109.99,
+				SalePrice:     "109.99",
+				StockQuantity: 5,
+				IsActive:      true,
+			},
+		},
+		Attributes: []*analysispb.ProductAttribute{
+			{Name: "Material", Value: "Cotton"},
+			{Name: "Pattern", Value: "Plain"},
+		},
+		SimilarProductIds: similarExternalIDs,
+		ShippingCost:      "0",
+		Offers: []*analysispb.Offer{
+			{
+				VariantId:     "variant-" + product.ExternalID + "-1",
+				SellerId:      "68",
+				SellerName:    "Mock Reseller",
+				Price:         "94.99",
+				StockQuantity: 2,
+				ShippingCost:  "9.99",
+			},
+		},
+	}, nil, nil
+}
+
+// savedProduct is what saveProduct stored: the product's ID, whether it
+// was crawled for the first time, and the changes to its details.
+type savedProduct struct {
+	ID      uint
+	Created bool
+	Changes []models.ProductChange
+}
+
+// saveProduct upserts a crawled product, its variants and its seller by
+// external ID, so product-analysis can resolve them when the product is
+// analysed, and snapshots it. html is the product page the data was parsed
+// from, or nil when it was not fetched.
+func (s *CrawlerService) saveProduct(categoryID uint, html []byte, data *analysispb.ProductData) (savedProduct, error) {
+	var saved savedProduct
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		saved, err = storeProduct(tx, &categoryID, html, data, time.Now())
+		return err
+	})
+	return saved, err
+}
+
+// storeProduct is saveProduct within a transaction. A nil categoryID
+// stores the product without a category.
+func storeProduct(tx *gorm.DB, categoryID *uint, html []byte, data *analysispb.ProductData, now time.Time) (savedProduct, error) {
+	var saved savedProduct
+	stored, err := loadStoredProduct(tx, data.Id)
+	if err != nil {
+		return saved, err
+	}
+	saved.Created = stored == nil
+	if stored != nil {
+		saved.Changes = diffProduct(stored, data, now)
+	}
+
+	sellerID, err := saveSeller(tx, data.SellerId, data.SellerName)
+	if err != nil {
+		return saved, err
+	}
+	if sellerID != 0 && data.SellerRating > 0 {
+		if err := recordSellerRating(tx, sellerID, float64(data.SellerRating), now); err != nil {
+			return saved, err
+		}
+	}
+	brandID, err := saveBrand(tx, data.BrandId, data.BrandName)
+	if err != nil {
+		return saved, err
+	}
+
+	product := models.Product{
+		ExternalID:         data.Id,
+		Name:               data.Name,
+		CategoryID:         categoryID,
+		BrandID:            brandID,
+		Description:        data.Description,
+		RatingScore:        float64(data.RatingScore),
+		FavoriteCount:      int(data.FavoriteCount),
+		CommentCount:       int(data.CommentCount),
+		ViewCount:          int(data.ViewCount),
+		AddToCartCount:     int(data.AddToCartCount),
+		OrderCount:         int(data.OrderCount),
+		SizeRecommendation: data.SizeRecommendation,
+		EstimatedDelivery:  data.EstimatedDelivery,
+		IsActive:           data.IsActive,
+		LastCrawledAt:      &now,
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "external_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "category_id", "brand_id", "description", "rating_score", "favorite_count", "comment_count",
+			"view_count", "add_to_cart_count", "order_count", "size_recommendation", "estimated_delivery",
+			"is_active", "updated_at", "last_crawled_at",
+		}),
+	}).Create(&product).Error
+	if err != nil {
+		return saved, fmt.Errorf("failed to save product: %v", err)
+	}
+	if err := saveImages(tx, product.ID, data.Images); err != nil {
+		return saved, err
+	}
+	if err := saveAttributes(tx, product.ID, data.Attributes); err != nil {
+		return saved, err
+	}
+	if err := saveSimilarProducts(tx, product.ID, data.Id, data.SimilarProductIds); err != nil {
+		return saved, err
+	}
+	saved.ID = product.ID
+	if err := saveSnapshot(tx, product.ID, html, data, now); err != nil {
+		return saved, err
+	}
+	if len(saved.Changes) > 0 {
+		if err := tx.Create(&saved.Changes).Error; err != nil {
+			return saved, fmt.Errorf("failed to record product changes: %v", err)
+		}
+	}
+
+	if len(data.Variants) == 0 {
+		return saved, nil
+	}
+	variants := make([]models.ProductVariant, len(data.Variants))
+	for i, v := range data.Variants {
+		variants[i] = models.ProductVariant{
+			ProductID:         product.ID,
+			SKU:               v.Sku,
+			ExternalVariantID: v.Id,
+			Color:             v.Color,
+			Size:              v.Size,
+			Price:             float64(v.Price),
+			StockQuantity:     int(v.StockQuantity),
+			IsActive:          v.IsActive,
+		}
+		if v.OriginalPrice > 0 {
+			originalPrice := float64(v.OriginalPrice)
+			variants[i].OriginalPrice = &originalPrice
+		}
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "product_id"}, {Name: "external_variant_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_variant_id IS NOT NULL"}}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"sku", "color", "size", "price", "original_price", "stock_quantity", "is_active", "updated_at",
+		}),
+	}).Create(&variants).Error
+	if err != nil {
+		return saved, fmt.Errorf("failed to save variants: %v", err)
+	}
+	return saved, saveOffers(tx, product.ID, sellerID, variants, data, now)
+}
+
+// saveImages upserts a product's images by URL and removes the ones no
+// longer listed. Known images keep their hashes and archived copy.
+func saveImages(tx *gorm.DB, productID uint, crawled []*analysispb.ProductImage) error {
+	seen := make(map[string]bool, len(crawled))
+	var images []models.ProductImage
+	var urls []string
+	for i, image := range crawled {
+		if image.Url == "" || seen[image.Url] {
+			continue
+		}
+		seen[image.Url] = true
+		urls = append(urls, image.Url)
+		sortOrder := int(image.SortOrder)
+		if sortOrder == 0 {
+			sortOrder = i
+		}
+		images = append(images, models.ProductImage{
+			ProductID: productID,
+			URL:       image.Url,
+			SortOrder: sortOrder,
+			IsVideo:   image.IsVideo,
+		})
+	}
+
+	remove := tx.Where("product_id = ?", productID)
+	if len(urls) > 0 {
+		remove = remove.Where("url NOT IN ?", urls)
+	}
+	if err := remove.Delete(&models.ProductImage{}).Error; err != nil {
+		return fmt.Errorf("failed to remove images: %v", err)
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sort_order", "is_video"}),
+	}).Create(&images).Error
+	if err != nil {
+		return fmt.Errorf("failed to save images: %v", err)
+	}
+	return nil
+}
+
+// saveAttributes replaces a product's attributes with the crawled ones.
+func saveAttributes(tx *gorm.DB, productID uint, crawled []*analysispb.ProductAttribute) error {
+	if err := tx.Where("product_id = ?", productID).Delete(&models.ProductAttribute{}).Error; err != nil {
+		return fmt.Errorf("failed to clear attributes: %v", err)
+	}
+	var attributes []models.ProductAttribute
+	for _, a := range crawled {
+		if a.Name == "" || a.Value == "" {
+			continue
+		}
+		attributes = append(attributes, models.ProductAttribute{
+			ProductID:      productID,
+			AttributeName:  a.Name,
+			AttributeValue: a.Value,
+		})
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	if err := tx.Create(&attributes).Error; err != nil {
+		return fmt.Errorf("failed to save attributes: %v", err)
+	}
+	return nil
+}
+
+// saveSimilarProducts replaces a product's similar-product edges with the
+// crawled ones, and resolves the edges of other products that point at it.
+func saveSimilarProducts(tx *gorm.DB, productID uint, externalID string, similarExternalIDs []string) error {
+	err := tx.Model(&models.SimilarProduct{}).
+		Where("similar_external_id = ? AND similar_product_id IS NULL", externalID).
+		Update("similar_product_id", productID).Error
+	if err != nil {
+		return fmt.Errorf("failed to resolve similar products: %v", err)
+	}
+
+	seen := make(map[string]bool, len(similarExternalIDs))
+	var edges []models.SimilarProduct
+	for _, id := range similarExternalIDs {
+		if id == "" || id == externalID || seen[id] {
+			continue
+		}
+		seen[id] = true
+		edges = append(edges, models.SimilarProduct{ProductID: productID, SimilarExternalID: id})
+	}
+
+	remove := tx.Where("product_id = ?", productID)
+	if len(edges) > 0 {
+		remove = remove.Where("similar_external_id NOT IN ?", similarExternalIDs)
+	}
+	if err := remove.Delete(&models.SimilarProduct{}).Error; err != nil {
+		return fmt.Errorf("failed to remove similar products: %v", err)
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+
+	// Edges to products crawled already resolve right away
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "similar_external_id"}},
+		DoNothing: true,
+	}).Create(&edges).Error; err != nil {
+		return fmt.Errorf("failed to save similar products: %v", err)
+	}
+	err = tx.Exec(`
+UPDATE similar_products sp SET similar_product_id = p.id
+FROM products p
+WHERE sp.product_id = ? AND sp.similar_product_id IS NULL AND p.external_id = sp.similar_external_id`, productID).Error
+	if err != nil {
+		return fmt.Errorf("failed to resolve similar products: %v", err)
+	}
+	return nil
+}
+
+// crawlReviews stores a product's reviews written since its newest stored
+// review. The pages fetched are counted towards run.
+func (s *CrawlerService) crawlReviews(run *crawlRun, productExternalID string) error {
+	var productID uint
+	if err := s.db.Model(&models.Product{}).Where("external_id = ?", productExternalID).
+		Pluck("id", &productID).Error; err != nil {
+		return fmt.Errorf("failed to get product: %v", err)
+	}
+	var since sql.NullTime
+	if err := s.db.Model(&models.Review{}).Where("product_id = ?", productID).
+		Select("MAX(review_date)").Row().Scan(&since); err != nil {
+		return fmt.Errorf("failed to get latest review: %v", err)
+	}
+
+	// Without a site to crawl, mock reviews stand in for development
+	var scraped []scraper.Review
+	if s.reviewScraper.Configured() {
+		var err error
+		if scraped, err = s.reviewScraper.ScrapeReviews(context.Background(), productExternalID, since.Time, run.fetched); err != nil {
+			return err
+		}
+	} else {
+		scraped = s.reviewScraper.MockReviews(productExternalID, since.Time)
+	}
+	if len(scraped) == 0 {
+		return nil
+	}
+
+	return saveReviews(s.db, productID, scraped, false)
+}
+
+// saveReviews stores scraped reviews of a product. Overlapping crawls can
+// fetch a review twice, so a stored review is kept as it is unless
+// overwrite is set, as when archived pages are reparsed.
+func saveReviews(db *gorm.DB, productID uint, scraped []scraper.Review, overwrite bool) error {
+	reviews := make([]models.Review, len(scraped))
+	for i, r := range scraped {
+		date := r.Date
+		reviews[i] = models.Review{
+			ProductID:        productID,
+			ExternalReviewID: r.ExternalID,
+			Rating:           r.Rating,
+			Comment:          r.Comment,
+			ReviewerName:     r.ReviewerName,
+			ReviewDate:       &date,
+			IsTopReview:      r.IsTopReview,
+		}
+	}
+	onConflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "product_id"}, {Name: "external_review_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_review_id IS NOT NULL"}}},
+		DoNothing:   true,
+	}
+	if overwrite {
+		onConflict.DoNothing = false
+		onConflict.DoUpdates = clause.AssignmentColumns([]string{"rating", "comment", "reviewer_name", "review_date", "is_top_review"})
+	}
+	if err := db.Clauses(onConflict).CreateInBatches(&reviews, 500).Error; err != nil {
+		return fmt.Errorf("failed to save reviews: %v", err)
+	}
+	return nil
+}
+
+// saveSeller upserts a seller by external ID and returns its ID, or 0 when
+// the product names no seller. Without a name the external ID stands in,
+// and a known name is kept.
+func saveSeller(tx *gorm.DB, externalID, name string) (uint, error) {
+	if externalID == "" {
+		return 0, nil
+	}
+	seller := models.Seller{ExternalID: externalID, Name: name}
+	onConflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "external_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_id IS NOT NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}
+	if seller.Name == "" {
+		seller.Name = externalID
+		onConflict.DoUpdates = nil
+		onConflict.DoNothing = true
+	}
+	if err := tx.Clauses(onConflict).Create(&seller).Error; err != nil {
+		return 0, fmt.Errorf("failed to save seller: %v", err)
+	}
+	if seller.ID == 0 {
+		// DO NOTHING returns no row for an existing seller
+		if err := tx.Model(&models.Seller{}).Where("external_id = ?", externalID).
+			Pluck("id", &seller.ID).Error; err != nil {
+			return 0, fmt.Errorf("failed to get seller %s: %v", externalID, err)
+		}
+	}
+	return seller.ID, nil
+}
+
+// recordSellerRating stores a seller's crawled rating and records it in the
+// rating history when it changed.
+func recordSellerRating(tx *gorm.DB, sellerID uint, rating float64, now time.Time) error {
+	rating = math.Round(rating*100) / 100
+	result := tx.Model(&models.Seller{}).
+		Where("id = ? AND rating IS DISTINCT FROM ?", sellerID, rating).
+		Updates(map[string]interface{}{"rating": rating, "updated_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("failed to save seller rating: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+	if err := tx.Create(&models.SellerRating{SellerID: sellerID, Rating: rating, RecordedAt: now}).Error; err != nil {
+		return fmt.Errorf("failed to record seller rating: %v", err)
+	}
+	return nil
+}
+
+// saveBrand upserts a brand by external ID and returns its ID, or nil when
+// the product names no brand. Like sellers, a brand without a name is
+// named after its external ID until a name is crawled.
+func saveBrand(tx *gorm.DB, externalID, name string) (*uint, error) {
+	if externalID == "" {
+		return nil, nil
+	}
+	brand := models.Brand{ExternalID: externalID, Name: name}
+	onConflict := clause.OnConflict{
+		Columns:     []clause.Column{{Name: "external_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_id IS NOT NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"name", "updated_at"}),
+	}
+	if brand.Name == "" {
+		brand.Name = externalID
+		onConflict.DoUpdates = nil
+		onConflict.DoNothing = true
+	}
+	if err := tx.Clauses(onConflict).Create(&brand).Error; err != nil {
+		return nil, fmt.Errorf("failed to save brand: %v", err)
+	}
+	if brand.ID == 0 {
+		// DO NOTHING returns no row for an existing brand
+		if err := tx.Model(&models.Brand{}).Where("external_id = ?", externalID).
+			Pluck("id", &brand.ID).Error; err != nil {
+			return nil, fmt.Errorf("failed to get brand %s: %v", externalID, err)
+		}
+	}
+	return &brand.ID, nil
+}
+
+// saveOffers upserts every seller's offer on the product's variants: the
+// product's own seller's from its variants and the others' from
+// data.Offers. Offers on the product that this crawl did not see are
+// deactivated.
+func saveOffers(tx *gorm.DB, productID, sellerID uint, variants []models.ProductVariant, data *analysispb.ProductData, now time.Time) error {
+	currency := strings.ToUpper(data.Currency)
+	if currency == "" {
+		currency = "TRY"
+	}
+	shippingCost, err := parseOfferPrice(data.ShippingCost, 0)
+	if err != nil {
+		return fmt.Errorf("invalid shipping cost: %v", err)
+	}
+
+	variantIDs := make(map[string]uint, len(variants))
+	for _, v := range variants {
+		variantIDs[v.ExternalVariantID] = v.ID
+	}
+
+	var offers []models.Offer
+	if sellerID != 0 {
+		for i, v := range data.Variants {
+			price, err := parseOfferPrice(v.SalePrice, v.Price)
+			if err != nil {
+				return fmt.Errorf("invalid sale price on variant %s: %v", v.Id, err)
+			}
+			listPrice, err := parseOfferPrice(v.ListPrice, v.OriginalPrice)
+			if err != nil {
+				return fmt.Errorf("invalid list price on variant %s: %v", v.Id, err)
+			}
+			offers = append(offers, models.Offer{
+				VariantID:         variants[i].ID,
+				SellerID:          sellerID,
+				Price:             price,
+				ListPrice:         decimal.NullDecimal{Decimal: listPrice, Valid: listPrice.IsPositive()},
+				StockQuantity:     int(v.StockQuantity),
+				ShippingCost:      shippingCost,
+				EstimatedDelivery: data.EstimatedDelivery,
+				IsActive:          v.IsActive,
+			})
+		}
+	}
+	for _, o := range data.Offers {
+		variantID, ok := variantIDs[o.VariantId]
+		if !ok {
+			return fmt.Errorf("offer from seller %s names unknown variant %s", o.SellerId, o.VariantId)
+		}
+		offerSellerID, err := saveSeller(tx, o.SellerId, o.SellerName)
+		if err != nil {
+			return err
+		}
+		if offerSellerID == 0 {
+			return fmt.Errorf("offer on variant %s has no seller", o.VariantId)
+		}
+		price, err := parseOfferPrice(o.Price, 0)
+		if err != nil {
+			return fmt.Errorf("invalid offer price on variant %s: %v", o.VariantId, err)
+		}
+		listPrice, err := parseOfferPrice(o.ListPrice, 0)
+		if err != nil {
+			return fmt.Errorf("invalid offer list price on variant %s: %v", o.VariantId, err)
+		}
+		offerShippingCost, err := parseOfferPrice(o.ShippingCost, 0)
+		if err != nil {
+			return fmt.Errorf("invalid offer shipping cost on variant %s: %v", o.VariantId, err)
+		}
+		offers = append(offers, models.Offer{
+			VariantID:         variantID,
+			SellerID:          offerSellerID,
+			Price:             price,
+			ListPrice:         decimal.NullDecimal{Decimal: listPrice, Valid: listPrice.IsPositive()},
+			StockQuantity:     int(o.StockQuantity),
+			ShippingCost:      offerShippingCost,
+			EstimatedDelivery: o.EstimatedDelivery,
+			IsActive:          true,
+		})
+	}
+
+	// A row can only be upserted once per statement, so a seller listed
+	// twice on a variant keeps its last offer
+	index := make(map[[2]uint]int, len(offers))
+	unique := offers[:0]
+	for _, o := range offers {
+		o.Currency = currency
+		o.LastSeenAt = now
+		key := [2]uint{o.VariantID, o.SellerID}
+		if i, ok := index[key]; ok {
+			unique[i] = o
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, o)
+	}
+	offers = unique
+
+	if len(offers) > 0 {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "variant_id"}, {Name: "seller_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"currency", "price", "list_price", "stock_quantity", "shipping_cost",
+				"estimated_delivery", "is_active", "last_seen_at", "updated_at",
+			}),
+		}).Create(&offers).Error
+		if err != nil {
+			return fmt.Errorf("failed to save offers: %v", err)
+		}
+	}
+
+	err = tx.Model(&models.Offer{}).
+		Where("variant_id IN (?) AND last_seen_at < ? AND is_active",
+			tx.Model(&models.ProductVariant{}).Select("id").Where("product_id = ?", productID), now).
+		Updates(map[string]interface{}{"is_active": false, "updated_at": now}).Error
+	if err != nil {
+		return fmt.Errorf("failed to deactivate offers: %v", err)
+	}
+	return nil
+}
+
+// parseOfferPrice parses an exact decimal price, falling back to the
+// approximate float field older scrapers fill.
+func parseOfferPrice(exact string, approximate float32) (decimal.Decimal, error) {
+	if exact == "" {
+		return decimal.NewFromFloat32(approximate).Round(2), nil
+	}
+	price, err := decimal.NewFromString(exact)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if price.IsNegative() {
+		return decimal.Decimal{}, fmt.Errorf("negative price %s", price)
+	}
+	return price.Round(2), nil
+}
+
+// sendProductsToAnalysis streams a category's products to Product Analysis
+// Service and records the products it could not analyse as errors of run.
+// It returns an error when the stream itself fails.
+func (s *CrawlerService) sendProductsToAnalysis(run *crawlRun, products []*analysispb.ProductData) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), analysisTimeout)
 	defer cancel()
 
-	response, err := s.productAnalysisClient.AnalyzeProduct(ctx, productData)
+	stream, err := s.productAnalysisClient.AnalyzeProducts(ctx)
 	if err != nil {
-		log.Printf("Failed to send product to analysis service: %v", err)
-		return
+		return fmt.Errorf("failed to open analysis stream: %v", err)
+	}
+	var sendErr error
+	for _, product := range products {
+		if sendErr = stream.Send(&analysispb.AnalyzeProductRequest{Product: product}); sendErr != nil {
+			// The stream is broken; CloseAndRecv reports why
+			break
+		}
+	}
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to analyze products: %v", err)
+	}
+	if sendErr != nil {
+		return fmt.Errorf("failed to send product: %v", sendErr)
 	}
 
-	log.Printf("Product sent to analysis service. Response: %v", response.Status)
-}
\ No newline at end of file
+	for _, result := range response.Results {
+		if result.Status != "success" {
+			run.errorf("Analysis of product %s failed: %s: %s", result.ProductId, result.ErrorCode, result.Error)
+		}
+	}
+	log.Printf("Products sent to analysis service: %d succeeded, %d failed", response.Succeeded, response.Failed)
+	return nil
+}
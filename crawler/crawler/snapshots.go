@@ -0,0 +1,213 @@
+package crawler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// saveSnapshot records what a product page showed at a crawl. html is the
+// raw page, or nil when it was not fetched. When the content matches the
+// product's latest snapshot, that snapshot is extended instead.
+func saveSnapshot(tx *gorm.DB, productID uint, html []byte, data *analysispb.ProductData, now time.Time) error {
+	serialised, err := proto.MarshalOptions{Deterministic: true}.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to serialise product data: %v", err)
+	}
+	hash := snapshotHash(html, serialised)
+
+	var latest models.ProductSnapshot
+	result := tx.Where("product_id = ?", productID).Order("captured_at DESC, id DESC").Limit(1).Find(&latest)
+	if result.Error != nil {
+		return fmt.Errorf("failed to get latest snapshot: %v", result.Error)
+	}
+	if result.RowsAffected > 0 && latest.ContentHash == hash {
+		return tx.Model(&latest).Update("last_seen_at", now).Error
+	}
+
+	content := models.SnapshotContent{ContentHash: hash, CreatedAt: now}
+	if content.Data, err = compress(serialised); err != nil {
+		return err
+	}
+	if html != nil {
+		if content.HTML, err = compress(html); err != nil {
+			return err
+		}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&content).Error; err != nil {
+		return fmt.Errorf("failed to save snapshot content: %v", err)
+	}
+	snapshot := models.ProductSnapshot{
+		ProductID:   productID,
+		ContentHash: hash,
+		CapturedAt:  now,
+		LastSeenAt:  now,
+	}
+	if err := tx.Create(&snapshot).Error; err != nil {
+		return fmt.Errorf("failed to save snapshot: %v", err)
+	}
+	return nil
+}
+
+// snapshotHash is the SHA-256 of a page and its parsed data. Each part is
+// length-prefixed, and a missing page hashes differently from an empty one.
+func snapshotHash(html, data []byte) string {
+	h := sha256.New()
+	for _, part := range [][]byte{html, data} {
+		var length [9]byte
+		if part != nil {
+			length[0] = 1
+		}
+		binary.BigEndian.PutUint64(length[1:], uint64(len(part)))
+		h.Write(length[:])
+		h.Write(part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot: %v", err)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// getProductAsOf returns a product as its page showed at asOf.
+func (s *CrawlerService) getProductAsOf(ctx context.Context, productID uint64, asOf time.Time, includeHTML bool) (*pb.GetProductResponse, error) {
+	db := s.db.WithContext(ctx)
+	var snapshot models.ProductSnapshot
+	result := db.Where("product_id = ? AND captured_at <= ?", productID, asOf).
+		Order("captured_at DESC, id DESC").
+		Take(&snapshot)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no snapshot of product %d as of %s", productID, asOf.Format(time.RFC3339))
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot: %v", result.Error)
+	}
+	var content models.SnapshotContent
+	if err := db.Take(&content, "content_hash = ?", snapshot.ContentHash).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot content: %v", err)
+	}
+
+	serialised, err := decompress(content.Data)
+	if err != nil {
+		return nil, err
+	}
+	var data analysispb.ProductData
+	if err := proto.Unmarshal(serialised, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %v", err)
+	}
+	dataJSON, err := protojson.Marshal(&data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+
+	product := &pb.Product{
+		Id:          fmt.Sprint(productID),
+		Name:        data.Name,
+		Description: data.Description,
+		CategoryId:  data.CategoryId,
+	}
+	for _, image := range data.Images {
+		if !image.IsVideo {
+			product.ImageUrl = image.Url
+			break
+		}
+	}
+	lowest := float32(math.Inf(1))
+	for _, v := range data.Variants {
+		if v.IsActive && v.Price < lowest {
+			lowest = v.Price
+		}
+	}
+	if !math.IsInf(float64(lowest), 1) {
+		product.Price = lowest
+	}
+
+	pbSnapshot := &pb.ProductSnapshot{
+		CapturedAt:  snapshot.CapturedAt.Format(time.RFC3339),
+		LastSeenAt:  snapshot.LastSeenAt.Format(time.RFC3339),
+		ContentHash: snapshot.ContentHash,
+		Data:        string(dataJSON),
+		HasHtml:     content.HTML != nil,
+	}
+	if includeHTML && content.HTML != nil {
+		if pbSnapshot.Html, err = decompress(content.HTML); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.GetProductResponse{Product: product, Snapshot: pbSnapshot}, nil
+}
+
+// StartSnapshotRetention prunes old snapshots once a day.
+func (s *CrawlerService) StartSnapshotRetention(retention time.Duration) {
+	log.Println("Starting snapshot retention...")
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		if err := s.pruneSnapshots(time.Now().Add(-retention)); err != nil {
+			log.Printf("Snapshot retention failed: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// pruneSnapshots deletes the snapshots last seen before cutoff, and the
+// contents no snapshot refers to any more. A product's latest snapshot is
+// always kept, so every product can still be shown as of its last crawl.
+func (s *CrawlerService) pruneSnapshots(cutoff time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where(`last_seen_at < ? AND id NOT IN (
+SELECT DISTINCT ON (product_id) id FROM product_snapshots
+ORDER BY product_id, captured_at DESC, id DESC)`, cutoff).
+			Delete(&models.ProductSnapshot{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete snapshots: %v", result.Error)
+		}
+		contents := tx.Where(`NOT EXISTS (
+SELECT 1 FROM product_snapshots s WHERE s.content_hash = snapshot_contents.content_hash)`).
+			Delete(&models.SnapshotContent{})
+		if contents.Error != nil {
+			return fmt.Errorf("failed to delete snapshot contents: %v", contents.Error)
+		}
+		log.Printf("Pruned %d snapshots and %d snapshot contents older than %s",
+			result.RowsAffected, contents.RowsAffected, cutoff.Format(time.RFC3339))
+		return nil
+	})
+}
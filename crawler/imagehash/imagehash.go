@@ -0,0 +1,74 @@
+// Package imagehash computes perceptual hashes of product images, so the
+// same picture can be recognised across listings after it has been
+// resized or recompressed.
+package imagehash
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math/bits"
+)
+
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+// Decode decodes a JPEG, PNG or GIF image and returns its DHash.
+func Decode(r io.Reader) (uint64, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return DHash(img), nil
+}
+
+// DHash is the 64-bit difference hash of img. The image is shrunk to 9x8
+// grayscale cells, and each bit records whether a cell is brighter than
+// the cell to its right. Hashes of the same picture usually differ in a
+// few bits at most.
+func DHash(img image.Image) uint64 {
+	var cells [hashHeight][hashWidth]float64
+	bounds := img.Bounds()
+	for y := 0; y < hashHeight; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/hashHeight
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/hashHeight
+		for x := 0; x < hashWidth; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/hashWidth
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/hashWidth
+			cells[y][x] = meanGray(img, x0, y0, max(x1, x0+1), max(y1, y0+1))
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if cells[y][x] > cells[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// meanGray is the mean luminance of the pixels in [x0, x1) x [y0, y1).
+func meanGray(img image.Image, x0, y0, x1, y1 int) float64 {
+	var sum float64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+		}
+	}
+	return sum / float64((x1-x0)*(y1-y0))
+}
+
+// Distance is the number of bits two hashes differ in.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
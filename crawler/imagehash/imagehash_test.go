@@ -0,0 +1,124 @@
+package imagehash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+)
+
+// picture draws a smooth test pattern of the given size, the same picture
+// at every size.
+func picture(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			u, v := float64(x)/float64(width), float64(y)/float64(height)
+			g := 128 + 60*math.Sin(7*u+3*v) + 50*math.Cos(5*v-4*u*v)
+			img.SetGray(x, y, color.Gray{Y: uint8(g)})
+		}
+	}
+	return img
+}
+
+// mirrored flips img left to right.
+func mirrored(img *image.Gray) *image.Gray {
+	b := img.Bounds()
+	out := image.NewGray(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			out.SetGray(b.Max.X-1-(x-b.Min.X), y, img.GrayAt(x, y))
+		}
+	}
+	return out
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{0, 0, 0},
+		{0xff, 0xff, 0},
+		{0, 1, 1},
+		{0b1010, 0b0101, 4},
+		{0, math.MaxUint64, 64},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%#x, %#x) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDHashGradients(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 90, 80))
+	for y := 0; y < 80; y++ {
+		for x := 0; x < 90; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(250 - 2*x)})
+		}
+	}
+	// Every cell is brighter than the one to its right
+	if got := DHash(img); got != math.MaxUint64 {
+		t.Errorf("darkening gradient hash = %#x, want all bits set", got)
+	}
+	if got := DHash(mirrored(img)); got != 0 {
+		t.Errorf("brightening gradient hash = %#x, want 0", got)
+	}
+}
+
+func TestDHashNearIdentical(t *testing.T) {
+	original := picture(400, 300)
+	hash := DHash(original)
+
+	var jpegBuf bytes.Buffer
+	if err := jpeg.Encode(&jpegBuf, original, &jpeg.Options{Quality: 60}); err != nil {
+		t.Fatal(err)
+	}
+	recompressed, err := jpeg.Decode(&jpegBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		img         image.Image
+		maxDistance int
+		minDistance int
+	}{
+		{"same picture", original, 0, 0},
+		{"resized", picture(200, 150), 4, 0},
+		{"recompressed", recompressed, 4, 0},
+		{"mirrored", mirrored(original), 64, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Distance(hash, DHash(tt.img))
+			if d > tt.maxDistance || d < tt.minDistance {
+				t.Errorf("distance = %d, want between %d and %d", d, tt.minDistance, tt.maxDistance)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	img := picture(120, 90)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != DHash(img) {
+		t.Errorf("decoded hash = %#x, want %#x", hash, DHash(img))
+	}
+
+	if _, err := Decode(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Error("decoding garbage succeeded")
+	}
+}
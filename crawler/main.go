@@ -1,19 +1,29 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"strings"
 
+	"github.com/segmentio/kafka-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"github.com/faisaloncode/ecommerce-crawler/crawler/config"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/crawler"
-	// "github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/imagestore"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/pagearchive"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/proto"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/scraper"
+	"github.com/faisaloncode/ecommerce-crawler/identity"
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
 )
 
 func main() {
@@ -26,25 +36,90 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	// Run database migrations
-	// migrateDB(db)
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database handle: %v", err)
+	}
+
+	// `crawler-service migrate [up|down [n]|status]` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.RunCommand(context.Background(), sqlDB, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	// Run migrations
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if err := migrations.VerifyModels(db, models.Tables()...); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
+
+	// Every page the scrapers fetch is archived for reparsing
+	pageArchive, err := pagearchive.New(db, cfg.PageArchiveDir)
+	if err != nil {
+		log.Fatalf("Failed to initialize page archive: %v", err)
+	}
+
+	// `crawler-service reparse [flags]` replays archived pages through the
+	// current parsers and exits
+	if len(os.Args) > 1 && os.Args[1] == "reparse" {
+		if err := crawler.RunReparseCommand(context.Background(), db, pageArchive, os.Args[2:]); err != nil {
+			log.Fatalf("Reparse failed: %v", err)
+		}
+		return
+	}
 
 	// Initialize category scraper
-	categoryScraper := scraper.NewCategoryScraper(db, cfg)
+	categoryScraper := scraper.NewCategoryScraper(db, cfg, pageArchive)
+
+	// Crawled products are streamed to Product Analysis Service
+	analysisConn, err := grpc.NewClient(cfg.ProductAnalysisServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create product analysis client: %v", err)
+	}
+	defer analysisConn.Close()
+
+	// Product images are archived into a content-addressed store
+	imageStore, err := newImageStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize image store: %v", err)
+	}
+
+	// Product change events go to the notification service when enabled
+	var eventWriter *kafka.Writer
+	if cfg.ProductChangeEvents {
+		eventWriter = &kafka.Writer{
+			Addr:     kafka.TCP(strings.Split(cfg.KafkaBrokers, ",")...),
+			Topic:    "product-updates",
+			Balancer: &kafka.Hash{},
+		}
+		defer eventWriter.Close()
+	}
 
 	// Initialize crawler service with category scraper
-	crawlerService := crawler.NewCrawlerService(db, nil, categoryScraper) // nil for product analysis client as crawler doesn't need to make requests
+	crawlerService := crawler.NewCrawlerService(db, analysispb.NewProductAnalysisServiceClient(analysisConn), categoryScraper, scraper.NewProductScraper(cfg, pageArchive), scraper.NewReviewScraper(cfg, pageArchive), imageStore, eventWriter)
 
 	// Start the crawler service
 	go crawlerService.StartScheduler()
 
+	// Prune product snapshots past their retention
+	go crawlerService.StartSnapshotRetention(cfg.SnapshotRetention)
+
 	// Setup gRPC server
 	lis, err := net.Listen("tcp", cfg.CrawlerServiceAddr)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(identity.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(identity.StreamServerInterceptor()),
+	)
 
 	// Register the crawler service
 	proto.RegisterCrawlerServiceServer(grpcServer, crawlerService)
@@ -62,14 +137,19 @@ func initDB(cfg *config.Config) (*gorm.DB, error) {
 	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
 }
 
-// func migrateDB(db *gorm.DB) {
-// 	// Run migrations specific to the crawler service
-// 	err := db.AutoMigrate(
-// 		&models.Category{},
-// 		&models.Product{},
-// 	)
-// 	if err != nil {
-// 		log.Fatalf("Failed to run migrations: %v", err)
-// 	}
-// 	log.Println("Database migrations completed")
-// }
+func newImageStore(cfg *config.Config) (imagestore.Store, error) {
+	switch cfg.ImageStore {
+	case "file":
+		return imagestore.NewFileStore(cfg.ImageStoreDir)
+	case "s3":
+		return imagestore.NewS3Store(imagestore.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Bucket:    cfg.S3Bucket,
+			Region:    cfg.S3Region,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("invalid IMAGE_STORE %q: must be file or s3", cfg.ImageStore)
+	}
+}
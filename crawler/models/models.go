@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type Category struct {
@@ -23,6 +25,10 @@ type CategoryCrawlStatus struct {
 	UpdatedAt     time.Time
 }
 
+func (CategoryCrawlStatus) TableName() string {
+	return "category_crawl_status"
+}
+
 type Product struct {
 	ID              uint      `gorm:"primaryKey"`
 	ExternalID      string    `gorm:"size:255;uniqueIndex;not null"`
@@ -50,7 +56,18 @@ type ProductImage struct {
 	URL        string `gorm:"size:500;not null"`
 	SortOrder  int    
 	IsVideo    bool   `gorm:"default:false"`
-	CreatedAt  time.Time
+	// ImageHash is the image's imagehash.DHash, bit for bit, or nil until
+	// the image has been fetched.
+	ImageHash *int64
+	// ContentHash is the imagestore key of the archived copy, or empty
+	// until the image has been archived.
+	ContentHash  string `gorm:"size:64"`
+	ContentType  string `gorm:"size:100"`
+	ETag         string `gorm:"column:etag;size:200"`
+	LastModified string `gorm:"size:100"`
+	ArchivedAt   *time.Time
+	CheckedAt    *time.Time
+	CreatedAt    time.Time
 }
 
 type ProductVariant struct {
@@ -76,44 +93,187 @@ type ProductAttribute struct {
 	CreatedAt     time.Time
 }
 
-type User struct {
-	ID        uint      `gorm:"primaryKey"`
-	Username  string    `gorm:"size:255;not null"`
-	Email     string    `gorm:"size:255;uniqueIndex;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+// Brand is a product's brand. Brands are upserted by external ID as
+// products are crawled.
+type Brand struct {
+	ID         uint   `gorm:"primaryKey"`
+	Name       string `gorm:"size:255;not null"`
+	ExternalID string `gorm:"size:255"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
-type UserFavorite struct {
-	ID        uint      `gorm:"primaryKey"`
-	UserID    uint      `gorm:"index"`
-	ProductID uint      `gorm:"index"`
-	CreatedAt time.Time
+// Seller is a merchant offering products. Sellers are upserted by external
+// ID as products are crawled.
+type Seller struct {
+	ID         uint   `gorm:"primaryKey"`
+	Name       string `gorm:"size:255;not null"`
+	ExternalID string `gorm:"size:255"`
+	Rating     *float64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
-type PriceHistory struct {
-	ID        uint      `gorm:"primaryKey"`
-	VariantID uint      `gorm:"index"`
-	OldPrice  float64
-	NewPrice  float64
-	ChangedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+// SellerRating records a seller's rating each time a crawl saw it change.
+type SellerRating struct {
+	ID         uint      `gorm:"primaryKey"`
+	SellerID   uint      `gorm:"not null"`
+	Rating     float64   `gorm:"type:decimal(3,2);not null"`
+	RecordedAt time.Time `gorm:"not null"`
 }
 
-type StockHistory struct {
-	ID          uint      `gorm:"primaryKey"`
-	VariantID   uint      `gorm:"index"`
-	OldQuantity int
-	NewQuantity int
-	ChangedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+// Offer is a seller's offer on a variant, as last crawled. Offers missing
+// from a crawl of their product are deactivated rather than deleted.
+type Offer struct {
+	ID                uint                `gorm:"primaryKey"`
+	VariantID         uint                `gorm:"not null"`
+	SellerID          uint                `gorm:"not null"`
+	Currency          string              `gorm:"type:char(3);not null"`
+	Price             decimal.Decimal     `gorm:"type:decimal(10,2);not null"`
+	ListPrice         decimal.NullDecimal `gorm:"type:decimal(10,2)"`
+	StockQuantity     int                 `gorm:"not null"`
+	ShippingCost      decimal.Decimal     `gorm:"type:decimal(10,2);not null"`
+	EstimatedDelivery string              `gorm:"size:255"`
+	IsActive          bool                `gorm:"not null"`
+	LastSeenAt        time.Time           `gorm:"not null"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
-type Notification struct {
-	ID              uint      `gorm:"primaryKey"`
-	UserID          uint      `gorm:"index"`
-	ProductID       uint      `gorm:"index"`
-	VariantID       *uint     `gorm:"index"`
-	NotificationType string   `gorm:"size:50;not null"`
-	Message         string    `gorm:"type:text;not null"`
-	IsRead          bool      `gorm:"default:false"`
-	CreatedAt       time.Time
-}
\ No newline at end of file
+// Review is a customer review of a product. Reviews are crawled
+// incrementally and never updated once stored.
+type Review struct {
+	ID               uint   `gorm:"primaryKey"`
+	ProductID        uint   `gorm:"not null"`
+	ExternalReviewID string `gorm:"size:255"`
+	Rating           int    `gorm:"not null"`
+	Comment          string `gorm:"type:text"`
+	ReviewerName     string `gorm:"size:255"`
+	ReviewDate       *time.Time
+	IsTopReview      bool
+	CreatedAt        time.Time
+}
+
+// ImageChange records a product image URL serving different content than
+// at the previous crawl.
+type ImageChange struct {
+	ID             uint   `gorm:"primaryKey"`
+	ProductID      uint   `gorm:"not null"`
+	URL            string `gorm:"size:500;not null"`
+	OldContentHash string `gorm:"size:64;not null"`
+	NewContentHash string `gorm:"size:64;not null"`
+	OldImageHash   *int64
+	NewImageHash   *int64
+	// Distance is how many bits the perceptual hashes differ in, or nil
+	// when either image could not be decoded.
+	Distance   *int
+	DetectedAt time.Time
+}
+
+// Fields a ProductChange can be about.
+const (
+	ChangeName               = "name"
+	ChangeDescription        = "description"
+	ChangeSizeRecommendation = "size_recommendation"
+	ChangeEstimatedDelivery  = "estimated_delivery"
+	ChangeAttribute          = "attribute"
+	ChangeImage              = "image"
+)
+
+// ProductChange is one field of a crawled product that differs from what
+// was stored. Key is the attribute name or image URL for attribute and
+// image changes. OldValue is nil for something added and NewValue nil for
+// something removed.
+type ProductChange struct {
+	ID        uint   `gorm:"primaryKey"`
+	ProductID uint   `gorm:"not null"`
+	Field     string `gorm:"size:32;not null"`
+	Key       string `gorm:"size:500;not null"`
+	OldValue  *string
+	NewValue  *string
+	ChangedAt time.Time
+}
+
+// SnapshotContent is a product page's content as of some crawl, stored
+// once per ContentHash. HTML is the gzipped raw page, or nil when the page
+// was not fetched; Data is the gzipped, serialised ProductData.
+type SnapshotContent struct {
+	ContentHash string `gorm:"primaryKey;size:64"`
+	HTML        []byte `gorm:"column:html"`
+	Data        []byte `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// ProductSnapshot records that a product showed a SnapshotContent from
+// CapturedAt until LastSeenAt.
+type ProductSnapshot struct {
+	ID          uint   `gorm:"primaryKey"`
+	ProductID   uint   `gorm:"not null"`
+	ContentHash string `gorm:"size:64;not null"`
+	CapturedAt  time.Time
+	LastSeenAt  time.Time
+}
+
+// FetchedPage is one fetch of a page by a scraper. The body is kept in
+// the page archive under ContentHash.
+type FetchedPage struct {
+	ID          uint   `gorm:"primaryKey"`
+	URL         string `gorm:"size:2000;not null"`
+	Kind        string `gorm:"size:32;not null"`
+	StatusCode  int    `gorm:"not null"`
+	ContentType string `gorm:"size:100"`
+	ContentHash string `gorm:"size:64;not null"`
+	Size        int    `gorm:"not null"`
+	FetchedAt   time.Time
+}
+
+// What started a CrawlRun.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// States of a CrawlRun.
+const (
+	CrawlRunning   = "running"
+	CrawlCompleted = "completed"
+	CrawlFailed    = "failed"
+)
+
+// CrawlRun is one crawl of a category. ErrorCount counts every error the
+// run hit; the first of them are kept as CrawlRunErrors.
+type CrawlRun struct {
+	ID                  uint   `gorm:"primaryKey"`
+	CategoryID          uint   `gorm:"not null"`
+	Trigger             string `gorm:"size:16;not null"`
+	Status              string `gorm:"size:16;not null"`
+	StartedAt           time.Time
+	FinishedAt          *time.Time
+	PagesFetched        int
+	ImagesFetched       int
+	BytesTransferred    int64
+	ProductsFound       int
+	ProductsNew         int
+	ProductsUpdated     int
+	ProductsDeactivated int
+	ErrorCount          int
+}
+
+// CrawlRunError is a sample of the errors a CrawlRun hit.
+type CrawlRunError struct {
+	ID         uint   `gorm:"primaryKey"`
+	CrawlRunID uint   `gorm:"not null"`
+	Message    string `gorm:"type:text;not null"`
+	OccurredAt time.Time
+}
+
+// SimilarProduct is an edge to a product the site lists as similar. The
+// target is known by external ID; SimilarProductID is set once it has been
+// crawled.
+type SimilarProduct struct {
+	ID                uint   `gorm:"primaryKey"`
+	ProductID         uint   `gorm:"not null"`
+	SimilarExternalID string `gorm:"size:255;not null"`
+	SimilarProductID  *uint
+	CreatedAt         time.Time
+}
@@ -0,0 +1,28 @@
+package models
+
+// Tables lists the models whose tables the crawler service owns. Other
+// services may read these tables but only the crawler writes them; their
+// schema is defined in migrations/.
+func Tables() []interface{} {
+	return []interface{}{
+		&Category{},
+		&CategoryCrawlStatus{},
+		&CrawlRun{},
+		&CrawlRunError{},
+		&Product{},
+		&ProductImage{},
+		&ImageChange{},
+		&ProductChange{},
+		&SnapshotContent{},
+		&ProductSnapshot{},
+		&FetchedPage{},
+		&ProductVariant{},
+		&ProductAttribute{},
+		&Brand{},
+		&Seller{},
+		&SellerRating{},
+		&Offer{},
+		&Review{},
+		&SimilarProduct{},
+	}
+}
@@ -0,0 +1,210 @@
+// Package pagearchive keeps every page the scrapers fetch, so historical
+// data can be re-extracted with fixed parsers instead of re-crawling.
+// Bodies are stored gzipped in a directory under the SHA-256 of their
+// content, and each fetch is recorded as a models.FetchedPage.
+package pagearchive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+)
+
+// Kinds of page, one per parser.
+const (
+	KindCategoriesHTML = "categories_html"
+	KindCategoriesAPI  = "categories_api"
+	KindReviews        = "reviews"
+	KindProduct        = "product"
+)
+
+// maxPageSize bounds how much of a response is read and archived.
+const maxPageSize = 32 << 20
+
+// ErrNotArchived is returned by Open for a page whose body is missing.
+var ErrNotArchived = errors.New("page body not archived")
+
+type Archive struct {
+	db   *gorm.DB
+	root string
+}
+
+func New(db *gorm.DB, root string) (*Archive, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create page archive directory: %w", err)
+	}
+	return &Archive{db: db, root: root}, nil
+}
+
+// Client returns an HTTP client that archives every response it receives
+// as a page of the given kind. A nil Archive returns a plain client.
+func (a *Archive) Client(kind string, timeout time.Duration) *http.Client {
+	if a == nil {
+		return &http.Client{Timeout: timeout}
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &transport{archive: a, kind: kind, next: http.DefaultTransport},
+	}
+}
+
+// Store archives a fetched page body.
+func (a *Archive) Store(ctx context.Context, kind, url string, statusCode int, contentType string, body []byte, fetchedAt time.Time) error {
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	if err := a.write(hash, body); err != nil {
+		return err
+	}
+	page := models.FetchedPage{
+		URL:         url,
+		Kind:        kind,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		ContentHash: hash,
+		Size:        len(body),
+		FetchedAt:   fetchedAt,
+	}
+	if err := a.db.WithContext(ctx).Create(&page).Error; err != nil {
+		return fmt.Errorf("failed to record fetched page: %w", err)
+	}
+	return nil
+}
+
+// Open returns the body of an archived page.
+func (a *Archive) Open(page models.FetchedPage) ([]byte, error) {
+	f, err := os.Open(a.path(page.ContentHash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotArchived
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archived page: %w", err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived page: %w", err)
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Filter selects archived pages. Zero fields match every page.
+type Filter struct {
+	Kind      string
+	URLPrefix string
+	Since     time.Time
+	Until     time.Time
+}
+
+// Pages returns the successfully fetched pages matching filter, oldest
+// first, so replaying them applies the latest fetch of a URL last.
+func (a *Archive) Pages(ctx context.Context, filter Filter) ([]models.FetchedPage, error) {
+	query := a.db.WithContext(ctx).Where("status_code = ?", http.StatusOK)
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.URLPrefix != "" {
+		query = query.Where("url LIKE ?", escapeLike(filter.URLPrefix)+"%")
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("fetched_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("fetched_at < ?", filter.Until)
+	}
+	var pages []models.FetchedPage
+	if err := query.Order("fetched_at, id").Find(&pages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list archived pages: %w", err)
+	}
+	return pages, nil
+}
+
+func (a *Archive) write(hash string, body []byte) error {
+	path := a.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create page directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to compress page: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to compress page: %w", err)
+	}
+	// Written aside and renamed, so a reader never sees a partial page
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write page: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to store page: %w", err)
+	}
+	return nil
+}
+
+func (a *Archive) path(hash string) string {
+	return filepath.Join(a.root, hash[:2], hash+".gz")
+}
+
+// transport archives the responses of the requests it sends. A response
+// that cannot be archived is still returned; the failure is only logged.
+type transport struct {
+	archive *Archive
+	kind    string
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fetchedAt := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > maxPageSize {
+		log.Printf("Not archiving %s: larger than %d bytes", req.URL, maxPageSize)
+		return resp, nil
+	}
+	if err := t.archive.Store(req.Context(), t.kind, req.URL.String(), resp.StatusCode,
+		resp.Header.Get("Content-Type"), body, fetchedAt); err != nil {
+		log.Printf("Failed to archive %s: %v", req.URL, err)
+	}
+	return resp, nil
+}
+
+func escapeLike(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		if r == '\\' || r == '%' || r == '_' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package pagearchive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
+)
+
+// TestArchiveRoundTrip archives the scrapers' fixture pages and checks
+// they open byte for byte, so a replay parses what was fetched.
+func TestArchiveRoundTrip(t *testing.T) {
+	archive, err := New(nil, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"categories.html", "categories.json", "product.html", "reviews.json"} {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("..", "scraper", "testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum := sha256.Sum256(body)
+			page := models.FetchedPage{ContentHash: hex.EncodeToString(sum[:])}
+
+			// A page fetched twice is written once
+			for i := 0; i < 2; i++ {
+				if err := archive.write(page.ContentHash, body); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := archive.Open(page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, body) {
+				t.Errorf("opened %d bytes, want the %d archived", len(got), len(body))
+			}
+		})
+	}
+}
+
+func TestOpenMissingPage(t *testing.T) {
+	archive, err := New(nil, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("never archived"))
+	_, err = archive.Open(models.FetchedPage{ContentHash: hex.EncodeToString(sum[:])})
+	if !errors.Is(err, ErrNotArchived) {
+		t.Fatalf("err = %v, want ErrNotArchived", err)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/p/": "https://example.com/p/",
+		"50%_off":                `50\%\_off`,
+		`a\b`:                    `a\\b`,
+	}
+	for in, want := range tests {
+		if got := escapeLike(in); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -526,27 +526,1783 @@ func (x *ListProductsResponse) GetTotal() int32 {
 }
 
 type GetProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// RFC 3339 time. When set, the product is returned as its page showed
+	// at that time, from the nearest snapshot captured at or before it.
+	AsOf string `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// Include the snapshot's raw HTML. Only used with as_of.
+	IncludeHtml   bool `protobuf:"varint,3,opt,name=include_html,json=includeHtml,proto3" json:"include_html,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetIncludeHtml() bool {
+	if x != nil {
+		return x.IncludeHtml
+	}
+	return false
+}
+
+type GetProductResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// The snapshot the product was read from, when as_of was given.
+	Snapshot      *ProductSnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductResponse) GetSnapshot() *ProductSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type ProductSnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The snapshot was seen at every crawl from captured_at to last_seen_at.
+	CapturedAt  string `protobuf:"bytes,1,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	LastSeenAt  string `protobuf:"bytes,2,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	ContentHash string `protobuf:"bytes,3,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// The parsed product data, as JSON.
+	Data          string `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	HasHtml       bool   `protobuf:"varint,5,opt,name=has_html,json=hasHtml,proto3" json:"has_html,omitempty"`
+	Html          []byte `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSnapshot) Reset() {
+	*x = ProductSnapshot{}
+	mi := &file_proto_crawler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSnapshot) ProtoMessage() {}
+
+func (x *ProductSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSnapshot.ProtoReflect.Descriptor instead.
+func (*ProductSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProductSnapshot) GetCapturedAt() string {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ProductSnapshot) GetHasHtml() bool {
+	if x != nil {
+		return x.HasHtml
+	}
+	return false
+}
+
+func (x *ProductSnapshot) GetHtml() []byte {
+	if x != nil {
+		return x.Html
+	}
+	return nil
+}
+
+// PriceStats summarises the active offers in one currency. Prices are exact
+// decimals.
+type PriceStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	OfferCount    int32                  `protobuf:"varint,2,opt,name=offer_count,json=offerCount,proto3" json:"offer_count,omitempty"`
+	MinPrice      string                 `protobuf:"bytes,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	AvgPrice      string                 `protobuf:"bytes,4,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	MaxPrice      string                 `protobuf:"bytes,5,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceStats) Reset() {
+	*x = PriceStats{}
+	mi := &file_proto_crawler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceStats) ProtoMessage() {}
+
+func (x *PriceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceStats.ProtoReflect.Descriptor instead.
+func (*PriceStats) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PriceStats) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceStats) GetOfferCount() int32 {
+	if x != nil {
+		return x.OfferCount
+	}
+	return 0
+}
+
+func (x *PriceStats) GetMinPrice() string {
+	if x != nil {
+		return x.MinPrice
+	}
+	return ""
+}
+
+func (x *PriceStats) GetAvgPrice() string {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return ""
+}
+
+func (x *PriceStats) GetMaxPrice() string {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return ""
+}
+
+type Brand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ProductCount  int32                  `protobuf:"varint,4,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	Prices        []*PriceStats          `protobuf:"bytes,5,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Brand) Reset() {
+	*x = Brand{}
+	mi := &file_proto_crawler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Brand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brand) ProtoMessage() {}
+
+func (x *Brand) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brand.ProtoReflect.Descriptor instead.
+func (*Brand) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Brand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Brand) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Brand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Brand) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+func (x *Brand) GetPrices() []*PriceStats {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type Seller struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExternalId string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Out of 5; 0 when no rating has been crawled.
+	Rating float32 `protobuf:"fixed32,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	// Products the seller has an active offer on.
+	ProductCount  int32         `protobuf:"varint,5,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	Prices        []*PriceStats `protobuf:"bytes,6,rep,name=prices,proto3" json:"prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Seller) Reset() {
+	*x = Seller{}
+	mi := &file_proto_crawler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Seller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Seller) ProtoMessage() {}
+
+func (x *Seller) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Seller.ProtoReflect.Descriptor instead.
+func (*Seller) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Seller) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Seller) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Seller) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Seller) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Seller) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+func (x *Seller) GetPrices() []*PriceStats {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type SellerRating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        float32                `protobuf:"fixed32,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	RecordedAt    string                 `protobuf:"bytes,2,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SellerRating) Reset() {
+	*x = SellerRating{}
+	mi := &file_proto_crawler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellerRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellerRating) ProtoMessage() {}
+
+func (x *SellerRating) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SellerRating.ProtoReflect.Descriptor instead.
+func (*SellerRating) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SellerRating) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *SellerRating) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+type ListBrandsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive substring of the name.
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32  `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrandsRequest) Reset() {
+	*x = ListBrandsRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrandsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrandsRequest) ProtoMessage() {}
+
+func (x *ListBrandsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrandsRequest.ProtoReflect.Descriptor instead.
+func (*ListBrandsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListBrandsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListBrandsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBrandsRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type ListBrandsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brands        []*Brand               `protobuf:"bytes,1,rep,name=brands,proto3" json:"brands,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrandsResponse) Reset() {
+	*x = ListBrandsResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrandsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrandsResponse) ProtoMessage() {}
+
+func (x *ListBrandsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrandsResponse.ProtoReflect.Descriptor instead.
+func (*ListBrandsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListBrandsResponse) GetBrands() []*Brand {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *ListBrandsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetBrandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrandRequest) Reset() {
+	*x = GetBrandRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrandRequest) ProtoMessage() {}
+
+func (x *GetBrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrandRequest.ProtoReflect.Descriptor instead.
+func (*GetBrandRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetBrandRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetBrandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         *Brand                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrandResponse) Reset() {
+	*x = GetBrandResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrandResponse) ProtoMessage() {}
+
+func (x *GetBrandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrandResponse.ProtoReflect.Descriptor instead.
+func (*GetBrandResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBrandResponse) GetBrand() *Brand {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+type ListSellersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Case-insensitive substring of the name.
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32  `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSellersRequest) Reset() {
+	*x = ListSellersRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSellersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSellersRequest) ProtoMessage() {}
+
+func (x *ListSellersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSellersRequest.ProtoReflect.Descriptor instead.
+func (*ListSellersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSellersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListSellersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSellersRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type ListSellersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sellers       []*Seller              `protobuf:"bytes,1,rep,name=sellers,proto3" json:"sellers,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSellersResponse) Reset() {
+	*x = ListSellersResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSellersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSellersResponse) ProtoMessage() {}
+
+func (x *ListSellersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSellersResponse.ProtoReflect.Descriptor instead.
+func (*ListSellersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSellersResponse) GetSellers() []*Seller {
+	if x != nil {
+		return x.Sellers
+	}
+	return nil
+}
+
+func (x *ListSellersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetSellerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerRequest) Reset() {
+	*x = GetSellerRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerRequest) ProtoMessage() {}
+
+func (x *GetSellerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerRequest.ProtoReflect.Descriptor instead.
+func (*GetSellerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetSellerRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetSellerResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Seller *Seller                `protobuf:"bytes,1,opt,name=seller,proto3" json:"seller,omitempty"`
+	// Rating changes, oldest first.
+	RatingHistory []*SellerRating `protobuf:"bytes,2,rep,name=rating_history,json=ratingHistory,proto3" json:"rating_history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerResponse) Reset() {
+	*x = GetSellerResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerResponse) ProtoMessage() {}
+
+func (x *GetSellerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerResponse.ProtoReflect.Descriptor instead.
+func (*GetSellerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetSellerResponse) GetSeller() *Seller {
+	if x != nil {
+		return x.Seller
+	}
+	return nil
+}
+
+func (x *GetSellerResponse) GetRatingHistory() []*SellerRating {
+	if x != nil {
+		return x.RatingHistory
+	}
+	return nil
+}
+
+type ListProductImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductImagesRequest) Reset() {
+	*x = ListProductImagesRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductImagesRequest) ProtoMessage() {}
+
+func (x *ListProductImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductImagesRequest.ProtoReflect.Descriptor instead.
+func (*ListProductImagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListProductImagesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ProductImage struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Url       string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	SortOrder int32                  `protobuf:"varint,2,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	IsVideo   bool                   `protobuf:"varint,3,opt,name=is_video,json=isVideo,proto3" json:"is_video,omitempty"`
+	// Key of the archived copy, the SHA-256 of its bytes. Empty until the
+	// image has been archived.
+	ContentHash string `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Perceptual hash as 16 hex digits. Empty when the image could not be
+	// decoded.
+	ImageHash     string `protobuf:"bytes,6,opt,name=image_hash,json=imageHash,proto3" json:"image_hash,omitempty"`
+	ArchivedAt    string `protobuf:"bytes,7,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	CheckedAt     string `protobuf:"bytes,8,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_proto_crawler_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetSortOrder() int32 {
+	if x != nil {
+		return x.SortOrder
+	}
+	return 0
+}
+
+func (x *ProductImage) GetIsVideo() bool {
+	if x != nil {
+		return x.IsVideo
+	}
+	return false
+}
+
+func (x *ProductImage) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *ProductImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductImage) GetImageHash() string {
+	if x != nil {
+		return x.ImageHash
+	}
+	return ""
+}
+
+func (x *ProductImage) GetArchivedAt() string {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return ""
+}
+
+func (x *ProductImage) GetCheckedAt() string {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return ""
+}
+
+type ImageChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Url            string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	OldContentHash string                 `protobuf:"bytes,2,opt,name=old_content_hash,json=oldContentHash,proto3" json:"old_content_hash,omitempty"`
+	NewContentHash string                 `protobuf:"bytes,3,opt,name=new_content_hash,json=newContentHash,proto3" json:"new_content_hash,omitempty"`
+	// Bits the perceptual hashes differ in; -1 when either image could not
+	// be decoded. Small distances are re-encodings of the same picture.
+	Distance      int32  `protobuf:"varint,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	DetectedAt    string `protobuf:"bytes,5,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageChange) Reset() {
+	*x = ImageChange{}
+	mi := &file_proto_crawler_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageChange) ProtoMessage() {}
+
+func (x *ImageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageChange.ProtoReflect.Descriptor instead.
+func (*ImageChange) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImageChange) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ImageChange) GetOldContentHash() string {
+	if x != nil {
+		return x.OldContentHash
+	}
+	return ""
+}
+
+func (x *ImageChange) GetNewContentHash() string {
+	if x != nil {
+		return x.NewContentHash
+	}
+	return ""
+}
+
+func (x *ImageChange) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *ImageChange) GetDetectedAt() string {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return ""
+}
+
+type ListProductImagesResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Images []*ProductImage        `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	// Changes to the product's images, newest first.
+	Changes       []*ImageChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductImagesResponse) Reset() {
+	*x = ListProductImagesResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductImagesResponse) ProtoMessage() {}
+
+func (x *ListProductImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductImagesResponse.ProtoReflect.Descriptor instead.
+func (*ListProductImagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListProductImagesResponse) GetImages() []*ProductImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ListProductImagesResponse) GetChanges() []*ImageChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type GetImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ContentHash   string                 `protobuf:"bytes,1,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageRequest) Reset() {
+	*x = GetImageRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageRequest) ProtoMessage() {}
+
+func (x *GetImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageRequest.ProtoReflect.Descriptor instead.
+func (*GetImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetImageRequest) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+type GetImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImageResponse) Reset() {
+	*x = GetImageResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImageResponse) ProtoMessage() {}
+
+func (x *GetImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImageResponse.ProtoReflect.Descriptor instead.
+func (*GetImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetImageResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetImageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type GetProductHistoryRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Only changes to one field: "name", "description",
+	// "size_recommendation", "estimated_delivery", "attribute" or "image".
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// RFC 3339 time; only changes at or after it.
+	Since         string `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	Page          int32  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32  `protobuf:"varint,5,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductHistoryRequest) Reset() {
+	*x = GetProductHistoryRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductHistoryRequest) ProtoMessage() {}
+
+func (x *GetProductHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetProductHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetProductHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductHistoryRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GetProductHistoryRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *GetProductHistoryRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetProductHistoryRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type ProductChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Attribute name or image URL for attribute and image changes.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Unset for something added, or for something removed respectively.
+	OldValue      *string `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3,oneof" json:"old_value,omitempty"`
+	NewValue      *string `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3,oneof" json:"new_value,omitempty"`
+	ChangedAt     string  `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductChange) Reset() {
+	*x = ProductChange{}
+	mi := &file_proto_crawler_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductChange) ProtoMessage() {}
+
+func (x *ProductChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductChange.ProtoReflect.Descriptor instead.
+func (*ProductChange) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProductChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ProductChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProductChange) GetOldValue() string {
+	if x != nil && x.OldValue != nil {
+		return *x.OldValue
+	}
+	return ""
+}
+
+func (x *ProductChange) GetNewValue() string {
+	if x != nil && x.NewValue != nil {
+		return *x.NewValue
+	}
+	return ""
+}
+
+func (x *ProductChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type GetProductHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Changes       []*ProductChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Total         int32            `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductHistoryResponse) Reset() {
+	*x = GetProductHistoryResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductHistoryResponse) ProtoMessage() {}
+
+func (x *GetProductHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetProductHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetProductHistoryResponse) GetChanges() []*ProductChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *GetProductHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListCrawlRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCrawlRunsRequest) Reset() {
+	*x = ListCrawlRunsRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCrawlRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCrawlRunsRequest) ProtoMessage() {}
+
+func (x *ListCrawlRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCrawlRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListCrawlRunsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListCrawlRunsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListCrawlRunsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCrawlRunsRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type CrawlRun struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CategoryId string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// "schedule" or "manual".
+	Trigger string `protobuf:"bytes,3,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// "running", "completed" or "failed".
+	Status    string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartedAt string `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Empty while the run is in progress.
+	FinishedAt          string `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	PagesFetched        int32  `protobuf:"varint,7,opt,name=pages_fetched,json=pagesFetched,proto3" json:"pages_fetched,omitempty"`
+	BytesTransferred    int64  `protobuf:"varint,8,opt,name=bytes_transferred,json=bytesTransferred,proto3" json:"bytes_transferred,omitempty"`
+	ProductsFound       int32  `protobuf:"varint,9,opt,name=products_found,json=productsFound,proto3" json:"products_found,omitempty"`
+	ProductsNew         int32  `protobuf:"varint,10,opt,name=products_new,json=productsNew,proto3" json:"products_new,omitempty"`
+	ProductsUpdated     int32  `protobuf:"varint,11,opt,name=products_updated,json=productsUpdated,proto3" json:"products_updated,omitempty"`
+	ProductsDeactivated int32  `protobuf:"varint,12,opt,name=products_deactivated,json=productsDeactivated,proto3" json:"products_deactivated,omitempty"`
+	ErrorCount          int32  `protobuf:"varint,13,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	// Images downloaded; pages_fetched counts pages only, while
+	// bytes_transferred counts both.
+	ImagesFetched int32 `protobuf:"varint,14,opt,name=images_fetched,json=imagesFetched,proto3" json:"images_fetched,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrawlRun) Reset() {
+	*x = CrawlRun{}
+	mi := &file_proto_crawler_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrawlRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrawlRun) ProtoMessage() {}
+
+func (x *CrawlRun) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrawlRun.ProtoReflect.Descriptor instead.
+func (*CrawlRun) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CrawlRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *CrawlRun) GetPagesFetched() int32 {
+	if x != nil {
+		return x.PagesFetched
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetBytesTransferred() int64 {
+	if x != nil {
+		return x.BytesTransferred
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetProductsFound() int32 {
+	if x != nil {
+		return x.ProductsFound
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetProductsNew() int32 {
+	if x != nil {
+		return x.ProductsNew
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetProductsUpdated() int32 {
+	if x != nil {
+		return x.ProductsUpdated
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetProductsDeactivated() int32 {
+	if x != nil {
+		return x.ProductsDeactivated
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetErrorCount() int32 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *CrawlRun) GetImagesFetched() int32 {
+	if x != nil {
+		return x.ImagesFetched
+	}
+	return 0
+}
+
+type ListCrawlRunsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Runs          []*CrawlRun `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	Total         int32       `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCrawlRunsResponse) Reset() {
+	*x = ListCrawlRunsResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCrawlRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCrawlRunsResponse) ProtoMessage() {}
+
+func (x *ListCrawlRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCrawlRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListCrawlRunsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListCrawlRunsResponse) GetRuns() []*CrawlRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *ListCrawlRunsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetCrawlRunRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_proto_crawler_proto_msgTypes[10]
+func (x *GetCrawlRunRequest) Reset() {
+	*x = GetCrawlRunRequest{}
+	mi := &file_proto_crawler_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *GetCrawlRunRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*GetCrawlRunRequest) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crawler_proto_msgTypes[10]
+func (x *GetCrawlRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,40 +2313,41 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_crawler_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetCrawlRunRequest.ProtoReflect.Descriptor instead.
+func (*GetCrawlRunRequest) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *GetCrawlRunRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-type GetProductResponse struct {
+type CrawlRunError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	OccurredAt    string                 `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
-	mi := &file_proto_crawler_proto_msgTypes[11]
+func (x *CrawlRunError) Reset() {
+	*x = CrawlRunError{}
+	mi := &file_proto_crawler_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductResponse) String() string {
+func (x *CrawlRunError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (*CrawlRunError) ProtoMessage() {}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_crawler_proto_msgTypes[11]
+func (x *CrawlRunError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,14 +2358,74 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_crawler_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CrawlRunError.ProtoReflect.Descriptor instead.
+func (*CrawlRunError) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *CrawlRunError) GetMessage() string {
 	if x != nil {
-		return x.Product
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CrawlRunError) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+type GetCrawlRunResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Run   *CrawlRun              `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	// The first errors the run hit, oldest first.
+	Errors        []*CrawlRunError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCrawlRunResponse) Reset() {
+	*x = GetCrawlRunResponse{}
+	mi := &file_proto_crawler_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCrawlRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCrawlRunResponse) ProtoMessage() {}
+
+func (x *GetCrawlRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_crawler_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCrawlRunResponse.ProtoReflect.Descriptor instead.
+func (*GetCrawlRunResponse) Descriptor() ([]byte, []int) {
+	return file_proto_crawler_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetCrawlRunResponse) GetRun() *CrawlRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *GetCrawlRunResponse) GetErrors() []*CrawlRunError {
+	if x != nil {
+		return x.Errors
 	}
 	return nil
 }
@@ -649,18 +2466,179 @@ const file_proto_crawler_proto_rawDesc = "" +
 	"\bper_page\x18\x03 \x01(\x05R\aperPage\"Z\n" +
 	"\x14ListProductsResponse\x12,\n" +
 	"\bproducts\x18\x01 \x03(\v2\x10.crawler.ProductR\bproducts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"#\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"[\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\tR\x04asOf\x12!\n" +
+	"\finclude_html\x18\x03 \x01(\bR\vincludeHtml\"v\n" +
 	"\x12GetProductResponse\x12*\n" +
-	"\aproduct\x18\x01 \x01(\v2\x10.crawler.ProductR\aproduct2\x98\x03\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.crawler.ProductR\aproduct\x124\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\x18.crawler.ProductSnapshotR\bsnapshot\"\xba\x01\n" +
+	"\x0fProductSnapshot\x12\x1f\n" +
+	"\vcaptured_at\x18\x01 \x01(\tR\n" +
+	"capturedAt\x12 \n" +
+	"\flast_seen_at\x18\x02 \x01(\tR\n" +
+	"lastSeenAt\x12!\n" +
+	"\fcontent_hash\x18\x03 \x01(\tR\vcontentHash\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x19\n" +
+	"\bhas_html\x18\x05 \x01(\bR\ahasHtml\x12\x12\n" +
+	"\x04html\x18\x06 \x01(\fR\x04html\"\xa0\x01\n" +
+	"\n" +
+	"PriceStats\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\voffer_count\x18\x02 \x01(\x05R\n" +
+	"offerCount\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\tR\bminPrice\x12\x1b\n" +
+	"\tavg_price\x18\x04 \x01(\tR\bavgPrice\x12\x1b\n" +
+	"\tmax_price\x18\x05 \x01(\tR\bmaxPrice\"\x9e\x01\n" +
+	"\x05Brand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rproduct_count\x18\x04 \x01(\x05R\fproductCount\x12+\n" +
+	"\x06prices\x18\x05 \x03(\v2\x13.crawler.PriceStatsR\x06prices\"\xb7\x01\n" +
+	"\x06Seller\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x02R\x06rating\x12#\n" +
+	"\rproduct_count\x18\x05 \x01(\x05R\fproductCount\x12+\n" +
+	"\x06prices\x18\x06 \x03(\v2\x13.crawler.PriceStatsR\x06prices\"G\n" +
+	"\fSellerRating\x12\x16\n" +
+	"\x06rating\x18\x01 \x01(\x02R\x06rating\x12\x1f\n" +
+	"\vrecorded_at\x18\x02 \x01(\tR\n" +
+	"recordedAt\"X\n" +
+	"\x11ListBrandsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\x05R\aperPage\"R\n" +
+	"\x12ListBrandsResponse\x12&\n" +
+	"\x06brands\x18\x01 \x03(\v2\x0e.crawler.BrandR\x06brands\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"!\n" +
+	"\x0fGetBrandRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x10GetBrandResponse\x12$\n" +
+	"\x05brand\x18\x01 \x01(\v2\x0e.crawler.BrandR\x05brand\"Y\n" +
+	"\x12ListSellersRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\x05R\aperPage\"V\n" +
+	"\x13ListSellersResponse\x12)\n" +
+	"\asellers\x18\x01 \x03(\v2\x0f.crawler.SellerR\asellers\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\"\n" +
+	"\x10GetSellerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"z\n" +
+	"\x11GetSellerResponse\x12'\n" +
+	"\x06seller\x18\x01 \x01(\v2\x0f.crawler.SellerR\x06seller\x12<\n" +
+	"\x0erating_history\x18\x02 \x03(\v2\x15.crawler.SellerRatingR\rratingHistory\"9\n" +
+	"\x18ListProductImagesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"\xff\x01\n" +
+	"\fProductImage\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x02 \x01(\x05R\tsortOrder\x12\x19\n" +
+	"\bis_video\x18\x03 \x01(\bR\aisVideo\x12!\n" +
+	"\fcontent_hash\x18\x04 \x01(\tR\vcontentHash\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"image_hash\x18\x06 \x01(\tR\timageHash\x12\x1f\n" +
+	"\varchived_at\x18\a \x01(\tR\n" +
+	"archivedAt\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\b \x01(\tR\tcheckedAt\"\xb0\x01\n" +
+	"\vImageChange\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12(\n" +
+	"\x10old_content_hash\x18\x02 \x01(\tR\x0eoldContentHash\x12(\n" +
+	"\x10new_content_hash\x18\x03 \x01(\tR\x0enewContentHash\x12\x1a\n" +
+	"\bdistance\x18\x04 \x01(\x05R\bdistance\x12\x1f\n" +
+	"\vdetected_at\x18\x05 \x01(\tR\n" +
+	"detectedAt\"z\n" +
+	"\x19ListProductImagesResponse\x12-\n" +
+	"\x06images\x18\x01 \x03(\v2\x15.crawler.ProductImageR\x06images\x12.\n" +
+	"\achanges\x18\x02 \x03(\v2\x14.crawler.ImageChangeR\achanges\"4\n" +
+	"\x0fGetImageRequest\x12!\n" +
+	"\fcontent_hash\x18\x01 \x01(\tR\vcontentHash\"I\n" +
+	"\x10GetImageResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\x94\x01\n" +
+	"\x18GetProductHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\tR\x05since\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x05 \x01(\x05R\aperPage\"\xb6\x01\n" +
+	"\rProductChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12 \n" +
+	"\told_value\x18\x03 \x01(\tH\x00R\boldValue\x88\x01\x01\x12 \n" +
+	"\tnew_value\x18\x04 \x01(\tH\x01R\bnewValue\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\tR\tchangedAtB\f\n" +
+	"\n" +
+	"_old_valueB\f\n" +
+	"\n" +
+	"_new_value\"c\n" +
+	"\x19GetProductHistoryResponse\x120\n" +
+	"\achanges\x18\x01 \x03(\v2\x16.crawler.ProductChangeR\achanges\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"f\n" +
+	"\x14ListCrawlRunsRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\x05R\aperPage\"\xef\x03\n" +
+	"\bCrawlRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x18\n" +
+	"\atrigger\x18\x03 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\tR\n" +
+	"finishedAt\x12#\n" +
+	"\rpages_fetched\x18\a \x01(\x05R\fpagesFetched\x12+\n" +
+	"\x11bytes_transferred\x18\b \x01(\x03R\x10bytesTransferred\x12%\n" +
+	"\x0eproducts_found\x18\t \x01(\x05R\rproductsFound\x12!\n" +
+	"\fproducts_new\x18\n" +
+	" \x01(\x05R\vproductsNew\x12)\n" +
+	"\x10products_updated\x18\v \x01(\x05R\x0fproductsUpdated\x121\n" +
+	"\x14products_deactivated\x18\f \x01(\x05R\x13productsDeactivated\x12\x1f\n" +
+	"\verror_count\x18\r \x01(\x05R\n" +
+	"errorCount\x12%\n" +
+	"\x0eimages_fetched\x18\x0e \x01(\x05R\rimagesFetched\"T\n" +
+	"\x15ListCrawlRunsResponse\x12%\n" +
+	"\x04runs\x18\x01 \x03(\v2\x11.crawler.CrawlRunR\x04runs\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"$\n" +
+	"\x12GetCrawlRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
+	"\rCrawlRunError\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1f\n" +
+	"\voccurred_at\x18\x02 \x01(\tR\n" +
+	"occurredAt\"j\n" +
+	"\x13GetCrawlRunResponse\x12#\n" +
+	"\x03run\x18\x01 \x01(\v2\x11.crawler.CrawlRunR\x03run\x12.\n" +
+	"\x06errors\x18\x02 \x03(\v2\x16.crawler.CrawlRunErrorR\x06errors2\xd3\b\n" +
 	"\x0eCrawlerService\x12;\n" +
 	"\x06Health\x12\x16.crawler.HealthRequest\x1a\x17.crawler.HealthResponse\"\x00\x12S\n" +
 	"\x0eListCategories\x12\x1e.crawler.ListCategoriesRequest\x1a\x1f.crawler.ListCategoriesResponse\"\x00\x12\\\n" +
 	"\x11RefreshCategories\x12!.crawler.RefreshCategoriesRequest\x1a\".crawler.RefreshCategoriesResponse\"\x00\x12M\n" +
 	"\fListProducts\x12\x1c.crawler.ListProductsRequest\x1a\x1d.crawler.ListProductsResponse\"\x00\x12G\n" +
 	"\n" +
-	"GetProduct\x12\x1a.crawler.GetProductRequest\x1a\x1b.crawler.GetProductResponse\"\x00B9Z7github.com/faisaloncode/ecommerce-crawler/crawler/protob\x06proto3"
+	"GetProduct\x12\x1a.crawler.GetProductRequest\x1a\x1b.crawler.GetProductResponse\"\x00\x12G\n" +
+	"\n" +
+	"ListBrands\x12\x1a.crawler.ListBrandsRequest\x1a\x1b.crawler.ListBrandsResponse\"\x00\x12A\n" +
+	"\bGetBrand\x12\x18.crawler.GetBrandRequest\x1a\x19.crawler.GetBrandResponse\"\x00\x12J\n" +
+	"\vListSellers\x12\x1b.crawler.ListSellersRequest\x1a\x1c.crawler.ListSellersResponse\"\x00\x12D\n" +
+	"\tGetSeller\x12\x19.crawler.GetSellerRequest\x1a\x1a.crawler.GetSellerResponse\"\x00\x12\\\n" +
+	"\x11ListProductImages\x12!.crawler.ListProductImagesRequest\x1a\".crawler.ListProductImagesResponse\"\x00\x12A\n" +
+	"\bGetImage\x12\x18.crawler.GetImageRequest\x1a\x19.crawler.GetImageResponse\"\x00\x12\\\n" +
+	"\x11GetProductHistory\x12!.crawler.GetProductHistoryRequest\x1a\".crawler.GetProductHistoryResponse\"\x00\x12P\n" +
+	"\rListCrawlRuns\x12\x1d.crawler.ListCrawlRunsRequest\x1a\x1e.crawler.ListCrawlRunsResponse\"\x00\x12J\n" +
+	"\vGetCrawlRun\x12\x1b.crawler.GetCrawlRunRequest\x1a\x1c.crawler.GetCrawlRunResponse\"\x00B9Z7github.com/faisaloncode/ecommerce-crawler/crawler/protob\x06proto3"
 
 var (
 	file_proto_crawler_proto_rawDescOnce sync.Once
@@ -674,7 +2652,7 @@ func file_proto_crawler_proto_rawDescGZIP() []byte {
 	return file_proto_crawler_proto_rawDescData
 }
 
-var file_proto_crawler_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_crawler_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_crawler_proto_goTypes = []any{
 	(*HealthRequest)(nil),             // 0: crawler.HealthRequest
 	(*HealthResponse)(nil),            // 1: crawler.HealthResponse
@@ -688,26 +2666,86 @@ var file_proto_crawler_proto_goTypes = []any{
 	(*ListProductsResponse)(nil),      // 9: crawler.ListProductsResponse
 	(*GetProductRequest)(nil),         // 10: crawler.GetProductRequest
 	(*GetProductResponse)(nil),        // 11: crawler.GetProductResponse
+	(*ProductSnapshot)(nil),           // 12: crawler.ProductSnapshot
+	(*PriceStats)(nil),                // 13: crawler.PriceStats
+	(*Brand)(nil),                     // 14: crawler.Brand
+	(*Seller)(nil),                    // 15: crawler.Seller
+	(*SellerRating)(nil),              // 16: crawler.SellerRating
+	(*ListBrandsRequest)(nil),         // 17: crawler.ListBrandsRequest
+	(*ListBrandsResponse)(nil),        // 18: crawler.ListBrandsResponse
+	(*GetBrandRequest)(nil),           // 19: crawler.GetBrandRequest
+	(*GetBrandResponse)(nil),          // 20: crawler.GetBrandResponse
+	(*ListSellersRequest)(nil),        // 21: crawler.ListSellersRequest
+	(*ListSellersResponse)(nil),       // 22: crawler.ListSellersResponse
+	(*GetSellerRequest)(nil),          // 23: crawler.GetSellerRequest
+	(*GetSellerResponse)(nil),         // 24: crawler.GetSellerResponse
+	(*ListProductImagesRequest)(nil),  // 25: crawler.ListProductImagesRequest
+	(*ProductImage)(nil),              // 26: crawler.ProductImage
+	(*ImageChange)(nil),               // 27: crawler.ImageChange
+	(*ListProductImagesResponse)(nil), // 28: crawler.ListProductImagesResponse
+	(*GetImageRequest)(nil),           // 29: crawler.GetImageRequest
+	(*GetImageResponse)(nil),          // 30: crawler.GetImageResponse
+	(*GetProductHistoryRequest)(nil),  // 31: crawler.GetProductHistoryRequest
+	(*ProductChange)(nil),             // 32: crawler.ProductChange
+	(*GetProductHistoryResponse)(nil), // 33: crawler.GetProductHistoryResponse
+	(*ListCrawlRunsRequest)(nil),      // 34: crawler.ListCrawlRunsRequest
+	(*CrawlRun)(nil),                  // 35: crawler.CrawlRun
+	(*ListCrawlRunsResponse)(nil),     // 36: crawler.ListCrawlRunsResponse
+	(*GetCrawlRunRequest)(nil),        // 37: crawler.GetCrawlRunRequest
+	(*CrawlRunError)(nil),             // 38: crawler.CrawlRunError
+	(*GetCrawlRunResponse)(nil),       // 39: crawler.GetCrawlRunResponse
 }
 var file_proto_crawler_proto_depIdxs = []int32{
 	2,  // 0: crawler.ListCategoriesResponse.categories:type_name -> crawler.Category
 	3,  // 1: crawler.ListProductsResponse.products:type_name -> crawler.Product
 	3,  // 2: crawler.GetProductResponse.product:type_name -> crawler.Product
-	0,  // 3: crawler.CrawlerService.Health:input_type -> crawler.HealthRequest
-	4,  // 4: crawler.CrawlerService.ListCategories:input_type -> crawler.ListCategoriesRequest
-	6,  // 5: crawler.CrawlerService.RefreshCategories:input_type -> crawler.RefreshCategoriesRequest
-	8,  // 6: crawler.CrawlerService.ListProducts:input_type -> crawler.ListProductsRequest
-	10, // 7: crawler.CrawlerService.GetProduct:input_type -> crawler.GetProductRequest
-	1,  // 8: crawler.CrawlerService.Health:output_type -> crawler.HealthResponse
-	5,  // 9: crawler.CrawlerService.ListCategories:output_type -> crawler.ListCategoriesResponse
-	7,  // 10: crawler.CrawlerService.RefreshCategories:output_type -> crawler.RefreshCategoriesResponse
-	9,  // 11: crawler.CrawlerService.ListProducts:output_type -> crawler.ListProductsResponse
-	11, // 12: crawler.CrawlerService.GetProduct:output_type -> crawler.GetProductResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	12, // 3: crawler.GetProductResponse.snapshot:type_name -> crawler.ProductSnapshot
+	13, // 4: crawler.Brand.prices:type_name -> crawler.PriceStats
+	13, // 5: crawler.Seller.prices:type_name -> crawler.PriceStats
+	14, // 6: crawler.ListBrandsResponse.brands:type_name -> crawler.Brand
+	14, // 7: crawler.GetBrandResponse.brand:type_name -> crawler.Brand
+	15, // 8: crawler.ListSellersResponse.sellers:type_name -> crawler.Seller
+	15, // 9: crawler.GetSellerResponse.seller:type_name -> crawler.Seller
+	16, // 10: crawler.GetSellerResponse.rating_history:type_name -> crawler.SellerRating
+	26, // 11: crawler.ListProductImagesResponse.images:type_name -> crawler.ProductImage
+	27, // 12: crawler.ListProductImagesResponse.changes:type_name -> crawler.ImageChange
+	32, // 13: crawler.GetProductHistoryResponse.changes:type_name -> crawler.ProductChange
+	35, // 14: crawler.ListCrawlRunsResponse.runs:type_name -> crawler.CrawlRun
+	35, // 15: crawler.GetCrawlRunResponse.run:type_name -> crawler.CrawlRun
+	38, // 16: crawler.GetCrawlRunResponse.errors:type_name -> crawler.CrawlRunError
+	0,  // 17: crawler.CrawlerService.Health:input_type -> crawler.HealthRequest
+	4,  // 18: crawler.CrawlerService.ListCategories:input_type -> crawler.ListCategoriesRequest
+	6,  // 19: crawler.CrawlerService.RefreshCategories:input_type -> crawler.RefreshCategoriesRequest
+	8,  // 20: crawler.CrawlerService.ListProducts:input_type -> crawler.ListProductsRequest
+	10, // 21: crawler.CrawlerService.GetProduct:input_type -> crawler.GetProductRequest
+	17, // 22: crawler.CrawlerService.ListBrands:input_type -> crawler.ListBrandsRequest
+	19, // 23: crawler.CrawlerService.GetBrand:input_type -> crawler.GetBrandRequest
+	21, // 24: crawler.CrawlerService.ListSellers:input_type -> crawler.ListSellersRequest
+	23, // 25: crawler.CrawlerService.GetSeller:input_type -> crawler.GetSellerRequest
+	25, // 26: crawler.CrawlerService.ListProductImages:input_type -> crawler.ListProductImagesRequest
+	29, // 27: crawler.CrawlerService.GetImage:input_type -> crawler.GetImageRequest
+	31, // 28: crawler.CrawlerService.GetProductHistory:input_type -> crawler.GetProductHistoryRequest
+	34, // 29: crawler.CrawlerService.ListCrawlRuns:input_type -> crawler.ListCrawlRunsRequest
+	37, // 30: crawler.CrawlerService.GetCrawlRun:input_type -> crawler.GetCrawlRunRequest
+	1,  // 31: crawler.CrawlerService.Health:output_type -> crawler.HealthResponse
+	5,  // 32: crawler.CrawlerService.ListCategories:output_type -> crawler.ListCategoriesResponse
+	7,  // 33: crawler.CrawlerService.RefreshCategories:output_type -> crawler.RefreshCategoriesResponse
+	9,  // 34: crawler.CrawlerService.ListProducts:output_type -> crawler.ListProductsResponse
+	11, // 35: crawler.CrawlerService.GetProduct:output_type -> crawler.GetProductResponse
+	18, // 36: crawler.CrawlerService.ListBrands:output_type -> crawler.ListBrandsResponse
+	20, // 37: crawler.CrawlerService.GetBrand:output_type -> crawler.GetBrandResponse
+	22, // 38: crawler.CrawlerService.ListSellers:output_type -> crawler.ListSellersResponse
+	24, // 39: crawler.CrawlerService.GetSeller:output_type -> crawler.GetSellerResponse
+	28, // 40: crawler.CrawlerService.ListProductImages:output_type -> crawler.ListProductImagesResponse
+	30, // 41: crawler.CrawlerService.GetImage:output_type -> crawler.GetImageResponse
+	33, // 42: crawler.CrawlerService.GetProductHistory:output_type -> crawler.GetProductHistoryResponse
+	36, // 43: crawler.CrawlerService.ListCrawlRuns:output_type -> crawler.ListCrawlRunsResponse
+	39, // 44: crawler.CrawlerService.GetCrawlRun:output_type -> crawler.GetCrawlRunResponse
+	31, // [31:45] is the sub-list for method output_type
+	17, // [17:31] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_proto_crawler_proto_init() }
@@ -715,13 +2753,14 @@ func file_proto_crawler_proto_init() {
 	if File_proto_crawler_proto != nil {
 		return
 	}
+	file_proto_crawler_proto_msgTypes[32].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_crawler_proto_rawDesc), len(file_proto_crawler_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,15 @@ const (
 	CrawlerService_RefreshCategories_FullMethodName = "/crawler.CrawlerService/RefreshCategories"
 	CrawlerService_ListProducts_FullMethodName      = "/crawler.CrawlerService/ListProducts"
 	CrawlerService_GetProduct_FullMethodName        = "/crawler.CrawlerService/GetProduct"
+	CrawlerService_ListBrands_FullMethodName        = "/crawler.CrawlerService/ListBrands"
+	CrawlerService_GetBrand_FullMethodName          = "/crawler.CrawlerService/GetBrand"
+	CrawlerService_ListSellers_FullMethodName       = "/crawler.CrawlerService/ListSellers"
+	CrawlerService_GetSeller_FullMethodName         = "/crawler.CrawlerService/GetSeller"
+	CrawlerService_ListProductImages_FullMethodName = "/crawler.CrawlerService/ListProductImages"
+	CrawlerService_GetImage_FullMethodName          = "/crawler.CrawlerService/GetImage"
+	CrawlerService_GetProductHistory_FullMethodName = "/crawler.CrawlerService/GetProductHistory"
+	CrawlerService_ListCrawlRuns_FullMethodName     = "/crawler.CrawlerService/ListCrawlRuns"
+	CrawlerService_GetCrawlRun_FullMethodName       = "/crawler.CrawlerService/GetCrawlRun"
 )
 
 // CrawlerServiceClient is the client API for CrawlerService service.
@@ -35,6 +44,15 @@ type CrawlerServiceClient interface {
 	RefreshCategories(ctx context.Context, in *RefreshCategoriesRequest, opts ...grpc.CallOption) (*RefreshCategoriesResponse, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*GetProductResponse, error)
+	ListBrands(ctx context.Context, in *ListBrandsRequest, opts ...grpc.CallOption) (*ListBrandsResponse, error)
+	GetBrand(ctx context.Context, in *GetBrandRequest, opts ...grpc.CallOption) (*GetBrandResponse, error)
+	ListSellers(ctx context.Context, in *ListSellersRequest, opts ...grpc.CallOption) (*ListSellersResponse, error)
+	GetSeller(ctx context.Context, in *GetSellerRequest, opts ...grpc.CallOption) (*GetSellerResponse, error)
+	ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (*ListProductImagesResponse, error)
+	GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*GetImageResponse, error)
+	GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error)
+	ListCrawlRuns(ctx context.Context, in *ListCrawlRunsRequest, opts ...grpc.CallOption) (*ListCrawlRunsResponse, error)
+	GetCrawlRun(ctx context.Context, in *GetCrawlRunRequest, opts ...grpc.CallOption) (*GetCrawlRunResponse, error)
 }
 
 type crawlerServiceClient struct {
@@ -95,6 +113,96 @@ func (c *crawlerServiceClient) GetProduct(ctx context.Context, in *GetProductReq
 	return out, nil
 }
 
+func (c *crawlerServiceClient) ListBrands(ctx context.Context, in *ListBrandsRequest, opts ...grpc.CallOption) (*ListBrandsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBrandsResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_ListBrands_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) GetBrand(ctx context.Context, in *GetBrandRequest, opts ...grpc.CallOption) (*GetBrandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBrandResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_GetBrand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) ListSellers(ctx context.Context, in *ListSellersRequest, opts ...grpc.CallOption) (*ListSellersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSellersResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_ListSellers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) GetSeller(ctx context.Context, in *GetSellerRequest, opts ...grpc.CallOption) (*GetSellerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSellerResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_GetSeller_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) ListProductImages(ctx context.Context, in *ListProductImagesRequest, opts ...grpc.CallOption) (*ListProductImagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductImagesResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_ListProductImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) GetImage(ctx context.Context, in *GetImageRequest, opts ...grpc.CallOption) (*GetImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImageResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_GetImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProductHistoryResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_GetProductHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) ListCrawlRuns(ctx context.Context, in *ListCrawlRunsRequest, opts ...grpc.CallOption) (*ListCrawlRunsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCrawlRunsResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_ListCrawlRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *crawlerServiceClient) GetCrawlRun(ctx context.Context, in *GetCrawlRunRequest, opts ...grpc.CallOption) (*GetCrawlRunResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCrawlRunResponse)
+	err := c.cc.Invoke(ctx, CrawlerService_GetCrawlRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CrawlerServiceServer is the server API for CrawlerService service.
 // All implementations must embed UnimplementedCrawlerServiceServer
 // for forward compatibility.
@@ -104,6 +212,15 @@ type CrawlerServiceServer interface {
 	RefreshCategories(context.Context, *RefreshCategoriesRequest) (*RefreshCategoriesResponse, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
 	GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error)
+	ListBrands(context.Context, *ListBrandsRequest) (*ListBrandsResponse, error)
+	GetBrand(context.Context, *GetBrandRequest) (*GetBrandResponse, error)
+	ListSellers(context.Context, *ListSellersRequest) (*ListSellersResponse, error)
+	GetSeller(context.Context, *GetSellerRequest) (*GetSellerResponse, error)
+	ListProductImages(context.Context, *ListProductImagesRequest) (*ListProductImagesResponse, error)
+	GetImage(context.Context, *GetImageRequest) (*GetImageResponse, error)
+	GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error)
+	ListCrawlRuns(context.Context, *ListCrawlRunsRequest) (*ListCrawlRunsResponse, error)
+	GetCrawlRun(context.Context, *GetCrawlRunRequest) (*GetCrawlRunResponse, error)
 	mustEmbedUnimplementedCrawlerServiceServer()
 }
 
@@ -129,6 +246,33 @@ func (UnimplementedCrawlerServiceServer) ListProducts(context.Context, *ListProd
 func (UnimplementedCrawlerServiceServer) GetProduct(context.Context, *GetProductRequest) (*GetProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
 }
+func (UnimplementedCrawlerServiceServer) ListBrands(context.Context, *ListBrandsRequest) (*ListBrandsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBrands not implemented")
+}
+func (UnimplementedCrawlerServiceServer) GetBrand(context.Context, *GetBrandRequest) (*GetBrandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrand not implemented")
+}
+func (UnimplementedCrawlerServiceServer) ListSellers(context.Context, *ListSellersRequest) (*ListSellersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSellers not implemented")
+}
+func (UnimplementedCrawlerServiceServer) GetSeller(context.Context, *GetSellerRequest) (*GetSellerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeller not implemented")
+}
+func (UnimplementedCrawlerServiceServer) ListProductImages(context.Context, *ListProductImagesRequest) (*ListProductImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductImages not implemented")
+}
+func (UnimplementedCrawlerServiceServer) GetImage(context.Context, *GetImageRequest) (*GetImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImage not implemented")
+}
+func (UnimplementedCrawlerServiceServer) GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductHistory not implemented")
+}
+func (UnimplementedCrawlerServiceServer) ListCrawlRuns(context.Context, *ListCrawlRunsRequest) (*ListCrawlRunsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCrawlRuns not implemented")
+}
+func (UnimplementedCrawlerServiceServer) GetCrawlRun(context.Context, *GetCrawlRunRequest) (*GetCrawlRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCrawlRun not implemented")
+}
 func (UnimplementedCrawlerServiceServer) mustEmbedUnimplementedCrawlerServiceServer() {}
 func (UnimplementedCrawlerServiceServer) testEmbeddedByValue()                        {}
 
@@ -240,6 +384,168 @@ func _CrawlerService_GetProduct_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CrawlerService_ListBrands_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBrandsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).ListBrands(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_ListBrands_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).ListBrands(ctx, req.(*ListBrandsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_GetBrand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).GetBrand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_GetBrand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).GetBrand(ctx, req.(*GetBrandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_ListSellers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSellersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).ListSellers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_ListSellers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).ListSellers(ctx, req.(*ListSellersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_GetSeller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSellerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).GetSeller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_GetSeller_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).GetSeller(ctx, req.(*GetSellerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_ListProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).ListProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_ListProductImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).ListProductImages(ctx, req.(*ListProductImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_GetImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).GetImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_GetImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).GetImage(ctx, req.(*GetImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_GetProductHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).GetProductHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_GetProductHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).GetProductHistory(ctx, req.(*GetProductHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_ListCrawlRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCrawlRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).ListCrawlRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_ListCrawlRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).ListCrawlRuns(ctx, req.(*ListCrawlRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CrawlerService_GetCrawlRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCrawlRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CrawlerServiceServer).GetCrawlRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CrawlerService_GetCrawlRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CrawlerServiceServer).GetCrawlRun(ctx, req.(*GetCrawlRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CrawlerService_ServiceDesc is the grpc.ServiceDesc for CrawlerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +573,42 @@ var CrawlerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _CrawlerService_GetProduct_Handler,
 		},
+		{
+			MethodName: "ListBrands",
+			Handler:    _CrawlerService_ListBrands_Handler,
+		},
+		{
+			MethodName: "GetBrand",
+			Handler:    _CrawlerService_GetBrand_Handler,
+		},
+		{
+			MethodName: "ListSellers",
+			Handler:    _CrawlerService_ListSellers_Handler,
+		},
+		{
+			MethodName: "GetSeller",
+			Handler:    _CrawlerService_GetSeller_Handler,
+		},
+		{
+			MethodName: "ListProductImages",
+			Handler:    _CrawlerService_ListProductImages_Handler,
+		},
+		{
+			MethodName: "GetImage",
+			Handler:    _CrawlerService_GetImage_Handler,
+		},
+		{
+			MethodName: "GetProductHistory",
+			Handler:    _CrawlerService_GetProductHistory_Handler,
+		},
+		{
+			MethodName: "ListCrawlRuns",
+			Handler:    _CrawlerService_ListCrawlRuns_Handler,
+		},
+		{
+			MethodName: "GetCrawlRun",
+			Handler:    _CrawlerService_GetCrawlRun_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/crawler.proto",
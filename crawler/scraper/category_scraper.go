@@ -3,6 +3,7 @@ package scraper
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -13,18 +14,31 @@ import (
 
 	"github.com/faisaloncode/ecommerce-crawler/crawler/models"
 	"github.com/faisaloncode/ecommerce-crawler/crawler/config"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/pagearchive"
 )
 
 type CategoryScraper struct {
 	db         *gorm.DB
 	httpClient *http.Client
+	apiClient  *http.Client
 	baseURL    string
 }
 
-func NewCategoryScraper(db *gorm.DB, cfg *config.Config) *CategoryScraper {
+// ScrapedCategory is a category as parsed from a categories page.
+type ScrapedCategory struct {
+	Name       string
+	Slug       string
+	ExternalID string
+	Children   []ScrapedCategory
+}
+
+// NewCategoryScraper returns a scraper that keeps the pages it fetches in
+// archive, or keeps nothing when archive is nil.
+func NewCategoryScraper(db *gorm.DB, cfg *config.Config, archive *pagearchive.Archive) *CategoryScraper {
 	return &CategoryScraper{
 		db:         db,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: archive.Client(pagearchive.KindCategoriesHTML, 30*time.Second),
+		apiClient:  archive.Client(pagearchive.KindCategoriesAPI, 30*time.Second),
 		baseURL:    cfg.BaseURL,
 	}
 }
@@ -32,7 +46,7 @@ func NewCategoryScraper(db *gorm.DB, cfg *config.Config) *CategoryScraper {
 // ScrapeCategories fetches all categories from Trendyol
 func (s *CategoryScraper) ScrapeCategories() error {
 	log.Println("Starting category scraping process")
-	
+
 	// Fetch the main page
 	resp, err := s.httpClient.Get(s.baseURL)
 	if err != nil {
@@ -44,73 +58,66 @@ func (s *CategoryScraper) ScrapeCategories() error {
 		return fmt.Errorf("bad response status: %s", resp.Status)
 	}
 
+	categories, err := ParseCategoriesHTML(resp.Body)
+	if err != nil {
+		return err
+	}
+	if _, err := SaveCategories(s.db, categories, false, SaveNew); err != nil {
+		return err
+	}
+
+	log.Println("Category scraping completed successfully")
+	return nil
+}
+
+// ParseCategoriesHTML parses the category navigation of the main page.
+func ParseCategoriesHTML(r io.Reader) ([]ScrapedCategory, error) {
 	// Parse HTML using goquery
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return fmt.Errorf("failed to parse HTML: %w", err)
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
 	// Find main category navigation
 	// Note: These selectors would need to be adjusted based on Trendyol's actual HTML structure
-	mainCategories := doc.Find("nav.main-nav ul.main-menu > li")
-
-	// Process each main category
-	// Capture the scraper instance for use in closure
-	scraper := s
-
-	mainCategories.Each(func(i int, sel *goquery.Selection) {
+	var categories []ScrapedCategory
+	doc.Find("nav.main-nav ul.main-menu > li").Each(func(i int, sel *goquery.Selection) {
 		categoryName := strings.TrimSpace(sel.Find("a span").First().Text())
 		categoryURL, exists := sel.Find("a").First().Attr("href")
-		
-		if exists && categoryName != "" {
-			// Create or update main category
-			mainCategory := models.Category{
-				Name:      categoryName,
-				Slug:      extractSlug(categoryURL),
-				ExternalID: extractCategoryID(categoryURL),
-			}
-			
-			result := scraper.db.Where("name = ?", mainCategory.Name).FirstOrCreate(&mainCategory)
-			if result.Error != nil {
-				log.Printf("Error saving main category %s: %v", mainCategory.Name, result.Error)
-				return
-			}
-			
-			// Process subcategories
-			sel.Find("div.sub-menu .sub-item-list li").Each(func(j int, subSel *goquery.Selection) {
-				subCategoryName := strings.TrimSpace(subSel.Find("a").Text())
-				subCategoryURL, subExists := subSel.Find("a").Attr("href")
-				
-				if subExists && subCategoryName != "" {
-					// Create or update subcategory
-					subCategory := models.Category{
-						Name:      subCategoryName,
-						ParentID:  &mainCategory.ID,
-						Slug:      extractSlug(subCategoryURL),
-						ExternalID: extractCategoryID(subCategoryURL),
-					}
-					
-					result := s.db.Where("name = ? AND parent_id = ?", subCategory.Name, mainCategory.ID).FirstOrCreate(&subCategory)
-					if result.Error != nil {
-						log.Printf("Error saving subcategory %s: %v", subCategory.Name, result.Error)
-					}
-				}
-			})
+		if !exists || categoryName == "" {
+			return
+		}
+		mainCategory := ScrapedCategory{
+			Name:       categoryName,
+			Slug:       extractSlug(categoryURL),
+			ExternalID: extractCategoryID(categoryURL),
 		}
-	})
 
-	log.Println("Category scraping completed successfully")
-	return nil
+		// Process subcategories
+		sel.Find("div.sub-menu .sub-item-list li").Each(func(j int, subSel *goquery.Selection) {
+			subCategoryName := strings.TrimSpace(subSel.Find("a").Text())
+			subCategoryURL, subExists := subSel.Find("a").Attr("href")
+			if subExists && subCategoryName != "" {
+				mainCategory.Children = append(mainCategory.Children, ScrapedCategory{
+					Name:       subCategoryName,
+					Slug:       extractSlug(subCategoryURL),
+					ExternalID: extractCategoryID(subCategoryURL),
+				})
+			}
+		})
+		categories = append(categories, mainCategory)
+	})
+	return categories, nil
 }
 
 // Alternative approach: Use Trendyol's API if available
 func (s *CategoryScraper) ScrapeCategoriesAPI() error {
 	log.Println("Starting category scraping via API")
-	
+
 	// This would be the API endpoint for categories if available
 	apiURL := fmt.Sprintf("%s/api/v1/categories", s.baseURL)
-	
-	resp, err := s.httpClient.Get(apiURL)
+
+	resp, err := s.apiClient.Get(apiURL)
 	if err != nil {
 		return fmt.Errorf("failed to fetch categories API: %w", err)
 	}
@@ -120,61 +127,173 @@ func (s *CategoryScraper) ScrapeCategoriesAPI() error {
 		return fmt.Errorf("bad API response status: %s", resp.Status)
 	}
 
-	// Parse JSON response
-	var categories struct {
+	categories, err := ParseCategoriesAPI(resp.Body)
+	if err != nil {
+		return err
+	}
+	if _, err := SaveCategories(s.db, categories, true, SaveNew); err != nil {
+		return err
+	}
+
+	log.Println("Category scraping via API completed successfully")
+	return nil
+}
+
+// ParseCategoriesAPI parses a response of the categories API.
+func ParseCategoriesAPI(r io.Reader) ([]ScrapedCategory, error) {
+	var body struct {
 		Data []struct {
 			ID       string `json:"id"`
 			Name     string `json:"name"`
-			ParentID string `json:"parentId"`
 			Slug     string `json:"slug"`
 			Children []struct {
-				ID       string `json:"id"`
-				Name     string `json:"name"`
-				ParentID string `json:"parentId"`
-				Slug     string `json:"slug"`
+				ID   string `json:"id"`
+				Name string `json:"name"`
+				Slug string `json:"slug"`
 			} `json:"children"`
 		} `json:"data"`
 	}
-	
-	if err := json.NewDecoder(resp.Body).Decode(&categories); err != nil {
-		return fmt.Errorf("failed to decode API response: %w", err)
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode API response: %w", err)
 	}
 
-	// Process categories from API
-	for _, mainCat := range categories.Data {
-		// Create or update main category
-		var parentID *uint
-		mainCategory := models.Category{
-			ExternalID: mainCat.ID,
-			Name:       mainCat.Name,
-			Slug:       mainCat.Slug,
-			ParentID:   parentID,
-		}
-		
-		result := s.db.Where("external_id = ?", mainCategory.ExternalID).FirstOrCreate(&mainCategory)
-		if result.Error != nil {
-			log.Printf("Error saving main category %s: %v", mainCategory.Name, result.Error)
-			continue
-		}
-		
-		// Process subcategories
+	categories := make([]ScrapedCategory, 0, len(body.Data))
+	for _, mainCat := range body.Data {
+		category := ScrapedCategory{ExternalID: mainCat.ID, Name: mainCat.Name, Slug: mainCat.Slug}
 		for _, subCat := range mainCat.Children {
-			subCategory := models.Category{
+			category.Children = append(category.Children, ScrapedCategory{
 				ExternalID: subCat.ID,
 				Name:       subCat.Name,
 				Slug:       subCat.Slug,
-				ParentID:   &mainCategory.ID,
+			})
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// SaveMode says how parsed data is stored.
+type SaveMode int
+
+const (
+	// SaveNew creates what is not stored yet and leaves stored rows as
+	// they are, as a crawl does.
+	SaveNew SaveMode = iota
+	// SaveUpdate also overwrites stored rows with the parsed values.
+	SaveUpdate
+	// SaveDryRun stores nothing and only reports what SaveUpdate would
+	// change.
+	SaveDryRun
+)
+
+// FieldDiff is a field that saving parsed data changes. Old is empty for
+// a created row.
+type FieldDiff struct {
+	Entity string
+	Key    string
+	Field  string
+	Old    string
+	New    string
+}
+
+// SaveCategories stores parsed categories. Categories parsed from the API
+// are matched to stored ones by external ID, and those parsed from the main
+// page by name and parent. It returns the fields it changed, or in
+// SaveDryRun mode would change.
+func SaveCategories(db *gorm.DB, categories []ScrapedCategory, byExternalID bool, mode SaveMode) ([]FieldDiff, error) {
+	var diffs []FieldDiff
+	var save func(scraped ScrapedCategory, parent *models.Category) error
+	save = func(scraped ScrapedCategory, parent *models.Category) error {
+		var parentID *uint
+		if parent != nil {
+			if parent.ID == 0 {
+				// The parent would be created by a dry run; so would this
+				parentID = new(uint)
+			} else {
+				parentID = &parent.ID
+			}
+		}
+
+		var stored models.Category
+		query := db.Where("external_id = ?", scraped.ExternalID)
+		if !byExternalID {
+			query = db.Where("name = ?", scraped.Name)
+			if parentID != nil {
+				query = query.Where("parent_id = ?", *parentID)
+			}
+		}
+		result := query.Limit(1).Find(&stored)
+		if result.Error != nil {
+			return fmt.Errorf("failed to get category %s: %w", scraped.Name, result.Error)
+		}
+		found := result.RowsAffected > 0
+
+		key := scraped.ExternalID
+		if !byExternalID {
+			key = scraped.Name
+		}
+		category := models.Category{
+			ID:         stored.ID,
+			Name:       scraped.Name,
+			Slug:       scraped.Slug,
+			ExternalID: scraped.ExternalID,
+			ParentID:   parentID,
+		}
+		for _, f := range []struct {
+			field         string
+			before, after string
+		}{
+			{"name", stored.Name, category.Name},
+			{"slug", stored.Slug, category.Slug},
+			{"external_id", stored.ExternalID, category.ExternalID},
+			{"parent_id", formatCategoryID(stored.ParentID), formatCategoryID(category.ParentID)},
+		} {
+			if !found || f.before != f.after {
+				diffs = append(diffs, FieldDiff{Entity: "category", Key: key, Field: f.field, Old: f.before, New: f.after})
+			}
+		}
+
+		switch {
+		case mode == SaveDryRun:
+			if !found {
+				category.ID = 0
+			}
+		case !found:
+			if err := db.Create(&category).Error; err != nil {
+				return fmt.Errorf("failed to save category %s: %w", scraped.Name, err)
+			}
+		case mode == SaveUpdate:
+			if err := db.Model(&stored).Select("name", "slug", "external_id", "parent_id").Updates(&category).Error; err != nil {
+				return fmt.Errorf("failed to update category %s: %w", scraped.Name, err)
 			}
-			
-			result := s.db.Where("external_id = ?", subCategory.ExternalID).FirstOrCreate(&subCategory)
-			if result.Error != nil {
-				log.Printf("Error saving subcategory %s: %v", subCategory.Name, result.Error)
+		default:
+			category = stored
+		}
+
+		for _, child := range scraped.Children {
+			if err := save(child, &category); err != nil {
+				return err
 			}
 		}
+		return nil
 	}
 
-	log.Println("Category scraping via API completed successfully")
-	return nil
+	for _, category := range categories {
+		if err := save(category, nil); err != nil {
+			return diffs, err
+		}
+	}
+	return diffs, nil
+}
+
+func formatCategoryID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	if *id == 0 {
+		return "(new)"
+	}
+	return fmt.Sprint(*id)
 }
 
 // Mock categories for development/testing when site is not available
@@ -0,0 +1,195 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/config"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/pagearchive"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// productStatePrefix starts the script a product page embeds its data in.
+const productStatePrefix = "window.__PRODUCT_DETAIL_APP_INITIAL_STATE__"
+
+type ProductScraper struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewProductScraper returns a scraper that keeps the pages it fetches in
+// archive, or keeps nothing when archive is nil.
+func NewProductScraper(cfg *config.Config, archive *pagearchive.Archive) *ProductScraper {
+	return &ProductScraper{
+		httpClient: archive.Client(pagearchive.KindProduct, 30*time.Second),
+		baseURL:    cfg.BaseURL,
+	}
+}
+
+// Configured reports whether a site to scrape is configured. Without one
+// the crawler uses mock products.
+func (s *ProductScraper) Configured() bool {
+	return s.baseURL != ""
+}
+
+// ScrapeProduct fetches a product's page and returns the product it shows
+// with the raw page. fetched, when not nil, is called with the size of the
+// page downloaded, whatever its status.
+func (s *ProductScraper) ScrapeProduct(ctx context.Context, productExternalID string, fetched func(size int)) (*analysispb.ProductData, []byte, error) {
+	pageURL := fmt.Sprintf("%s/p/%s", s.baseURL, url.PathEscape(productExternalID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build product request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch product page: %w", err)
+	}
+	page, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read product page: %w", err)
+	}
+	if fetched != nil {
+		fetched(len(page))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("bad product page response status: %s", resp.Status)
+	}
+	product, err := ParseProductPage(bytes.NewReader(page))
+	if err != nil {
+		return nil, nil, err
+	}
+	return product, page, nil
+}
+
+// ParseProductPage parses the product a product page embeds as its initial
+// state. The category is left empty: pages name the site's category, which
+// the crawler resolves itself.
+func ParseProductPage(r io.Reader) (*analysispb.ProductData, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+	var state string
+	doc.Find("script").EachWithBreak(func(i int, sel *goquery.Selection) bool {
+		text := strings.TrimSpace(sel.Text())
+		if !strings.HasPrefix(text, productStatePrefix) {
+			return true
+		}
+		state = strings.TrimLeft(strings.TrimPrefix(text, productStatePrefix), " =")
+		return false
+	})
+	if state == "" {
+		return nil, fmt.Errorf("no product state in page")
+	}
+
+	// The state is followed by further assignments, which the decoder
+	// leaves unread
+	var body struct {
+		Product struct {
+			ID          json.Number `json:"id"`
+			Name        string      `json:"name"`
+			Description string      `json:"description"`
+			Brand       struct {
+				ID   json.Number `json:"id"`
+				Name string      `json:"name"`
+			} `json:"brand"`
+			Merchant struct {
+				ID          json.Number `json:"id"`
+				Name        string      `json:"name"`
+				SellerScore float32     `json:"sellerScore"`
+			} `json:"merchant"`
+			RatingScore struct {
+				AverageRating float32 `json:"averageRating"`
+				TotalCount    int32   `json:"totalCount"`
+			} `json:"ratingScore"`
+			FavoriteCount      int32    `json:"favoriteCount"`
+			SizeRecommendation string   `json:"sizeRecommendation"`
+			EstimatedDelivery  string   `json:"deliveryInformation"`
+			IsSellable         bool     `json:"isSellable"`
+			Color              string   `json:"color"`
+			Currency           string   `json:"currency"`
+			Images             []string `json:"images"`
+			Attributes         []struct {
+				Key   struct{ Name string } `json:"key"`
+				Value struct{ Name string } `json:"value"`
+			} `json:"attributes"`
+			Variants []struct {
+				ItemNumber     json.Number `json:"itemNumber"`
+				Barcode        string      `json:"barcode"`
+				AttributeValue string      `json:"attributeValue"`
+				Stock          int32       `json:"stock"`
+				Sellable       bool        `json:"sellable"`
+				Price          struct {
+					SellingPrice  struct{ Value json.Number } `json:"sellingPrice"`
+					OriginalPrice struct{ Value json.Number } `json:"originalPrice"`
+				} `json:"price"`
+			} `json:"variants"`
+		} `json:"product"`
+	}
+	if err := json.NewDecoder(strings.NewReader(state)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode product state: %w", err)
+	}
+	p := body.Product
+	if p.ID == "" {
+		return nil, fmt.Errorf("product state has no product ID")
+	}
+
+	product := &analysispb.ProductData{
+		Id:                 p.ID.String(),
+		Name:               p.Name,
+		Description:        p.Description,
+		BrandId:            p.Brand.ID.String(),
+		BrandName:          p.Brand.Name,
+		SellerId:           p.Merchant.ID.String(),
+		SellerName:         p.Merchant.Name,
+		SellerRating:       p.Merchant.SellerScore,
+		RatingScore:        p.RatingScore.AverageRating,
+		CommentCount:       p.RatingScore.TotalCount,
+		FavoriteCount:      p.FavoriteCount,
+		SizeRecommendation: p.SizeRecommendation,
+		EstimatedDelivery:  p.EstimatedDelivery,
+		IsActive:           p.IsSellable,
+		Currency:           p.Currency,
+	}
+	for i, imageURL := range p.Images {
+		product.Images = append(product.Images, &analysispb.ProductImage{Url: imageURL, SortOrder: int32(i)})
+	}
+	for _, a := range p.Attributes {
+		product.Attributes = append(product.Attributes, &analysispb.ProductAttribute{Name: a.Key.Name, Value: a.Value.Name})
+	}
+	for _, v := range p.Variants {
+		variant := &analysispb.ProductVariant{
+			Id:            v.ItemNumber.String(),
+			Sku:           v.Barcode,
+			Color:         p.Color,
+			Size:          v.AttributeValue,
+			StockQuantity: v.Stock,
+			IsActive:      v.Sellable,
+		}
+		// The exact prices are kept as written; the float fields are
+		// approximate
+		if v.Price.SellingPrice.Value != "" {
+			variant.SalePrice = v.Price.SellingPrice.Value.String()
+			price, _ := v.Price.SellingPrice.Value.Float64()
+			variant.Price = float32(price)
+		}
+		if v.Price.OriginalPrice.Value != "" {
+			variant.ListPrice = v.Price.OriginalPrice.Value.String()
+			price, _ := v.Price.OriginalPrice.Value.Float64()
+			variant.OriginalPrice = float32(price)
+		}
+		product.Variants = append(product.Variants, variant)
+	}
+	return product, nil
+}
@@ -0,0 +1,174 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/config"
+	"github.com/faisaloncode/ecommerce-crawler/crawler/pagearchive"
+)
+
+// maxReviewPages bounds how far back one crawl pages through a product's
+// reviews; the rest are picked up by later crawls.
+const maxReviewPages = 20
+
+// Review is a review as scraped from a product's review pages.
+type Review struct {
+	ExternalID   string
+	Rating       int
+	Comment      string
+	ReviewerName string
+	Date         time.Time
+	IsTopReview  bool
+}
+
+type ReviewScraper struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewReviewScraper returns a scraper that keeps the pages it fetches in
+// archive, or keeps nothing when archive is nil.
+func NewReviewScraper(cfg *config.Config, archive *pagearchive.Archive) *ReviewScraper {
+	return &ReviewScraper{
+		httpClient: archive.Client(pagearchive.KindReviews, 30*time.Second),
+		baseURL:    cfg.BaseURL,
+	}
+}
+
+// Configured reports whether a site to scrape is configured. Without one
+// the crawler uses MockReviews.
+func (s *ReviewScraper) Configured() bool {
+	return s.baseURL != ""
+}
+
+// ScrapeReviews fetches a product's reviews written after since, newest
+// first. Review pages are sorted newest first, so paging stops at the first
+// review that is not newer than since. fetched, when not nil, is called
+// with the size of every page downloaded, whatever its status.
+func (s *ReviewScraper) ScrapeReviews(ctx context.Context, productExternalID string, since time.Time, fetched func(size int)) ([]Review, error) {
+	var reviews []Review
+	for page := 0; page < maxReviewPages; page++ {
+		apiURL := fmt.Sprintf("%s/api/v1/products/%s/reviews?orderBy=newest&page=%d",
+			s.baseURL, url.PathEscape(productExternalID), page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build reviews request: %w", err)
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read reviews: %w", err)
+		}
+		if fetched != nil {
+			fetched(len(body))
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("bad reviews response status: %s", resp.Status)
+		}
+		scraped, err := ParseReviews(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if len(scraped) == 0 {
+			return reviews, nil
+		}
+
+		for _, r := range scraped {
+			if !r.Date.After(since) {
+				return reviews, nil
+			}
+			reviews = append(reviews, r)
+		}
+	}
+	return reviews, nil
+}
+
+// ParseReviews parses one page of the reviews API.
+func ParseReviews(r io.Reader) ([]Review, error) {
+	var body struct {
+		Data []struct {
+			ID           string `json:"id"`
+			Rating       int    `json:"rate"`
+			Comment      string `json:"comment"`
+			ReviewerName string `json:"userFullName"`
+			Date         int64  `json:"commentDateISOtype"`
+			IsTopReview  bool   `json:"isElite"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode reviews response: %w", err)
+	}
+
+	reviews := make([]Review, 0, len(body.Data))
+	for _, r := range body.Data {
+		reviews = append(reviews, Review{
+			ExternalID:   r.ID,
+			Rating:       r.Rating,
+			Comment:      r.Comment,
+			ReviewerName: r.ReviewerName,
+			Date:         time.UnixMilli(r.Date).UTC(),
+			IsTopReview:  r.IsTopReview,
+		})
+	}
+	return reviews, nil
+}
+
+// ReviewsProductID returns the external ID of the product whose reviews a
+// reviews API URL fetches.
+func ReviewsProductID(reviewsURL string) (string, bool) {
+	u, err := url.Parse(reviewsURL)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "products" && parts[i+2] == "reviews" {
+			id, err := url.PathUnescape(parts[i+1])
+			return id, err == nil && id != ""
+		}
+	}
+	return "", false
+}
+
+// MockReviews returns a fixed set of reviews for development/testing when
+// the site is not available, filtered like ScrapeReviews.
+func (s *ReviewScraper) MockReviews(productExternalID string, since time.Time) []Review {
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	mock := []Review{
+		{
+			ExternalID:   productExternalID + "-review-" + day.Format("20060102") + "-1",
+			Rating:       5,
+			Comment:      "Great product, fast delivery",
+			ReviewerName: "Mock Reviewer",
+			Date:         day.Add(10 * time.Hour),
+			IsTopReview:  true,
+		},
+		{
+			ExternalID:   productExternalID + "-review-" + day.Format("20060102") + "-2",
+			Rating:       3,
+			Comment:      "Smaller than expected",
+			ReviewerName: "Another Reviewer",
+			Date:         day.Add(9 * time.Hour),
+		},
+	}
+
+	var reviews []Review
+	for _, r := range mock {
+		if r.Date.After(since) {
+			reviews = append(reviews, r)
+		}
+	}
+	return reviews
+}
@@ -0,0 +1,248 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/faisaloncode/ecommerce-crawler/crawler/config"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// fixture opens a page kept in testdata as it was fetched.
+func fixture(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestParseCategoriesHTML(t *testing.T) {
+	got, err := ParseCategoriesHTML(fixture(t, "categories.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ScrapedCategory{
+		{Name: "Women", Slug: "10-women", ExternalID: "10", Children: []ScrapedCategory{
+			{Name: "Dresses", Slug: "11-dresses", ExternalID: "11"},
+			{Name: "Shoes", Slug: "12-shoes", ExternalID: "12"},
+		}},
+		{Name: "Electronics", Slug: "20-electronics", ExternalID: "20"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("categories = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseCategoriesAPI(t *testing.T) {
+	got, err := ParseCategoriesAPI(fixture(t, "categories.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ScrapedCategory{
+		{Name: "Women", Slug: "women", ExternalID: "10", Children: []ScrapedCategory{
+			{Name: "Dresses", Slug: "dresses", ExternalID: "11"},
+			{Name: "Shoes", Slug: "shoes", ExternalID: "12"},
+		}},
+		{Name: "Electronics", Slug: "electronics", ExternalID: "20"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("categories = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseCategoriesAPI(strings.NewReader("<html>")); err == nil {
+		t.Error("parsing a non-JSON response succeeded")
+	}
+}
+
+var fixtureReviews = []Review{
+	{
+		ExternalID:   "r-2",
+		Rating:       5,
+		Comment:      "Great product, fast delivery",
+		ReviewerName: "A** B**",
+		Date:         time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		IsTopReview:  true,
+	},
+	{
+		ExternalID:   "r-1",
+		Rating:       2,
+		Comment:      "Smaller than expected",
+		ReviewerName: "C** D**",
+		Date:         time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestParseReviews(t *testing.T) {
+	got, err := ParseReviews(fixture(t, "reviews.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, fixtureReviews) {
+		t.Errorf("reviews = %+v, want %+v", got, fixtureReviews)
+	}
+}
+
+func TestReviewsProductID(t *testing.T) {
+	tests := []struct {
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{"https://example.com/api/v1/products/12345/reviews?page=0", "12345", true},
+		{"https://example.com/api/v1/products/a%2Fb/reviews", "a/b", true},
+		{"https://example.com/api/v1/products//reviews", "", false},
+		{"https://example.com/api/v1/categories", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ReviewsProductID(tt.url)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ReviewsProductID(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+var fixtureProduct = &analysispb.ProductData{
+	Id:                 "12345",
+	Name:               "Cotton T-shirt",
+	Description:        "A plain cotton T-shirt",
+	BrandId:            "45",
+	BrandName:          "Basics",
+	SellerId:           "67",
+	SellerName:         "Good Seller",
+	SellerRating:       9.2,
+	RatingScore:        4.5,
+	CommentCount:       120,
+	FavoriteCount:      3400,
+	SizeRecommendation: "Runs small",
+	EstimatedDelivery:  "Ships in 2 days",
+	IsActive:           true,
+	Currency:           "TRY",
+	Images: []*analysispb.ProductImage{
+		{Url: "https://cdn.example.com/12345/1.jpg", SortOrder: 0},
+		{Url: "https://cdn.example.com/12345/2.jpg", SortOrder: 1},
+	},
+	Attributes: []*analysispb.ProductAttribute{
+		{Name: "Material", Value: "Cotton"},
+		{Name: "Pattern", Value: "Plain"},
+	},
+	Variants: []*analysispb.ProductVariant{
+		{
+			Id:            "111",
+			Sku:           "869000000001",
+			Color:         "Red",
+			Size:          "M",
+			Price:         99.99,
+			SalePrice:     "99.99",
+			OriginalPrice: 119.90,
+			ListPrice:     "119.90",
+			StockQuantity: 5,
+			IsActive:      true,
+		},
+		{
+			Id:        "112",
+			Sku:       "869000000002",
+			Color:     "Red",
+			Size:      "L",
+			Price:     109.99,
+			SalePrice: "109.99",
+		},
+	},
+}
+
+func TestParseProductPage(t *testing.T) {
+	got, err := ParseProductPage(fixture(t, "product.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, fixtureProduct) {
+		t.Errorf("product = %v, want %v", got, fixtureProduct)
+	}
+}
+
+func TestParseProductPageWithoutState(t *testing.T) {
+	for _, page := range []string{
+		"<html><body><script>window.TYPageName = \"home\";</script></body></html>",
+		"<html><body><script>window.__PRODUCT_DETAIL_APP_INITIAL_STATE__ = {\"product\":{}};</script></body></html>",
+		"<html><body><script>window.__PRODUCT_DETAIL_APP_INITIAL_STATE__ = {</script></body></html>",
+	} {
+		if _, err := ParseProductPage(strings.NewReader(page)); err == nil {
+			t.Errorf("parsing %q succeeded", page)
+		}
+	}
+}
+
+// serveFixtures serves the testdata pages at the paths the scrapers fetch.
+func serveFixtures(t *testing.T, pages map[string]string) *config.Config {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", name))
+	}))
+	t.Cleanup(server.Close)
+	return &config.Config{BaseURL: server.URL}
+}
+
+func TestScrapeProduct(t *testing.T) {
+	cfg := serveFixtures(t, map[string]string{"/p/12345": "product.html"})
+	s := NewProductScraper(cfg, nil)
+
+	var sizes []int
+	fetched := func(size int) { sizes = append(sizes, size) }
+	got, page, err := s.ScrapeProduct(context.Background(), "12345", fetched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, fixtureProduct) {
+		t.Errorf("product = %v, want %v", got, fixtureProduct)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "product.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(page) != string(want) {
+		t.Error("returned page differs from the one served")
+	}
+
+	// A page that fails is still a page fetched
+	if _, _, err := s.ScrapeProduct(context.Background(), "missing", fetched); err == nil {
+		t.Error("scraping a missing product succeeded")
+	}
+	if len(sizes) != 2 || sizes[0] != len(want) || sizes[1] == 0 {
+		t.Errorf("fetched sizes = %v, want the product page's %d bytes then the error page", sizes, len(want))
+	}
+}
+
+func TestScrapeReviewsStopsAtSince(t *testing.T) {
+	cfg := serveFixtures(t, map[string]string{"/api/v1/products/12345/reviews": "reviews.json"})
+	s := NewReviewScraper(cfg, nil)
+
+	// Only the first review is newer than since, so paging stops there
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var pages int
+	got, err := s.ScrapeReviews(context.Background(), "12345", since, func(int) { pages++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, fixtureReviews[:1]) {
+		t.Errorf("reviews = %+v, want %+v", got, fixtureReviews[:1])
+	}
+	if pages != 1 {
+		t.Errorf("fetched %d pages, want 1", pages)
+	}
+}
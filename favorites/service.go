@@ -0,0 +1,172 @@
+package favorites
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	crawlerpb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	"github.com/faisaloncode/ecommerce-crawler/models"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+var (
+	ErrFavoriteNotFound = errors.New("product is not in favorites")
+	ErrProductNotFound  = errors.New("product not found")
+)
+
+// FavoriteStatus is a user's favorite state for a product together with the
+// product's aggregate favorite count.
+type FavoriteStatus struct {
+	ProductID     uint `json:"product_id"`
+	Favorited     bool `json:"favorited"`
+	FavoriteCount int  `json:"favorite_count"`
+}
+
+type FavoriteService struct {
+	db             *gorm.DB
+	crawlerClient  crawlerpb.CrawlerServiceClient
+	analysisClient analysispb.ProductAnalysisServiceClient
+}
+
+func NewFavoriteService(db *gorm.DB, crawlerClient crawlerpb.CrawlerServiceClient, analysisClient analysispb.ProductAnalysisServiceClient) *FavoriteService {
+	return &FavoriteService{
+		db:             db,
+		crawlerClient:  crawlerClient,
+		analysisClient: analysisClient,
+	}
+}
+
+// ListFavorites returns the user's favorites, newest first.
+func (s *FavoriteService) ListFavorites(ctx context.Context, userID uint) ([]models.UserFavorite, error) {
+	var favorites []models.UserFavorite
+	result := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&favorites)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list favorites: %w", result.Error)
+	}
+	return favorites, nil
+}
+
+// GetFavoriteStatus reports whether the user has favorited the product and
+// how many users have.
+func (s *FavoriteService) GetFavoriteStatus(ctx context.Context, userID, productID uint) (*FavoriteStatus, error) {
+	var favorited int64
+	if err := s.db.WithContext(ctx).Model(&models.UserFavorite{}).
+		Where("user_id = ? AND product_id = ?", userID, productID).
+		Count(&favorited).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up favorite: %w", err)
+	}
+
+	var count models.ProductFavoriteCount
+	result := s.db.WithContext(ctx).Where("product_id = ?", productID).Limit(1).Find(&count)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get favorite count: %w", result.Error)
+	}
+
+	return &FavoriteStatus{ProductID: productID, Favorited: favorited > 0, FavoriteCount: count.FavoriteCount}, nil
+}
+
+// AddFavorite favorites a product for the user. Favoriting a product twice is
+// not an error and does not change the count.
+func (s *FavoriteService) AddFavorite(ctx context.Context, userID, productID uint) (*FavoriteStatus, error) {
+	if err := s.ensureProductExists(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	var (
+		added bool
+		count int
+	)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		favorite := &models.UserFavorite{UserID: userID, ProductID: productID}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite)
+		if result.Error != nil {
+			return fmt.Errorf("failed to add favorite: %w", result.Error)
+		}
+		added = result.RowsAffected > 0
+
+		if !added {
+			return tx.Model(&models.ProductFavoriteCount{}).
+				Select("favorite_count").
+				Where("product_id = ?", productID).
+				Scan(&count).Error
+		}
+		return tx.Raw(`INSERT INTO product_favorite_counts (product_id, favorite_count, updated_at)
+			VALUES (?, 1, ?)
+			ON CONFLICT (product_id) DO UPDATE
+			SET favorite_count = product_favorite_counts.favorite_count + 1, updated_at = EXCLUDED.updated_at
+			RETURNING favorite_count`, productID, time.Now()).Scan(&count).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if added {
+		s.syncProductPriority(ctx, productID)
+	}
+	return &FavoriteStatus{ProductID: productID, Favorited: true, FavoriteCount: count}, nil
+}
+
+// RemoveFavorite unfavorites a product for the user. The product drops back
+// to normal priority once nobody is interested in it any more.
+func (s *FavoriteService) RemoveFavorite(ctx context.Context, userID, productID uint) (*FavoriteStatus, error) {
+	var count int
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND product_id = ?", userID, productID).Delete(&models.UserFavorite{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove favorite: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrFavoriteNotFound
+		}
+
+		return tx.Raw(`UPDATE product_favorite_counts
+			SET favorite_count = GREATEST(favorite_count - 1, 0), updated_at = ?
+			WHERE product_id = ?
+			RETURNING favorite_count`, time.Now(), productID).Scan(&count).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncProductPriority(ctx, productID)
+	return &FavoriteStatus{ProductID: productID, Favorited: false, FavoriteCount: count}, nil
+}
+
+func (s *FavoriteService) ensureProductExists(ctx context.Context, productID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := s.crawlerClient.GetProduct(ctx, &crawlerpb.GetProductRequest{Id: fmt.Sprint(productID)})
+	if status.Code(err) == codes.NotFound {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up product %d: %w", productID, err)
+	}
+	return nil
+}
+
+// syncProductPriority tells product-analysis that the interest in a product
+// changed. product-analysis derives the priority from the committed favorite
+// counts and notification preferences itself, so concurrent syncs cannot
+// leave a stale one. Failures are logged only; the favorite change has been
+// committed.
+func (s *FavoriteService) syncProductPriority(ctx context.Context, productID uint) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := s.analysisClient.UpdateProductPriority(ctx, &analysispb.UpdateProductPriorityRequest{
+		ProductId: fmt.Sprint(productID),
+	})
+	if err != nil {
+		log.Printf("Failed to update priority for product %d: %v", productID, err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/faisaloncode/ecommerce-crawler/auth"
+	"github.com/faisaloncode/ecommerce-crawler/favorites"
+)
+
+func (api *APIServer) listFavorites(c echo.Context) error {
+	favs, err := api.favoriteService.ListFavorites(c.Request().Context(), auth.UserID(c))
+	if err != nil {
+		return favoriteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, favs)
+}
+
+func (api *APIServer) getFavorite(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+	}
+
+	status, err := api.favoriteService.GetFavoriteStatus(c.Request().Context(), auth.UserID(c), uint(productID))
+	if err != nil {
+		return favoriteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+func (api *APIServer) addFavorite(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+	}
+
+	status, err := api.favoriteService.AddFavorite(c.Request().Context(), auth.UserID(c), uint(productID))
+	if err != nil {
+		return favoriteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+func (api *APIServer) removeFavorite(c echo.Context) error {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+	}
+
+	status, err := api.favoriteService.RemoveFavorite(c.Request().Context(), auth.UserID(c), uint(productID))
+	if err != nil {
+		return favoriteError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// favoriteError maps favorites service errors to HTTP responses.
+func favoriteError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, favorites.ErrFavoriteNotFound), errors.Is(err, favorites.ErrProductNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
@@ -1,28 +1,47 @@
 module github.com/faisaloncode/ecommerce-crawler
 
-replace github.com/faisaloncode/ecommerce-crawler/crawler => ./crawler
-
-go 1.23.0
+replace (
+	github.com/faisaloncode/ecommerce-crawler/crawler => ./crawler
+	github.com/faisaloncode/ecommerce-crawler/identity => ./identity
+	github.com/faisaloncode/ecommerce-crawler/migrations => ./migrations
+	github.com/faisaloncode/ecommerce-crawler/notification => ./notification
+	github.com/faisaloncode/ecommerce-crawler/product-analysis => ./product-analysis
+)
 
-toolchain go1.23.8
+go 1.23.8
 
 require (
 	github.com/faisaloncode/ecommerce-crawler/crawler v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/identity v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/migrations v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/notification v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/product-analysis v0.0.0-00010101000000-000000000000
+	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/labstack/echo/v4 v4.13.3
+	golang.org/x/crypto v0.37.0
 	google.golang.org/grpc v1.72.0
+	gorm.io/driver/postgres v1.5.11
+	gorm.io/gorm v1.26.0
 )
 
 require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/pgx/v5 v5.7.4 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/labstack/gommon v0.4.2 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/shopspring/decimal v1.4.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
-	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sync v0.13.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
 	golang.org/x/time v0.8.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
-	google.golang.org/protobuf v1.36.5 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250425173222-7b384671a197 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
 )
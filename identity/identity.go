@@ -0,0 +1,104 @@
+// Package identity carries the authenticated caller between the gateway and
+// the backend services.
+//
+// The gateway authenticates every request and forwards the user id in the
+// x-user-id gRPC metadata key (or the X-User-ID header for HTTP services).
+// Services read it back with FromContext and scope user-owned data to it.
+// The id is trusted as sent, so the services must be reachable by the
+// gateway only; docker-compose publishes none of their ports.
+package identity
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MetadataKey is the gRPC metadata key holding the caller's user id.
+	MetadataKey = "x-user-id"
+	// Header is the HTTP header holding the caller's user id.
+	Header = "X-User-ID"
+)
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the caller's user id.
+func NewContext(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// FromContext returns the caller's user id, if the request was made on
+// behalf of a user.
+func FromContext(ctx context.Context) (uint, bool) {
+	userID, ok := ctx.Value(contextKey{}).(uint)
+	return userID, ok
+}
+
+// OutgoingContext attaches the caller's user id to outgoing gRPC metadata.
+// Contexts created with NewContext are forwarded automatically.
+func OutgoingContext(ctx context.Context, userID uint) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, strconv.FormatUint(uint64(userID), 10))
+}
+
+// Parse converts a user id taken from metadata or a header.
+func Parse(value string) (uint, bool) {
+	userID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || userID == 0 {
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+// UnaryServerInterceptor copies the caller's user id from incoming metadata
+// into the request context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(fromIncoming(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: fromIncoming(ss.Context())})
+	}
+}
+
+// UnaryClientInterceptor forwards the user id stored with NewContext to the
+// next service.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if userID, ok := FromContext(ctx); ok {
+			ctx = OutgoingContext(ctx, userID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func fromIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ctx
+	}
+	if userID, ok := Parse(values[0]); ok {
+		return NewContext(ctx, userID)
+	}
+	return ctx
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -5,24 +5,46 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"google.golang.org/grpc"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 
-
+	"github.com/faisaloncode/ecommerce-crawler/auth"
+	"github.com/faisaloncode/ecommerce-crawler/config"
 	pb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	"github.com/faisaloncode/ecommerce-crawler/favorites"
+	"github.com/faisaloncode/ecommerce-crawler/identity"
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
+	"github.com/faisaloncode/ecommerce-crawler/models"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
 )
 
+// maxImageMessageSize bounds the GetImage responses the gateway accepts.
+// The crawler archives images of up to 10 MiB.
+const maxImageMessageSize = 11 << 20
+
+// maxSnapshotMessageSize bounds the GetProduct responses the gateway
+// accepts, which carry a page's raw HTML when asked to.
+const maxSnapshotMessageSize = 32 << 20
+
 type APIServer struct {
-	crawlerClient  pb.CrawlerServiceClient
-	analysisClient pb.ProductAnalysisServiceClient
+	crawlerClient     pb.CrawlerServiceClient
+	analysisClient    analysispb.ProductAnalysisServiceClient
+	authService       *auth.AuthService
+	favoriteService   *favorites.FavoriteService
+	notificationProxy *httputil.ReverseProxy
 }
 
 func (api *APIServer) healthCheck(c echo.Context) error {
 	// Check crawler service health
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
 	_, err := api.crawlerClient.Health(ctx, &pb.HealthRequest{})
@@ -37,7 +59,7 @@ func (api *APIServer) healthCheck(c echo.Context) error {
 }
 
 func (api *APIServer) listCategories(c echo.Context) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
 	resp, err := api.crawlerClient.ListCategories(ctx, &pb.ListCategoriesRequest{})
@@ -49,7 +71,7 @@ func (api *APIServer) listCategories(c echo.Context) error {
 }
 
 func (api *APIServer) refreshCategories(c echo.Context) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 30*time.Second)
 	defer cancel()
 
 	_, err := api.crawlerClient.RefreshCategories(ctx, &pb.RefreshCategoriesRequest{})
@@ -60,8 +82,42 @@ func (api *APIServer) refreshCategories(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"status": "refresh started"})
 }
 
+// listCrawlRuns returns a category's crawl history, newest first.
+func (api *APIServer) listCrawlRuns(c echo.Context) error {
+	page, perPage, err := pageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.ListCrawlRuns(ctx, &pb.ListCrawlRunsRequest{
+		CategoryId: c.Param("id"),
+		Page:       page,
+		PerPage:    perPage,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getCrawlRun(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.GetCrawlRun(ctx, &pb.GetCrawlRunRequest{Id: c.Param("id")})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 func (api *APIServer) listProducts(c echo.Context) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
 	resp, err := api.crawlerClient.ListProducts(ctx, &pb.ListProductsRequest{})
@@ -74,39 +130,144 @@ func (api *APIServer) listProducts(c echo.Context) error {
 
 func (api *APIServer) getProduct(c echo.Context) error {
 	id := c.Param("id")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := api.crawlerClient.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+	// With as_of the product is read from its snapshot at that time, and the
+	// snapshot is returned alongside it
+	asOf := c.QueryParam("as_of")
+	var includeHTML bool
+	if value := c.QueryParam("include_html"); value != "" {
+		var err error
+		if includeHTML, err = strconv.ParseBool(value); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid include_html"})
+		}
+	}
+
+	resp, err := api.crawlerClient.GetProduct(ctx, &pb.GetProductRequest{Id: id, AsOf: asOf, IncludeHtml: includeHTML},
+		grpc.MaxCallRecvMsgSize(maxSnapshotMessageSize))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return grpcError(c, err)
 	}
 
+	if asOf != "" {
+		return c.JSON(http.StatusOK, resp)
+	}
 	return c.JSON(http.StatusOK, resp.Product)
 }
 
+func (api *APIServer) listProductImages(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.ListProductImages(ctx, &pb.ListProductImagesRequest{ProductId: c.Param("id")})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (api *APIServer) getProductHistory(c echo.Context) error {
+	page, perPage, err := pageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.GetProductHistory(ctx, &pb.GetProductHistoryRequest{
+		ProductId: c.Param("id"),
+		Field:     c.QueryParam("field"),
+		Since:     c.QueryParam("since"),
+		Page:      page,
+		PerPage:   perPage,
+	})
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// getImage serves an archived image. Images are stored by content hash, so
+// a response never changes and may be cached for good.
+func (api *APIServer) getImage(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := api.crawlerClient.GetImage(ctx, &pb.GetImageRequest{ContentHash: c.Param("hash")},
+		grpc.MaxCallRecvMsgSize(maxImageMessageSize))
+	if err != nil {
+		return grpcError(c, err)
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	c.Response().Header().Set("ETag", `"`+c.Param("hash")+`"`)
+	return c.Blob(http.StatusOK, resp.ContentType, resp.Data)
+}
+
+// proxyToNotifications forwards a request to the notification service. The
+// auth middleware has already set the X-User-ID header.
+func (api *APIServer) proxyToNotifications(c echo.Context) error {
+	api.notificationProxy.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
 func main() {
-	// Set service addresses
-	crawlerAddr := "localhost:50051"
-	analysisAddr := "localhost:50052"
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize database
+	db, err := initDB(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database handle: %v", err)
+	}
+
+	// Run migrations
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if err := migrations.VerifyModels(db, models.Tables()...); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
 
 	// Initialize gRPC clients
-	crawlerConn, err := initGRPCClient(crawlerAddr)
+	crawlerConn, err := initGRPCClient(cfg.CrawlerServiceAddr)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to crawler service: %v", err)
 	}
 	defer crawlerConn.Close()
 
-	analysisConn, err := initGRPCClient(analysisAddr)
+	analysisConn, err := initGRPCClient(cfg.ProductAnalysisServiceAddr)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to product analysis service: %v", err)
 	}
 	defer analysisConn.Close()
 
+	notificationURL, err := url.Parse(cfg.NotificationServiceURL)
+	if err != nil {
+		log.Fatalf("Invalid notification service URL: %v", err)
+	}
+
 	// Initialize API server
+	crawlerClient := pb.NewCrawlerServiceClient(crawlerConn)
+	analysisClient := analysispb.NewProductAnalysisServiceClient(analysisConn)
 	api := &APIServer{
-		crawlerClient:  pb.NewCrawlerServiceClient(crawlerConn),
-		analysisClient: pb.NewProductAnalysisServiceClient(analysisConn),
+		crawlerClient:     crawlerClient,
+		analysisClient:    analysisClient,
+		authService:       auth.NewAuthService(db, cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL),
+		favoriteService:   favorites.NewFavoriteService(db, crawlerClient, analysisClient),
+		notificationProxy: httputil.NewSingleHostReverseProxy(notificationURL),
 	}
 
 	// Setup Echo server
@@ -117,17 +278,81 @@ func main() {
 	// Health check endpoint
 	e.GET("/health", api.healthCheck)
 
+	// Authentication endpoints
+	e.POST("/auth/register", api.register)
+	e.POST("/auth/login", api.login)
+	e.POST("/auth/refresh", api.refresh)
+	e.POST("/auth/logout", api.logout)
+
+	// Everything below requires a bearer token or API key
+	authed := e.Group("", auth.Middleware(api.authService))
+	admin := auth.RequireAdmin(api.authService)
+
+	// Account endpoints
+	authed.GET("/me", api.me)
+	authed.GET("/api-keys", api.listAPIKeys)
+	authed.POST("/api-keys", api.createAPIKey)
+	authed.DELETE("/api-keys/:id", api.revokeAPIKey)
+
 	// Category endpoints
-	e.GET("/categories", api.listCategories)
-	e.POST("/categories/refresh", api.refreshCategories)
+	authed.GET("/categories", api.listCategories)
+	authed.POST("/categories/refresh", api.refreshCategories, admin)
+	authed.GET("/categories/:id/crawl-runs", api.listCrawlRuns)
+	authed.GET("/crawl-runs/:id", api.getCrawlRun)
+
+	// Product endpoints
+	authed.GET("/products", api.listProducts)
+	authed.GET("/products/:id", api.getProduct)
+	authed.GET("/products/:id/forecast", api.getPriceForecast)
+	authed.GET("/products/:id/buy-box", api.getBuyBoxHistory)
+	authed.GET("/products/:id/reviews", api.listReviews)
+	authed.GET("/products/:id/similar", api.getSimilarProducts)
+	authed.GET("/products/:id/images", api.listProductImages)
+	authed.GET("/products/:id/history", api.getProductHistory)
+	authed.GET("/images/:hash", api.getImage)
+
+	// Brand and seller endpoints
+	authed.GET("/brands", api.listBrands)
+	authed.GET("/brands/:id", api.getBrand)
+	authed.GET("/brands/:id/sentiment", api.getBrandSentiment)
+	authed.GET("/sellers", api.listSellers)
+	authed.GET("/sellers/:id", api.getSeller)
+
+	// Canonical product endpoints
+	authed.GET("/canonical-products/:id", api.getCanonicalProduct)
+	authed.POST("/canonical-products/:id/confirm", api.confirmCanonicalProduct, admin)
+	authed.POST("/canonical-products/:id/split", api.splitCanonicalProduct, admin)
+
+	// Merchandising endpoints
+	authed.GET("/trending", api.listTrending)
+	authed.GET("/top-movers", api.listTopMovers)
+
+	// Favorite endpoints
+	authed.GET("/favorites", api.listFavorites)
+	authed.GET("/products/:id/favorite", api.getFavorite)
+	authed.PUT("/products/:id/favorite", api.addFavorite)
+	authed.DELETE("/products/:id/favorite", api.removeFavorite)
+
+	// Notification service endpoints
+	authed.Any("/notifications/*", api.proxyToNotifications)
+	authed.Any("/preferences", api.proxyToNotifications)
+	authed.Any("/users/:user_id/preferences", api.proxyToNotifications)
+	authed.Any("/users/:user_id/preferences/*", api.proxyToNotifications)
 
 	// Start API server
-	port := "8082"
-	e.Logger.Fatal(e.Start(":" + port))
+	e.Logger.Fatal(e.Start(":" + cfg.ServerPort))
+}
+
+func initDB(cfg *config.Config) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPass, cfg.DBName)
+
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 }
 
 func initGRPCClient(addr string) (*grpc.ClientConn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	return grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock())
+	return grpc.DialContext(ctx, addr, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()))
 }
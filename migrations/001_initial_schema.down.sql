@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS stock_history;
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS user_favorites;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS similar_products;
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS product_attributes;
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_images;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS sellers;
+DROP TABLE IF EXISTS brands;
+DROP TABLE IF EXISTS category_crawl_status;
+DROP TABLE IF EXISTS categories;
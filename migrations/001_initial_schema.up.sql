@@ -165,19 +165,30 @@ CREATE TABLE IF NOT EXISTS notifications (
 );
 
 -- Insert some sample data
+-- Databases initialised before schema_migrations existed already hold these
+-- rows, so the seeds must be safe to apply a second time.
 INSERT INTO users (username, email) VALUES
     ('user1', 'user1@example.com'),
-    ('user2', 'user2@example.com');
+    ('user2', 'user2@example.com')
+ON CONFLICT (email) DO NOTHING;
 
 -- Insert some sample categories
-INSERT INTO categories (name, slug) VALUES
+INSERT INTO categories (name, slug)
+SELECT v.name, v.slug
+FROM (VALUES
     ('Electronics', 'electronics'),
     ('Clothing', 'clothing'),
-    ('Home & Garden', 'home-garden');
+    ('Home & Garden', 'home-garden')
+) AS v(name, slug)
+WHERE NOT EXISTS (SELECT 1 FROM categories);
 
 -- Insert some child categories
-INSERT INTO categories (name, parent_id, slug) VALUES
+INSERT INTO categories (name, parent_id, slug)
+SELECT v.name, v.parent_id, v.slug
+FROM (VALUES
     ('Smartphones', 1, 'electronics/smartphones'),
     ('Laptops', 1, 'electronics/laptops'),
     ('Men''s Clothing', 2, 'clothing/mens'),
-    ('Women''s Clothing', 2, 'clothing/womens');
\ No newline at end of file
+    ('Women''s Clothing', 2, 'clothing/womens')
+) AS v(name, parent_id, slug)
+WHERE NOT EXISTS (SELECT 1 FROM categories WHERE parent_id IS NOT NULL);
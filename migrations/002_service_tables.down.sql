@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS update_priorities;
+DROP TABLE IF EXISTS stock_histories;
+DROP TABLE IF EXISTS price_histories;
+DROP TABLE IF EXISTS product_analytics;
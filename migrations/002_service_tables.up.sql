@@ -0,0 +1,71 @@
+-- Tables that product-analysis and the notification service used to create
+-- with GORM AutoMigrate on startup. IF NOT EXISTS keeps this a no-op on
+-- databases where AutoMigrate already created them.
+
+-- product-analysis
+CREATE TABLE IF NOT EXISTS product_analytics (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT,
+    last_analyzed_at TIMESTAMPTZ,
+    price_change_count BIGINT,
+    stock_change_count BIGINT,
+    favorite_count BIGINT,
+    view_count BIGINT,
+    add_to_cart_count BIGINT,
+    order_count BIGINT,
+    popularity_score DECIMAL,
+    update_priority BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_analytics_product_id ON product_analytics (product_id);
+
+CREATE TABLE IF NOT EXISTS price_histories (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id BIGINT,
+    old_price DECIMAL,
+    new_price DECIMAL,
+    changed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_price_histories_variant_id ON price_histories (variant_id);
+
+CREATE TABLE IF NOT EXISTS stock_histories (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id BIGINT,
+    old_quantity BIGINT,
+    new_quantity BIGINT,
+    changed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_stock_histories_variant_id ON stock_histories (variant_id);
+
+CREATE TABLE IF NOT EXISTS update_priorities (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT,
+    priority BIGINT,
+    last_updated TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_update_priorities_product_id ON update_priorities (product_id);
+
+-- notification service
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    user_id VARCHAR(100),
+    product_id VARCHAR(100),
+    variant_id VARCHAR(100) NOT NULL DEFAULT '',
+    min_price DECIMAL(10,2),
+    max_price DECIMAL(10,2),
+    notify_stock BOOLEAN
+);
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS variant_id VARCHAR(100) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_deleted_at ON notification_preferences (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_user_id ON notification_preferences (user_id);
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_product_id ON notification_preferences (product_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_preference_user_product_variant
+    ON notification_preferences (user_id, product_id, variant_id);
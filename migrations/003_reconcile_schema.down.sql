@@ -0,0 +1,75 @@
+-- Restores the 002 shapes. Legacy tables left behind by the up migration
+-- are not merged back.
+
+CREATE TABLE IF NOT EXISTS stock_histories (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id BIGINT,
+    old_quantity BIGINT,
+    new_quantity BIGINT,
+    changed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_stock_histories_variant_id ON stock_histories (variant_id);
+INSERT INTO stock_histories (variant_id, old_quantity, new_quantity, changed_at, created_at)
+SELECT variant_id, old_quantity, new_quantity, changed_at, created_at FROM stock_history ORDER BY id;
+
+CREATE TABLE IF NOT EXISTS price_histories (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id BIGINT,
+    old_price DECIMAL,
+    new_price DECIMAL,
+    changed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_price_histories_variant_id ON price_histories (variant_id);
+INSERT INTO price_histories (variant_id, old_price, new_price, changed_at, created_at)
+SELECT variant_id, old_price, new_price, changed_at, created_at FROM price_history ORDER BY id;
+
+DROP INDEX IF EXISTS idx_stock_history_variant_id;
+DROP INDEX IF EXISTS idx_price_history_variant_id;
+ALTER TABLE stock_history DROP COLUMN created_at;
+ALTER TABLE price_history DROP COLUMN created_at;
+
+DROP INDEX idx_preference_user_product_variant;
+DROP INDEX idx_notification_preferences_product_id;
+DROP INDEX idx_notification_preferences_user_id;
+-- The foreign keys point at integer ids, so they go before the columns
+-- become text
+ALTER TABLE notification_preferences DROP CONSTRAINT IF EXISTS notification_preferences_reconciled_user_id_fkey;
+ALTER TABLE notification_preferences DROP CONSTRAINT IF EXISTS notification_preferences_reconciled_product_id_fkey;
+ALTER TABLE notification_preferences DROP CONSTRAINT IF EXISTS notification_preferences_reconciled_variant_id_fkey;
+ALTER TABLE notification_preferences ALTER COLUMN id TYPE BIGINT;
+ALTER SEQUENCE notification_preferences_id_seq AS BIGINT;
+ALTER TABLE notification_preferences ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN user_id TYPE VARCHAR(100) USING user_id::text;
+ALTER TABLE notification_preferences ALTER COLUMN product_id DROP NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN product_id TYPE VARCHAR(100) USING product_id::text;
+ALTER TABLE notification_preferences ALTER COLUMN variant_id TYPE VARCHAR(100) USING COALESCE(variant_id::text, '');
+ALTER TABLE notification_preferences ALTER COLUMN variant_id SET DEFAULT '';
+ALTER TABLE notification_preferences ALTER COLUMN variant_id SET NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN min_price DROP NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN min_price DROP DEFAULT;
+ALTER TABLE notification_preferences ALTER COLUMN max_price DROP NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN max_price DROP DEFAULT;
+ALTER TABLE notification_preferences ALTER COLUMN notify_stock DROP NOT NULL;
+ALTER TABLE notification_preferences ALTER COLUMN notify_stock DROP DEFAULT;
+ALTER TABLE notification_preferences ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE notification_preferences ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE notification_preferences ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE notification_preferences ALTER COLUMN updated_at DROP DEFAULT;
+ALTER TABLE notification_preferences ADD COLUMN deleted_at TIMESTAMPTZ;
+CREATE INDEX idx_notification_preferences_deleted_at ON notification_preferences (deleted_at);
+CREATE INDEX idx_notification_preferences_user_id ON notification_preferences (user_id);
+CREATE INDEX idx_notification_preferences_product_id ON notification_preferences (product_id);
+CREATE UNIQUE INDEX idx_preference_user_product_variant
+    ON notification_preferences (user_id, product_id, variant_id);
+
+-- notifications goes back to its 001 shape
+DROP INDEX idx_notifications_product_id;
+DROP INDEX idx_notifications_user_id;
+ALTER TABLE notifications DROP COLUMN updated_at;
+ALTER TABLE notifications ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE notifications ALTER COLUMN is_read DROP NOT NULL;
+ALTER TABLE notifications RENAME CONSTRAINT notifications_reconciled_user_id_fkey TO notifications_user_id_fkey;
+ALTER TABLE notifications RENAME CONSTRAINT notifications_reconciled_product_id_fkey TO notifications_product_id_fkey;
+ALTER TABLE notifications RENAME CONSTRAINT notifications_reconciled_variant_id_fkey TO notifications_variant_id_fkey;
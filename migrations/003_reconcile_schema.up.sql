@@ -0,0 +1,215 @@
+-- One authoritative shape for the tables the services used to define
+-- independently:
+--
+--   * notifications and notification_preferences use integer user, product
+--     and variant ids referencing users, products and product_variants.
+--   * price and stock changes live in price_history / stock_history; the
+--     GORM-created price_histories / stock_histories tables are folded in.
+--
+-- Rows that cannot satisfy the foreign keys (for example non-numeric user
+-- ids written by older notification service builds) are kept in legacy_*
+-- tables instead of being discarded.
+
+-- retire_table renames a table that still holds unmapped rows to legacy_*,
+-- along with its indexes and id sequence, so the reconciled table can take
+-- over the original names.
+CREATE FUNCTION pg_temp.retire_table(name TEXT) RETURNS void AS $$
+DECLARE
+    legacy TEXT := 'legacy_' || name;
+    idx RECORD;
+    seq TEXT;
+BEGIN
+    EXECUTE format('ALTER TABLE %I RENAME TO %I', name, legacy);
+    FOR idx IN SELECT indexname FROM pg_indexes
+               WHERE schemaname = current_schema() AND tablename = legacy LOOP
+        EXECUTE format('ALTER INDEX %I RENAME TO %I', idx.indexname, 'legacy_' || idx.indexname);
+    END LOOP;
+    seq := pg_get_serial_sequence(legacy, 'id');
+    IF seq IS NOT NULL THEN
+        EXECUTE format('ALTER SEQUENCE %s RENAME TO %I', seq, 'legacy_' || name || '_id_seq');
+    END IF;
+END $$ LANGUAGE plpgsql;
+
+-- notifications -------------------------------------------------------------
+
+CREATE TABLE notifications_reconciled (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    product_id INTEGER REFERENCES products(id),
+    variant_id INTEGER REFERENCES product_variants(id),
+    notification_type VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$
+DECLARE
+    type_column TEXT;
+    has_variant BOOLEAN;
+    has_deleted BOOLEAN;
+    moved BIGINT;
+    total BIGINT;
+BEGIN
+    -- 001 calls the column notification_type; the notification service
+    -- AutoMigrate called it type and added soft deletes.
+    SELECT column_name INTO type_column FROM information_schema.columns
+    WHERE table_schema = current_schema() AND table_name = 'notifications'
+      AND column_name IN ('notification_type', 'type');
+
+    SELECT EXISTS (SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'notifications' AND column_name = 'variant_id')
+    INTO has_variant;
+
+    SELECT EXISTS (SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'notifications' AND column_name = 'deleted_at')
+    INTO has_deleted;
+
+    -- Without a type column there is nothing to carry the rows' type
+    -- over from, so they are all kept in legacy_notifications
+    IF type_column IS NULL THEN
+        RAISE NOTICE 'notifications: no notification_type or type column, all rows kept in legacy_notifications';
+        PERFORM pg_temp.retire_table('notifications');
+        RETURN;
+    END IF;
+
+    EXECUTE format($q$
+        INSERT INTO notifications_reconciled
+            (user_id, product_id, variant_id, notification_type, message, is_read, created_at, updated_at)
+        SELECT n.user_id::text::integer,
+               NULLIF(n.product_id::text, '')::integer,
+               %s,
+               n.%I, n.message, COALESCE(n.is_read, FALSE), n.created_at, n.created_at
+        FROM notifications n
+        WHERE n.user_id::text ~ '^[0-9]+$'
+          AND EXISTS (SELECT 1 FROM users u WHERE u.id = n.user_id::text::integer)
+          AND (COALESCE(n.product_id::text, '') = ''
+               OR (n.product_id::text ~ '^[0-9]+$'
+                   AND EXISTS (SELECT 1 FROM products p WHERE p.id = n.product_id::text::integer)))
+          %s
+        ORDER BY n.id
+    $q$,
+        CASE WHEN has_variant THEN 'n.variant_id' ELSE 'NULL::integer' END,
+        type_column,
+        CASE WHEN has_deleted THEN 'AND n.deleted_at IS NULL' ELSE '' END);
+    GET DIAGNOSTICS moved = ROW_COUNT;
+
+    EXECUTE 'SELECT count(*) FROM notifications' INTO total;
+    IF has_deleted THEN
+        EXECUTE 'SELECT count(*) FROM notifications WHERE deleted_at IS NULL' INTO total;
+    END IF;
+
+    IF moved < total THEN
+        RAISE NOTICE 'notifications: % of % rows could not be mapped to users/products, kept in legacy_notifications', total - moved, total;
+        PERFORM pg_temp.retire_table('notifications');
+    ELSE
+        DROP TABLE notifications;
+    END IF;
+END $$;
+
+ALTER TABLE notifications_reconciled RENAME TO notifications;
+ALTER SEQUENCE notifications_reconciled_id_seq RENAME TO notifications_id_seq;
+ALTER INDEX notifications_reconciled_pkey RENAME TO notifications_pkey;
+CREATE INDEX idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX idx_notifications_product_id ON notifications (product_id);
+
+-- notification_preferences --------------------------------------------------
+
+CREATE TABLE notification_preferences_reconciled (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    variant_id INTEGER REFERENCES product_variants(id),
+    min_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    max_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    notify_stock BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$
+DECLARE
+    moved BIGINT;
+    total BIGINT;
+BEGIN
+    INSERT INTO notification_preferences_reconciled
+        (user_id, product_id, variant_id, min_price, max_price, notify_stock, created_at, updated_at)
+    SELECT DISTINCT ON (p.user_id, p.product_id, p.variant_id)
+           p.user_id::integer, p.product_id::integer, NULLIF(p.variant_id, '')::integer,
+           COALESCE(p.min_price, 0), COALESCE(p.max_price, 0), COALESCE(p.notify_stock, FALSE),
+           p.created_at, p.updated_at
+    FROM notification_preferences p
+    WHERE p.deleted_at IS NULL
+      AND p.user_id ~ '^[0-9]+$'
+      AND p.product_id ~ '^[0-9]+$'
+      AND (p.variant_id = '' OR p.variant_id ~ '^[0-9]+$')
+      AND EXISTS (SELECT 1 FROM users u WHERE u.id = p.user_id::integer)
+      AND EXISTS (SELECT 1 FROM products pr WHERE pr.id = p.product_id::integer)
+      AND (p.variant_id = '' OR EXISTS (SELECT 1 FROM product_variants v WHERE v.id = p.variant_id::integer))
+    ORDER BY p.user_id, p.product_id, p.variant_id, p.updated_at DESC;
+    GET DIAGNOSTICS moved = ROW_COUNT;
+
+    SELECT count(*) INTO total FROM notification_preferences WHERE deleted_at IS NULL;
+
+    IF moved < total THEN
+        RAISE NOTICE 'notification_preferences: % of % rows could not be mapped, kept in legacy_notification_preferences', total - moved, total;
+        PERFORM pg_temp.retire_table('notification_preferences');
+    ELSE
+        DROP TABLE notification_preferences;
+    END IF;
+END $$;
+
+ALTER TABLE notification_preferences_reconciled RENAME TO notification_preferences;
+ALTER SEQUENCE notification_preferences_reconciled_id_seq RENAME TO notification_preferences_id_seq;
+ALTER INDEX notification_preferences_reconciled_pkey RENAME TO notification_preferences_pkey;
+CREATE INDEX idx_notification_preferences_user_id ON notification_preferences (user_id);
+CREATE INDEX idx_notification_preferences_product_id ON notification_preferences (product_id);
+-- NULL variant ids mean "any variant"; COALESCE keeps them unique too.
+CREATE UNIQUE INDEX idx_preference_user_product_variant
+    ON notification_preferences (user_id, product_id, COALESCE(variant_id, 0));
+
+-- price_history / stock_history ---------------------------------------------
+
+ALTER TABLE price_history ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE stock_history ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_price_history_variant_id ON price_history (variant_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_stock_history_variant_id ON stock_history (variant_id, changed_at);
+
+DO $$
+DECLARE
+    moved BIGINT;
+    total BIGINT;
+BEGIN
+    INSERT INTO price_history (variant_id, old_price, new_price, changed_at, created_at)
+    SELECT h.variant_id, h.old_price, h.new_price, h.changed_at, h.created_at
+    FROM price_histories h
+    WHERE EXISTS (SELECT 1 FROM product_variants v WHERE v.id = h.variant_id)
+    ORDER BY h.id;
+    GET DIAGNOSTICS moved = ROW_COUNT;
+
+    SELECT count(*) INTO total FROM price_histories;
+    IF moved < total THEN
+        RAISE NOTICE 'price_histories: % of % rows reference unknown variants, kept in legacy_price_histories', total - moved, total;
+        PERFORM pg_temp.retire_table('price_histories');
+    ELSE
+        DROP TABLE price_histories;
+    END IF;
+
+    INSERT INTO stock_history (variant_id, old_quantity, new_quantity, changed_at, created_at)
+    SELECT h.variant_id, h.old_quantity, h.new_quantity, h.changed_at, h.created_at
+    FROM stock_histories h
+    WHERE EXISTS (SELECT 1 FROM product_variants v WHERE v.id = h.variant_id)
+    ORDER BY h.id;
+    GET DIAGNOSTICS moved = ROW_COUNT;
+
+    SELECT count(*) INTO total FROM stock_histories;
+    IF moved < total THEN
+        RAISE NOTICE 'stock_histories: % of % rows reference unknown variants, kept in legacy_stock_histories', total - moved, total;
+        PERFORM pg_temp.retire_table('stock_histories');
+    ELSE
+        DROP TABLE stock_histories;
+    END IF;
+END $$;
+
+DROP FUNCTION pg_temp.retire_table(TEXT);
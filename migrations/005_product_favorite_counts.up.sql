@@ -0,0 +1,15 @@
+-- Aggregate number of users who have favorited each product. Maintained by
+-- the gateway in the same transaction as user_favorites so it never needs a
+-- COUNT(*) over the favorites table.
+CREATE TABLE IF NOT EXISTS product_favorite_counts (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    favorite_count INTEGER NOT NULL DEFAULT 0 CHECK (favorite_count >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO product_favorite_counts (product_id, favorite_count)
+SELECT product_id, COUNT(*)
+FROM user_favorites
+WHERE product_id IS NOT NULL
+GROUP BY product_id
+ON CONFLICT (product_id) DO NOTHING;
@@ -0,0 +1,21 @@
+-- Engagement counters as seen on each analysis run. product_analytics only
+-- holds the latest values; this keeps their history for windowed analytics.
+CREATE TABLE IF NOT EXISTS engagement_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    favorite_count INTEGER NOT NULL DEFAULT 0,
+    add_to_cart_count INTEGER NOT NULL DEFAULT 0,
+    order_count INTEGER NOT NULL DEFAULT 0,
+    captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_engagement_snapshots_product_captured ON engagement_snapshots (product_id, captured_at);
+
+-- Seed one snapshot per analysed product so existing counters have a
+-- starting point.
+INSERT INTO engagement_snapshots (product_id, view_count, favorite_count, add_to_cart_count, order_count, captured_at)
+SELECT pa.product_id, COALESCE(pa.view_count, 0), COALESCE(pa.favorite_count, 0),
+       COALESCE(pa.add_to_cart_count, 0), COALESCE(pa.order_count, 0),
+       COALESCE(pa.last_analyzed_at, CURRENT_TIMESTAMP)
+FROM product_analytics pa
+JOIN products p ON p.id = pa.product_id;
@@ -0,0 +1,3 @@
+-- Compacted rows stay behind as ordinary snapshots.
+DROP INDEX IF EXISTS idx_engagement_snapshots_bucket;
+ALTER TABLE engagement_snapshots DROP COLUMN IF EXISTS resolution;
@@ -0,0 +1,10 @@
+-- Engagement snapshots are kept raw for 7 days, then compacted to one row
+-- per hour, and after 90 days to one row per day. Compacted rows are stamped
+-- with the start of their bucket and hold the last counters seen in it.
+ALTER TABLE engagement_snapshots
+    ADD COLUMN resolution VARCHAR(8) NOT NULL DEFAULT 'raw'
+    CHECK (resolution IN ('raw', 'hourly', 'daily'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_engagement_snapshots_bucket
+    ON engagement_snapshots (product_id, resolution, captured_at)
+    WHERE resolution <> 'raw';
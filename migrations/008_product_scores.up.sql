@@ -0,0 +1,13 @@
+-- Popularity scores from each product-analysis scorer, with the product's
+-- rank within its category. Recomputed in batch; product_analytics keeps
+-- the configured primary score in popularity_score.
+CREATE TABLE IF NOT EXISTS product_scores (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    scorer VARCHAR(32) NOT NULL,
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    score DOUBLE PRECISION NOT NULL,
+    category_rank INTEGER NOT NULL,
+    computed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (product_id, scorer)
+);
+CREATE INDEX IF NOT EXISTS idx_product_scores_category_rank ON product_scores (scorer, category_id, category_rank);
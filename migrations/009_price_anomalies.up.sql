@@ -0,0 +1,19 @@
+-- Suspicious variant prices found by product-analysis. A flag stays open
+-- (resolved_at NULL) while the condition holds on each analysis and is
+-- resolved once it no longer does; there is at most one open flag per
+-- variant and kind.
+CREATE TABLE IF NOT EXISTS price_anomalies (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    detail TEXT NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    original_price DECIMAL(10,2),
+    detected_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_anomalies_open
+    ON price_anomalies (variant_id, kind)
+    WHERE resolved_at IS NULL;
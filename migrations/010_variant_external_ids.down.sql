@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_product_variants_external_id;
@@ -0,0 +1,28 @@
+-- product-analysis resolves the variants the crawler reports by
+-- (product, external variant ID), so that pair must identify one variant.
+-- Duplicates left by earlier crawls are merged into the oldest variant:
+-- their history and notifications move to it, and preferences it already
+-- has are dropped. Their anomalies are recomputed, so they cascade away.
+CREATE TEMP TABLE duplicate_variants ON COMMIT DROP AS
+SELECT id, keep_id FROM (
+    SELECT id, first_value(id) OVER (PARTITION BY product_id, external_variant_id ORDER BY id) AS keep_id
+    FROM product_variants
+    WHERE external_variant_id IS NOT NULL
+) v
+WHERE id <> keep_id;
+
+UPDATE price_history h SET variant_id = d.keep_id FROM duplicate_variants d WHERE h.variant_id = d.id;
+UPDATE stock_history h SET variant_id = d.keep_id FROM duplicate_variants d WHERE h.variant_id = d.id;
+UPDATE notifications n SET variant_id = d.keep_id FROM duplicate_variants d WHERE n.variant_id = d.id;
+DELETE FROM notification_preferences p USING duplicate_variants d, notification_preferences k
+WHERE p.variant_id = d.id AND k.variant_id = d.keep_id
+  AND k.user_id = p.user_id AND k.product_id = p.product_id;
+DELETE FROM notification_preferences p USING duplicate_variants d, notification_preferences o
+WHERE p.variant_id = d.id AND o.variant_id IN (SELECT id FROM duplicate_variants WHERE keep_id = d.keep_id)
+  AND o.user_id = p.user_id AND o.product_id = p.product_id AND o.id < p.id;
+UPDATE notification_preferences p SET variant_id = d.keep_id FROM duplicate_variants d WHERE p.variant_id = d.id;
+DELETE FROM product_variants v USING duplicate_variants d WHERE v.id = d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_variants_external_id
+    ON product_variants (product_id, external_variant_id)
+    WHERE external_variant_id IS NOT NULL;
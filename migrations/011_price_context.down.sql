@@ -0,0 +1,22 @@
+-- Flags from different sellers of a variant cannot all stay open
+UPDATE price_anomalies a SET resolved_at = now()
+WHERE resolved_at IS NULL AND EXISTS (
+    SELECT 1 FROM price_anomalies b
+    WHERE b.variant_id = a.variant_id AND b.kind = a.kind
+        AND b.resolved_at IS NULL AND b.id > a.id
+);
+DROP INDEX IF EXISTS idx_price_anomalies_open;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_anomalies_open
+    ON price_anomalies (variant_id, kind)
+    WHERE resolved_at IS NULL;
+ALTER TABLE price_anomalies DROP COLUMN IF EXISTS seller_id;
+
+DROP INDEX IF EXISTS idx_price_history_seller;
+ALTER TABLE price_history
+    DROP COLUMN IF EXISTS seller_id,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS list_price,
+    DROP COLUMN IF EXISTS basket_price,
+    DROP COLUMN IF EXISTS promotions;
+
+DROP INDEX IF EXISTS idx_sellers_external_id;
@@ -0,0 +1,27 @@
+-- Price observations record the context a price was seen in: the seller
+-- offering the variant, the currency, the list (strikethrough), sale and
+-- basket prices, and any promotion labels. new_price and old_price remain
+-- the sale price and the previous sale price from the same seller.
+-- Sellers are resolved by external ID, so duplicates are dropped first,
+-- keeping the oldest; nothing references sellers yet.
+DELETE FROM sellers a USING sellers b
+WHERE a.external_id = b.external_id AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sellers_external_id
+    ON sellers (external_id)
+    WHERE external_id IS NOT NULL;
+
+ALTER TABLE price_history
+    ADD COLUMN seller_id INTEGER REFERENCES sellers(id),
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    ADD COLUMN list_price DECIMAL(10,2),
+    ADD COLUMN basket_price DECIMAL(10,2),
+    ADD COLUMN promotions JSONB NOT NULL DEFAULT '[]';
+CREATE INDEX IF NOT EXISTS idx_price_history_seller
+    ON price_history (variant_id, seller_id, changed_at);
+
+-- Anomalies are judged against the history of the same seller
+ALTER TABLE price_anomalies ADD COLUMN seller_id INTEGER REFERENCES sellers(id);
+DROP INDEX IF EXISTS idx_price_anomalies_open;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_price_anomalies_open
+    ON price_anomalies (variant_id, COALESCE(seller_id, 0), kind)
+    WHERE resolved_at IS NULL;
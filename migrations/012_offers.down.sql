@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS buy_box_tenures;
+DROP TABLE IF EXISTS offers;
@@ -0,0 +1,37 @@
+-- Every seller's offer on a variant, as last crawled. The crawler keeps one
+-- row per variant and seller and deactivates offers that disappear.
+CREATE TABLE IF NOT EXISTS offers (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    seller_id INTEGER NOT NULL REFERENCES sellers(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL DEFAULT 'TRY',
+    price DECIMAL(10,2) NOT NULL,
+    list_price DECIMAL(10,2),
+    stock_quantity INTEGER NOT NULL DEFAULT 0,
+    shipping_cost DECIMAL(10,2) NOT NULL DEFAULT 0,
+    estimated_delivery VARCHAR(255),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_seen_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_offers_variant_seller ON offers (variant_id, seller_id);
+CREATE INDEX IF NOT EXISTS idx_offers_seller_id ON offers (seller_id);
+
+-- Which seller held the buy box (the cheapest in-stock offer, shipping
+-- included) on a variant, and when. lost_at is NULL for the current holder.
+CREATE TABLE IF NOT EXISTS buy_box_tenures (
+    id BIGSERIAL PRIMARY KEY,
+    variant_id INTEGER NOT NULL REFERENCES product_variants(id) ON DELETE CASCADE,
+    seller_id INTEGER NOT NULL REFERENCES sellers(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    price DECIMAL(10,2) NOT NULL,
+    shipping_cost DECIMAL(10,2) NOT NULL,
+    offer_count INTEGER NOT NULL,
+    won_at TIMESTAMP NOT NULL,
+    lost_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_buy_box_tenures_variant ON buy_box_tenures (variant_id, won_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_buy_box_tenures_current
+    ON buy_box_tenures (variant_id)
+    WHERE lost_at IS NULL;
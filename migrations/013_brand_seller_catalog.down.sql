@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS seller_ratings;
+
+DROP INDEX IF EXISTS idx_products_brand_id;
+DROP INDEX IF EXISTS idx_brands_external_id;
@@ -0,0 +1,21 @@
+-- Brands are upserted by external ID as products are crawled, like sellers.
+-- Duplicates are merged into the oldest brand first.
+UPDATE products p SET brand_id = k.id
+FROM brands d, brands k
+WHERE p.brand_id = d.id AND d.external_id = k.external_id AND k.id < d.id
+  AND NOT EXISTS (SELECT 1 FROM brands o WHERE o.external_id = d.external_id AND o.id < k.id);
+DELETE FROM brands a USING brands b
+WHERE a.external_id = b.external_id AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_brands_external_id
+    ON brands (external_id)
+    WHERE external_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_products_brand_id ON products (brand_id);
+
+-- A seller's rating each time it changed. sellers.rating is the latest.
+CREATE TABLE IF NOT EXISTS seller_ratings (
+    id BIGSERIAL PRIMARY KEY,
+    seller_id INTEGER NOT NULL REFERENCES sellers(id) ON DELETE CASCADE,
+    rating DECIMAL(3,2) NOT NULL,
+    recorded_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_seller_ratings_seller ON seller_ratings (seller_id, recorded_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reviews_product_date;
+DROP INDEX IF EXISTS idx_reviews_external_id;
@@ -0,0 +1,8 @@
+-- Reviews are crawled incrementally and stored once per external review ID.
+-- The newest review_date of a product is where its next crawl resumes.
+DELETE FROM reviews a USING reviews b
+WHERE a.product_id = b.product_id AND a.external_review_id = b.external_review_id AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_external_id
+    ON reviews (product_id, external_review_id)
+    WHERE external_review_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_product_date ON reviews (product_id, review_date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS review_aspects;
+DROP TABLE IF EXISTS review_sentiments;
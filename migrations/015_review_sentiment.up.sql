@@ -0,0 +1,22 @@
+-- Offline sentiment analysis of each review, written by product-analysis.
+-- product_id is copied from the review so aggregates need no join.
+CREATE TABLE IF NOT EXISTS review_sentiments (
+    review_id INTEGER PRIMARY KEY REFERENCES reviews(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    language CHAR(2) NOT NULL,
+    score REAL NOT NULL,
+    keywords JSONB NOT NULL DEFAULT '[]',
+    analysed_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_review_sentiments_product ON review_sentiments (product_id);
+
+-- The aspects (sizing, shipping, ...) a review talks about, with the score
+-- of the clauses mentioning each.
+CREATE TABLE IF NOT EXISTS review_aspects (
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    aspect VARCHAR(32) NOT NULL,
+    score REAL NOT NULL,
+    PRIMARY KEY (review_id, aspect)
+);
+CREATE INDEX IF NOT EXISTS idx_review_aspects_product ON review_aspects (product_id, aspect);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_product_attributes_product;
+
+DROP INDEX IF EXISTS idx_similar_products_unresolved;
+DROP INDEX IF EXISTS idx_similar_products_edge;
+DELETE FROM similar_products WHERE similar_product_id IS NULL;
+ALTER TABLE similar_products
+    ALTER COLUMN product_id DROP NOT NULL,
+    DROP COLUMN IF EXISTS similar_external_id;
@@ -0,0 +1,20 @@
+-- The crawler stores the similar-product edges a product page lists, by the
+-- target's external ID. similar_product_id is filled in once the target
+-- has been crawled.
+ALTER TABLE similar_products ADD COLUMN similar_external_id VARCHAR(255);
+UPDATE similar_products sp SET similar_external_id = p.external_id
+FROM products p WHERE p.id = sp.similar_product_id;
+DELETE FROM similar_products WHERE similar_external_id IS NULL OR product_id IS NULL;
+DELETE FROM similar_products a USING similar_products b
+WHERE a.product_id = b.product_id AND a.similar_external_id = b.similar_external_id AND a.id > b.id;
+ALTER TABLE similar_products
+    ALTER COLUMN product_id SET NOT NULL,
+    ALTER COLUMN similar_external_id SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_similar_products_edge
+    ON similar_products (product_id, similar_external_id);
+CREATE INDEX IF NOT EXISTS idx_similar_products_unresolved
+    ON similar_products (similar_external_id)
+    WHERE similar_product_id IS NULL;
+
+-- Attributes are replaced on every crawl of their product
+CREATE INDEX IF NOT EXISTS idx_product_attributes_product ON product_attributes (product_id);
@@ -0,0 +1,7 @@
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS canonical;
+
+DROP TABLE IF EXISTS canonical_memberships;
+DROP TABLE IF EXISTS canonical_products;
+
+ALTER TABLE product_images DROP COLUMN IF EXISTS image_hash;
+DROP INDEX IF EXISTS idx_product_images_url;
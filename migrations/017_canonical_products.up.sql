@@ -0,0 +1,31 @@
+-- Images are upserted by URL, and each carries a 64-bit difference hash
+-- so listings of the same item can be matched by their pictures.
+DELETE FROM product_images a USING product_images b
+WHERE a.product_id = b.product_id AND a.url = b.url AND a.id > b.id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_images_url ON product_images (product_id, url);
+ALTER TABLE product_images ADD COLUMN image_hash BIGINT;
+
+-- Canonical products group the listings (products under different
+-- external IDs, sellers or re-listings) of the same item. Memberships are
+-- computed by product-analysis; locked ones were set by hand and are
+-- never moved by the matcher.
+CREATE TABLE IF NOT EXISTS canonical_products (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(500) NOT NULL,
+    brand_id INTEGER REFERENCES brands(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS canonical_memberships (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    canonical_product_id BIGINT NOT NULL REFERENCES canonical_products(id) ON DELETE CASCADE,
+    locked BOOLEAN NOT NULL DEFAULT FALSE,
+    matched_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_canonical_memberships_canonical
+    ON canonical_memberships (canonical_product_id);
+
+-- A canonical preference alerts on every listing of the product's
+-- canonical product, not just the product itself.
+ALTER TABLE notification_preferences ADD COLUMN canonical BOOLEAN NOT NULL DEFAULT FALSE;
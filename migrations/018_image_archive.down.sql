@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS image_changes;
+
+DROP INDEX IF EXISTS idx_product_images_content_hash;
+ALTER TABLE product_images
+    DROP COLUMN IF EXISTS checked_at,
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS last_modified,
+    DROP COLUMN IF EXISTS etag,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,27 @@
+-- Images are downloaded into a content-addressed store. content_hash is
+-- the SHA-256 the archived copy is stored under; etag and last_modified
+-- let later crawls revalidate an image instead of downloading it again.
+ALTER TABLE product_images
+    ADD COLUMN content_hash CHAR(64),
+    ADD COLUMN content_type VARCHAR(100),
+    ADD COLUMN etag VARCHAR(200),
+    ADD COLUMN last_modified VARCHAR(100),
+    ADD COLUMN archived_at TIMESTAMP,
+    ADD COLUMN checked_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_product_images_content_hash ON product_images (content_hash);
+
+-- An image change is a product image URL that served different content
+-- than at the previous crawl. distance is the bit distance between the
+-- perceptual hashes, when both images could be decoded.
+CREATE TABLE IF NOT EXISTS image_changes (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    url VARCHAR(500) NOT NULL,
+    old_content_hash CHAR(64) NOT NULL,
+    new_content_hash CHAR(64) NOT NULL,
+    old_image_hash BIGINT,
+    new_image_hash BIGINT,
+    distance INTEGER,
+    detected_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_image_changes_product ON image_changes (product_id, detected_at);
@@ -0,0 +1,3 @@
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS notify_changes;
+
+DROP TABLE IF EXISTS product_changes;
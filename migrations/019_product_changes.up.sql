@@ -0,0 +1,17 @@
+-- A product change is one field of a crawled product that differs from the
+-- stored one. key names the attribute or image URL for attribute and image
+-- changes and is empty otherwise; old_value is NULL for something added
+-- and new_value NULL for something removed.
+CREATE TABLE IF NOT EXISTS product_changes (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    field VARCHAR(32) NOT NULL,
+    key VARCHAR(500) NOT NULL DEFAULT '',
+    old_value TEXT,
+    new_value TEXT,
+    changed_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_changes_product ON product_changes (product_id, changed_at);
+
+-- Users opt in to alerts about product detail changes.
+ALTER TABLE notification_preferences ADD COLUMN notify_changes BOOLEAN NOT NULL DEFAULT FALSE;
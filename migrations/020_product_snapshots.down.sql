@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_snapshots;
+DROP TABLE IF EXISTS snapshot_contents;
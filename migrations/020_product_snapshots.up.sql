@@ -0,0 +1,21 @@
+-- A product snapshot is what a product page showed from captured_at until
+-- last_seen_at: its gzipped raw HTML, when the page was fetched, and the
+-- gzipped ProductData parsed from it. Contents are stored once per SHA-256
+-- and shared by every snapshot that saw them; a crawl that sees the same
+-- content as the product's latest snapshot only extends it.
+CREATE TABLE IF NOT EXISTS snapshot_contents (
+    content_hash CHAR(64) PRIMARY KEY,
+    html BYTEA,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS product_snapshots (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    content_hash CHAR(64) NOT NULL REFERENCES snapshot_contents(content_hash),
+    captured_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_snapshots_product ON product_snapshots (product_id, captured_at);
+CREATE INDEX IF NOT EXISTS idx_product_snapshots_content ON product_snapshots (content_hash);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fetched_pages;
@@ -0,0 +1,15 @@
+-- Every page the scrapers fetch is archived, gzipped, under its content
+-- hash; a fetched page row records each fetch of a URL so pages can be
+-- replayed through the current parsers with `crawler-service reparse`.
+CREATE TABLE IF NOT EXISTS fetched_pages (
+    id SERIAL PRIMARY KEY,
+    url VARCHAR(2000) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    status_code INTEGER NOT NULL,
+    content_type VARCHAR(100),
+    content_hash CHAR(64) NOT NULL,
+    size INTEGER NOT NULL,
+    fetched_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_fetched_pages_kind ON fetched_pages (kind, fetched_at);
+CREATE INDEX IF NOT EXISTS idx_fetched_pages_url ON fetched_pages (url, fetched_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS crawl_run_errors;
+DROP TABLE IF EXISTS crawl_runs;
@@ -0,0 +1,26 @@
+-- A crawl run is one crawl of a category, with what it fetched and found.
+-- A run keeps the first errors it hit as samples; error_count counts all.
+CREATE TABLE IF NOT EXISTS crawl_runs (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    trigger VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    pages_fetched INTEGER NOT NULL DEFAULT 0,
+    bytes_transferred BIGINT NOT NULL DEFAULT 0,
+    products_found INTEGER NOT NULL DEFAULT 0,
+    products_new INTEGER NOT NULL DEFAULT 0,
+    products_updated INTEGER NOT NULL DEFAULT 0,
+    products_deactivated INTEGER NOT NULL DEFAULT 0,
+    error_count INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_crawl_runs_category ON crawl_runs (category_id, started_at);
+
+CREATE TABLE IF NOT EXISTS crawl_run_errors (
+    id SERIAL PRIMARY KEY,
+    crawl_run_id INTEGER NOT NULL REFERENCES crawl_runs(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    occurred_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_crawl_run_errors_run ON crawl_run_errors (crawl_run_id);
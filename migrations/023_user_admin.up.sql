@@ -0,0 +1,4 @@
+-- Administrators may trigger crawls and curate canonical products. Nobody
+-- is an administrator by default; grant it with
+-- UPDATE users SET is_admin = TRUE WHERE email = '...'.
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE crawl_runs DROP COLUMN images_fetched;
@@ -0,0 +1,2 @@
+-- Images a crawl run downloads are counted apart from the pages it fetches
+ALTER TABLE crawl_runs ADD COLUMN images_fetched INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+// Run applies all pending migrations. Services call it on startup.
+func Run(ctx context.Context, db *sql.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Database migrations completed (%d applied)", applied)
+	return nil
+}
+
+// RunCommand implements the `migrate` subcommand shared by every service:
+//
+//	migrate up         apply all pending migrations
+//	migrate down [n]   roll back the last n migrations (default 1)
+//	migrate status     list migrations and when they were applied
+func RunCommand(ctx context.Context, db *sql.DB, args []string) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Applied %d migration(s)", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid step count %q: %v", args[1], err)
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Printf("Rolled back %d migration(s)", rolledBack)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%03d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate action %q (want up, down or status)", action)
+	}
+
+	return nil
+}
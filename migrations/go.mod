@@ -0,0 +1,10 @@
+module github.com/faisaloncode/ecommerce-crawler/migrations
+
+go 1.23.0
+
+require gorm.io/gorm v1.25.10
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+)
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// compatibleColumnTypes maps a GORM field data type to the Postgres column
+// types (information_schema udt_name) it can be stored in.
+var compatibleColumnTypes = map[schema.DataType][]string{
+	schema.Bool:   {"bool"},
+	schema.Int:    {"int2", "int4", "int8"},
+	schema.Uint:   {"int2", "int4", "int8"},
+	schema.Float:  {"numeric", "float4", "float8"},
+	schema.String: {"varchar", "text", "bpchar"},
+	schema.Time:   {"timestamp", "timestamptz", "date"},
+	schema.Bytes:  {"bytea"},
+}
+
+// VerifyModels checks that the table behind every model exists and has a
+// column of a compatible type for each mapped field. Services call it after
+// migrating so a model that drifts from the SQL schema fails at startup
+// instead of on the first query.
+func VerifyModels(db *gorm.DB, models ...interface{}) error {
+	var problems []string
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !db.Migrator().HasTable(table) {
+			problems = append(problems, fmt.Sprintf("table %s (%T) does not exist", table, model))
+			continue
+		}
+
+		columnTypes, err := db.Migrator().ColumnTypes(table)
+		if err != nil {
+			return fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		columns := make(map[string]string, len(columnTypes))
+		for _, column := range columnTypes {
+			columns[column.Name()] = strings.ToLower(column.DatabaseTypeName())
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			columnType, ok := columns[field.DBName]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("column %s.%s (%T.%s) does not exist", table, field.DBName, model, field.Name))
+				continue
+			}
+
+			allowed, known := compatibleColumnTypes[field.DataType]
+			if !known {
+				continue
+			}
+			if !containsString(allowed, columnType) {
+				problems = append(problems, fmt.Sprintf("column %s.%s is %s but %T.%s is %s",
+					table, field.DBName, columnType, model, field.Name, field.DataType))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema does not match models:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
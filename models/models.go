@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Username     string    `json:"username" gorm:"size:255;not null"`
+	Email        string    `json:"email" gorm:"size:255;uniqueIndex;not null"`
+	PasswordHash *string   `json:"-" gorm:"size:255"`
+	IsAdmin      bool      `json:"is_admin" gorm:"not null;default:false"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type UserFavorite struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"index"`
+	ProductID uint      `json:"product_id" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ProductFavoriteCount is the number of users who have favorited a product.
+// It is kept in step with user_favorites by the favorites service.
+type ProductFavoriteCount struct {
+	ProductID     uint      `json:"product_id" gorm:"primaryKey;autoIncrement:false"`
+	FavoriteCount int       `json:"favorite_count" gorm:"not null;default:0"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// RefreshToken records an issued refresh token by its JWT id so it can be
+// rotated and revoked.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"index;not null"`
+	TokenID   string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// APIKey authenticates machine clients. Only a SHA-256 hash of the key is
+// stored; Prefix is kept in clear text so users can tell their keys apart.
+type APIKey struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	UserID     uint       `json:"user_id" gorm:"index;not null"`
+	Name       string     `json:"name" gorm:"size:255;not null"`
+	Prefix     string     `json:"prefix" gorm:"size:16;not null"`
+	KeyHash    string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
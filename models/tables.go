@@ -0,0 +1,13 @@
+package models
+
+// Tables lists the models whose tables the gateway owns. Their schema is
+// defined in migrations/.
+func Tables() []interface{} {
+	return []interface{}{
+		&User{},
+		&UserFavorite{},
+		&ProductFavoriteCount{},
+		&RefreshToken{},
+		&APIKey{},
+	}
+}
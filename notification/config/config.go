@@ -10,6 +10,8 @@ type Config struct {
 	DBName      string
 	ServerPort  string
 	KafkaBroker string
+	CrawlerServiceAddr         string
+	ProductAnalysisServiceAddr string
 }
 
 func LoadConfig() (*Config, error) {
@@ -21,6 +23,8 @@ func LoadConfig() (*Config, error) {
 		DBName:      getEnv("DB_NAME", "ecommerce"),
 		ServerPort:  getEnv("SERVER_PORT", "8082"),
 		KafkaBroker: getEnv("KAFKA_BROKERS", "localhost:9092"),
+		CrawlerServiceAddr:         getEnv("CRAWLER_SERVICE_ADDR", "localhost:50051"),
+		ProductAnalysisServiceAddr: getEnv("PRODUCT_ANALYSIS_SERVICE_ADDR", "localhost:50052"),
 	}, nil
 }
 
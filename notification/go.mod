@@ -1,18 +1,24 @@
 module github.com/faisaloncode/ecommerce-crawler/notification
 
-go 1.21
+go 1.23.8
 
 require (
+	github.com/faisaloncode/ecommerce-crawler/crawler v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/identity v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/migrations v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/product-analysis v0.0.0-00010101000000-000000000000
 	github.com/labstack/echo/v4 v4.11.4
 	github.com/segmentio/kafka-go v0.4.47
-	gorm.io/driver/postgres v1.5.6
-	gorm.io/gorm v1.25.7
+	google.golang.org/grpc v1.72.0
+	gorm.io/driver/postgres v1.5.11
+	gorm.io/gorm v1.26.0
 )
 
 require (
 	github.com/jackc/pgpassfile v1.0.0 // indirect
-	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
-	github.com/jackc/pgx/v5 v5.4.3 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/pgx/v5 v5.7.4 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/klauspost/compress v1.15.9 // indirect
@@ -22,8 +28,18 @@ require (
 	github.com/pierrec/lz4/v4 v4.1.15 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasttemplate v1.2.2 // indirect
-	golang.org/x/crypto v0.17.0 // indirect
-	golang.org/x/net v0.19.0 // indirect
-	golang.org/x/sys v0.15.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sync v0.13.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250425173222-7b384671a197 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
+
+replace (
+	github.com/faisaloncode/ecommerce-crawler/crawler => ../crawler
+	github.com/faisaloncode/ecommerce-crawler/identity => ../identity
+	github.com/faisaloncode/ecommerce-crawler/migrations => ../migrations
+	github.com/faisaloncode/ecommerce-crawler/product-analysis => ../product-analysis
 )
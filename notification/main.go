@@ -1,14 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 
+	crawlerpb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	"github.com/faisaloncode/ecommerce-crawler/identity"
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
 	"github.com/faisaloncode/ecommerce-crawler/notification/config"
 	"github.com/faisaloncode/ecommerce-crawler/notification/models"
 	"github.com/faisaloncode/ecommerce-crawler/notification/service"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -26,23 +36,50 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
-	// Drop tables if they exist
-	db.Migrator().DropTable(&models.Notification{})
-	db.Migrator().DropTable(&models.NotificationPreference{})
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database handle: %v", err)
+	}
+
+	// `notification-service migrate [up|down [n]|status]` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.RunCommand(context.Background(), sqlDB, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
 
 	// Run migrations
-	err = db.AutoMigrate(
-		&models.Notification{},
-		&models.NotificationPreference{},
-	)
-	if err != nil {
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	if err := migrations.VerifyModels(db, models.Tables()...); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
 
 	// Initialize notification service
 	notificationService := service.NewNotificationService(db, cfg.KafkaBroker)
 	notificationService.Start()
 
+	// Initialize gRPC clients used to validate and prioritise preferences
+	crawlerConn, err := initGRPCClient(cfg.CrawlerServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to create crawler service client: %v", err)
+	}
+	defer crawlerConn.Close()
+
+	analysisConn, err := initGRPCClient(cfg.ProductAnalysisServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to create product analysis service client: %v", err)
+	}
+	defer analysisConn.Close()
+
+	preferenceService := service.NewPreferenceService(
+		db,
+		crawlerpb.NewCrawlerServiceClient(crawlerConn),
+		analysispb.NewProductAnalysisServiceClient(analysisConn),
+	)
+
 	// Initialize Echo server
 	e := echo.New()
 
@@ -51,11 +88,17 @@ func main() {
 		return c.JSON(http.StatusOK, map[string]string{"status": "healthy"})
 	})
 
+	// Every route below acts on behalf of the user the gateway authenticated
+	users := e.Group("", requireUser)
+
 	// Get notifications endpoint
-	e.GET("/notifications/:user_id", func(c echo.Context) error {
+	users.GET("/notifications/:user_id", func(c echo.Context) error {
 		var notifications []models.Notification
-		userID := c.Param("user_id")
-		
+		userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user id"})
+		}
+
 		result := db.Where("user_id = ?", userID).Order("created_at desc").Find(&notifications)
 		if result.Error != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": result.Error.Error()})
@@ -65,30 +108,102 @@ func main() {
 	})
 
 	// Mark notification as read endpoint
-	e.PUT("/notifications/:id/read", func(c echo.Context) error {
+	users.PUT("/notifications/:id/read", func(c echo.Context) error {
 		id := c.Param("id")
-		
-		result := db.Model(&models.Notification{}).Where("id = ?", id).Update("is_read", true)
+		userID, _ := identity.FromContext(c.Request().Context())
+
+		result := db.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userID).Update("is_read", true)
 		if result.Error != nil {
 			return c.JSON(http.StatusInternalServerError, map[string]string{"error": result.Error.Error()})
 		}
+		if result.RowsAffected == 0 {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "notification not found"})
+		}
 
 		return c.JSON(http.StatusOK, map[string]string{"status": "success"})
 	})
 
-	// Set notification preferences endpoint
-	e.POST("/preferences", func(c echo.Context) error {
-		pref := new(models.NotificationPreference)
-		if err := c.Bind(pref); err != nil {
+	// Create notification preference endpoint
+	createPreference := func(c echo.Context) error {
+		var input service.NewPreference
+		if err := c.Bind(&input); err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 		}
+		// requireUser has already checked any :user_id against the caller
+		userID, _ := identity.FromContext(c.Request().Context())
 
-		result := db.Create(pref)
-		if result.Error != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": result.Error.Error()})
+		pref, err := preferenceService.CreatePreference(c.Request().Context(), userID, input)
+		if err != nil {
+			return preferenceError(c, err)
 		}
 
 		return c.JSON(http.StatusCreated, pref)
+	}
+	users.POST("/preferences", createPreference)
+	users.POST("/users/:user_id/preferences", createPreference)
+
+	// List notification preferences endpoint
+	users.GET("/users/:user_id/preferences", func(c echo.Context) error {
+		userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user id"})
+		}
+
+		prefs, err := preferenceService.ListPreferences(c.Request().Context(), uint(userID))
+		if err != nil {
+			return preferenceError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, prefs)
+	})
+
+	// Get notification preference endpoint
+	users.GET("/users/:user_id/preferences/:id", func(c echo.Context) error {
+		userID, id, err := preferencePath(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		pref, err := preferenceService.GetPreference(c.Request().Context(), userID, id)
+		if err != nil {
+			return preferenceError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, pref)
+	})
+
+	// Update notification preference endpoint
+	users.PATCH("/users/:user_id/preferences/:id", func(c echo.Context) error {
+		userID, id, err := preferencePath(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		var update service.PreferenceUpdate
+		if err := c.Bind(&update); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		pref, err := preferenceService.UpdatePreference(c.Request().Context(), userID, id, update)
+		if err != nil {
+			return preferenceError(c, err)
+		}
+
+		return c.JSON(http.StatusOK, pref)
+	})
+
+	// Delete notification preference endpoint
+	users.DELETE("/users/:user_id/preferences/:id", func(c echo.Context) error {
+		userID, id, err := preferencePath(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		if err := preferenceService.DeletePreference(c.Request().Context(), userID, id); err != nil {
+			return preferenceError(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
 	})
 
 	// Start server
@@ -102,5 +217,65 @@ func initDB(cfg *config.Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPass, cfg.DBName)
 
-	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+}
+
+func initGRPCClient(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor()),
+	)
+}
+
+// requireUser reads the caller's user id from the X-User-ID header set by the
+// gateway and rejects requests for another user's :user_id. The id is put on
+// the request context so it is forwarded on outgoing gRPC calls.
+func requireUser(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, ok := identity.Parse(c.Request().Header.Get(identity.Header))
+		if !ok {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+		}
+		if param := c.Param("user_id"); param != "" {
+			pathUserID, ok := identity.Parse(param)
+			if !ok {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user id"})
+			}
+			if pathUserID != userID {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "resource belongs to another user"})
+			}
+		}
+
+		c.SetRequest(c.Request().WithContext(identity.NewContext(c.Request().Context(), userID)))
+		return next(c)
+	}
+}
+
+// preferencePath parses the user and preference ids from
+// /users/:user_id/preferences/:id.
+func preferencePath(c echo.Context) (uint, uint, error) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid user id")
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid preference id")
+	}
+	return uint(userID), uint(id), nil
+}
+
+// preferenceError maps preference service errors to HTTP responses.
+func preferenceError(c echo.Context, err error) error {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrPreferenceNotFound), errors.Is(err, service.ErrProductNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, service.ErrDuplicatePreference):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
 }
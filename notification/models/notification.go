@@ -2,8 +2,6 @@ package models
 
 import (
 	"time"
-
-	"gorm.io/gorm"
 )
 
 type NotificationType string
@@ -11,23 +9,37 @@ type NotificationType string
 const (
 	PriceDropNotification NotificationType = "PRICE_DROP"
 	StockChangeNotification NotificationType = "STOCK_CHANGE"
+	ProductChangeNotification NotificationType = "PRODUCT_CHANGE"
 )
 
 type Notification struct {
-	gorm.Model
-	UserID      string          `json:"user_id" gorm:"column:user_id;type:varchar(100);index"`
-	ProductID   string          `json:"product_id" gorm:"column:product_id;type:varchar(100);index"`
-	Type        NotificationType `json:"type" gorm:"column:type;type:varchar(50)"`
-	Message     string          `json:"message" gorm:"column:message;type:text"`
-	IsRead      bool            `json:"is_read" gorm:"column:is_read;default:false"`
-	CreatedAt   time.Time       `json:"created_at" gorm:"column:created_at"`
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	UserID      uint             `json:"user_id" gorm:"column:user_id;index"`
+	ProductID   uint             `json:"product_id" gorm:"column:product_id;index"`
+	VariantID   *uint            `json:"variant_id,omitempty" gorm:"column:variant_id"`
+	Type        NotificationType `json:"type" gorm:"column:notification_type;type:varchar(50)"`
+	Message     string           `json:"message" gorm:"column:message;type:text"`
+	IsRead      bool             `json:"is_read" gorm:"column:is_read;default:false"`
+	CreatedAt   time.Time        `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" gorm:"column:updated_at"`
 }
 
+// NotificationPreference is unique per user, product and variant. A nil
+// VariantID means the preference applies to every variant of the product.
 type NotificationPreference struct {
-	gorm.Model
-	UserID      string    `json:"user_id" gorm:"column:user_id;type:varchar(100);index"`
-	ProductID   string    `json:"product_id" gorm:"column:product_id;type:varchar(100);index"`
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UserID      uint      `json:"user_id" gorm:"column:user_id;index"`
+	ProductID   uint      `json:"product_id" gorm:"column:product_id;index"`
+	VariantID   *uint     `json:"variant_id,omitempty" gorm:"column:variant_id"`
 	MinPrice    float64   `json:"min_price" gorm:"column:min_price;type:decimal(10,2)"`
 	MaxPrice    float64   `json:"max_price" gorm:"column:max_price;type:decimal(10,2)"`
 	NotifyStock bool      `json:"notify_stock" gorm:"column:notify_stock"`
+	// Canonical extends the preference to every listing of the product's
+	// canonical product.
+	Canonical bool      `json:"canonical" gorm:"column:canonical"`
+	// NotifyChanges alerts on changes to the product's name, description,
+	// attributes, images, size recommendation or delivery estimate.
+	NotifyChanges bool  `json:"notify_changes" gorm:"column:notify_changes"`
+	CreatedAt   time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at"`
 }
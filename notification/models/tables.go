@@ -0,0 +1,10 @@
+package models
+
+// Tables lists the models whose tables the notification service owns.
+// Their schema is defined in migrations/.
+func Tables() []interface{} {
+	return []interface{}{
+		&Notification{},
+		&NotificationPreference{},
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	crawlerpb "github.com/faisaloncode/ecommerce-crawler/crawler/proto"
+	"github.com/faisaloncode/ecommerce-crawler/notification/models"
+	analysispb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+var (
+	ErrPreferenceNotFound  = errors.New("preference not found")
+	ErrDuplicatePreference = errors.New("a preference for this product and variant already exists")
+	ErrProductNotFound     = errors.New("product not found")
+)
+
+// ValidationError reports a preference field that failed validation.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// NewPreference holds the fields a client sets when creating a
+// preference. The owner is always the caller.
+type NewPreference struct {
+	ProductID     uint    `json:"product_id"`
+	VariantID     *uint   `json:"variant_id"`
+	MinPrice      float64 `json:"min_price"`
+	MaxPrice      float64 `json:"max_price"`
+	NotifyStock   bool    `json:"notify_stock"`
+	Canonical     bool    `json:"canonical"`
+	NotifyChanges bool    `json:"notify_changes"`
+}
+
+// PreferenceUpdate holds the fields a client may change on an existing
+// preference. Nil fields are left untouched.
+type PreferenceUpdate struct {
+	MinPrice      *float64 `json:"min_price"`
+	MaxPrice      *float64 `json:"max_price"`
+	NotifyStock   *bool    `json:"notify_stock"`
+	Canonical     *bool    `json:"canonical"`
+	NotifyChanges *bool    `json:"notify_changes"`
+}
+
+type PreferenceService struct {
+	db             *gorm.DB
+	crawlerClient  crawlerpb.CrawlerServiceClient
+	analysisClient analysispb.ProductAnalysisServiceClient
+}
+
+func NewPreferenceService(db *gorm.DB, crawlerClient crawlerpb.CrawlerServiceClient, analysisClient analysispb.ProductAnalysisServiceClient) *PreferenceService {
+	return &PreferenceService{
+		db:             db,
+		crawlerClient:  crawlerClient,
+		analysisClient: analysisClient,
+	}
+}
+
+// ListPreferences returns every preference owned by the user.
+func (s *PreferenceService) ListPreferences(ctx context.Context, userID uint) ([]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	result := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&prefs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list preferences: %w", result.Error)
+	}
+	return prefs, nil
+}
+
+// GetPreference returns a single preference, scoped to its owner.
+func (s *PreferenceService) GetPreference(ctx context.Context, userID, id uint) (*models.NotificationPreference, error) {
+	var pref models.NotificationPreference
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&pref)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrPreferenceNotFound
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get preference: %w", result.Error)
+	}
+	return &pref, nil
+}
+
+// CreatePreference validates and stores a new preference of the user, then
+// marks the product as favorited in product-analysis.
+func (s *PreferenceService) CreatePreference(ctx context.Context, userID uint, input NewPreference) (*models.NotificationPreference, error) {
+	if userID == 0 {
+		return nil, &ValidationError{Field: "user_id", Message: "is required"}
+	}
+	if input.ProductID == 0 {
+		return nil, &ValidationError{Field: "product_id", Message: "is required"}
+	}
+	if err := validatePriceBounds(input.MinPrice, input.MaxPrice); err != nil {
+		return nil, err
+	}
+	if err := s.ensureProductExists(ctx, input.ProductID); err != nil {
+		return nil, err
+	}
+
+	pref := &models.NotificationPreference{
+		UserID:        userID,
+		ProductID:     input.ProductID,
+		VariantID:     input.VariantID,
+		MinPrice:      input.MinPrice,
+		MaxPrice:      input.MaxPrice,
+		NotifyStock:   input.NotifyStock,
+		Canonical:     input.Canonical,
+		NotifyChanges: input.NotifyChanges,
+	}
+	// The unique index on user, product and variant rejects duplicates,
+	// a missing variant included
+	result := s.db.WithContext(ctx).Create(pref)
+	if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+		return nil, ErrDuplicatePreference
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to create preference: %w", result.Error)
+	}
+
+	s.syncProductPriority(ctx, pref.ProductID)
+	return pref, nil
+}
+
+// UpdatePreference applies a partial update to a preference.
+func (s *PreferenceService) UpdatePreference(ctx context.Context, userID, id uint, update PreferenceUpdate) (*models.NotificationPreference, error) {
+	pref, err := s.GetPreference(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.MinPrice != nil {
+		pref.MinPrice = *update.MinPrice
+	}
+	if update.MaxPrice != nil {
+		pref.MaxPrice = *update.MaxPrice
+	}
+	if update.NotifyStock != nil {
+		pref.NotifyStock = *update.NotifyStock
+	}
+	if update.Canonical != nil {
+		pref.Canonical = *update.Canonical
+	}
+	if update.NotifyChanges != nil {
+		pref.NotifyChanges = *update.NotifyChanges
+	}
+	if err := validatePriceBounds(pref.MinPrice, pref.MaxPrice); err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(ctx).Model(pref).Select("min_price", "max_price", "notify_stock", "canonical", "notify_changes").Updates(pref)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update preference: %w", result.Error)
+	}
+	return pref, nil
+}
+
+// DeletePreference removes a preference. The product drops back to normal
+// priority once no preference references it any more.
+func (s *PreferenceService) DeletePreference(ctx context.Context, userID, id uint) error {
+	pref, err := s.GetPreference(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.WithContext(ctx).Delete(pref)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete preference: %w", result.Error)
+	}
+
+	s.syncProductPriority(ctx, pref.ProductID)
+	return nil
+}
+
+func (s *PreferenceService) ensureProductExists(ctx context.Context, productID uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := s.crawlerClient.GetProduct(ctx, &crawlerpb.GetProductRequest{Id: fmt.Sprint(productID)})
+	if status.Code(err) == codes.NotFound {
+		return ErrProductNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up product %d: %w", productID, err)
+	}
+	return nil
+}
+
+// syncProductPriority tells product-analysis that the interest in a product
+// changed; it derives the priority from the committed preferences and
+// favorites itself. Failures are logged only; the preference change itself
+// has already been committed.
+func (s *PreferenceService) syncProductPriority(ctx context.Context, productID uint) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := s.analysisClient.UpdateProductPriority(ctx, &analysispb.UpdateProductPriorityRequest{
+		ProductId: fmt.Sprint(productID),
+	})
+	if err != nil {
+		log.Printf("Failed to update priority for product %d: %v", productID, err)
+	}
+}
+
+func validatePriceBounds(minPrice, maxPrice float64) error {
+	if minPrice < 0 {
+		return &ValidationError{Field: "min_price", Message: "must not be negative"}
+	}
+	if maxPrice < 0 {
+		return &ValidationError{Field: "max_price", Message: "must not be negative"}
+	}
+	if maxPrice > 0 && minPrice > maxPrice {
+		return &ValidationError{Field: "min_price", Message: "must not be greater than max_price"}
+	}
+	return nil
+}
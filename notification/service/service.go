@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/faisaloncode/ecommerce-crawler/notification/models"
 	"github.com/segmentio/kafka-go"
@@ -26,6 +28,13 @@ type StockChangeEvent struct {
 	InStock   bool   `json:"in_stock"`
 }
 
+// ProductChangeEvent names the fields of a product's details that changed
+// at a crawl.
+type ProductChangeEvent struct {
+	ProductID string   `json:"product_id"`
+	Fields    []string `json:"fields"`
+}
+
 func NewNotificationService(db *gorm.DB, kafkaBroker string) *NotificationService {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers: []string{kafkaBroker},
@@ -68,6 +77,8 @@ func (s *NotificationService) consumeKafkaMessages() {
 			s.handlePriceChange(event)
 		case "stock_change":
 			s.handleStockChange(event)
+		case "product_change":
+			s.handleProductChange(event)
 		}
 	}
 }
@@ -81,13 +92,13 @@ func (s *NotificationService) handlePriceChange(event map[string]interface{}) {
 	}
 
 	var prefs []models.NotificationPreference
-	s.db.Where("product_id = ? AND min_price >= ? AND max_price <= ?",
-		priceChange.ProductID, priceChange.NewPrice, priceChange.OldPrice).Find(&prefs)
+	s.preferencesFor(priceChange.ProductID).
+		Where("min_price >= ? AND max_price <= ?", priceChange.NewPrice, priceChange.OldPrice).Find(&prefs)
 
 	for _, pref := range prefs {
 		notification := models.Notification{
 			UserID:    pref.UserID,
-			ProductID: pref.ProductID,
+			ProductID: notifiedProductID(pref, priceChange.ProductID),
 			Type:      models.PriceDropNotification,
 			Message:   "Price dropped from " + formatPrice(priceChange.OldPrice) + " to " + formatPrice(priceChange.NewPrice),
 		}
@@ -103,7 +114,7 @@ func (s *NotificationService) handleStockChange(event map[string]interface{}) {
 	}
 
 	var prefs []models.NotificationPreference
-	s.db.Where("product_id = ? AND notify_stock = ?", stockChange.ProductID, true).Find(&prefs)
+	s.preferencesFor(stockChange.ProductID).Where("notify_stock = ?", true).Find(&prefs)
 
 	for _, pref := range prefs {
 		status := "back in stock"
@@ -113,7 +124,7 @@ func (s *NotificationService) handleStockChange(event map[string]interface{}) {
 
 		notification := models.Notification{
 			UserID:    pref.UserID,
-			ProductID: pref.ProductID,
+			ProductID: notifiedProductID(pref, stockChange.ProductID),
 			Type:      models.StockChangeNotification,
 			Message:   "Product is now " + status,
 		}
@@ -121,6 +132,50 @@ func (s *NotificationService) handleStockChange(event map[string]interface{}) {
 	}
 }
 
+func (s *NotificationService) handleProductChange(event map[string]interface{}) {
+	data := event["data"].(map[string]interface{})
+	productChange := ProductChangeEvent{
+		ProductID: data["product_id"].(string),
+	}
+	fields, _ := data["fields"].([]interface{})
+	for _, field := range fields {
+		if name, ok := field.(string); ok {
+			productChange.Fields = append(productChange.Fields, strings.ReplaceAll(name, "_", " "))
+		}
+	}
+
+	var prefs []models.NotificationPreference
+	s.preferencesFor(productChange.ProductID).Where("notify_changes = ?", true).Find(&prefs)
+
+	for _, pref := range prefs {
+		notification := models.Notification{
+			UserID:    pref.UserID,
+			ProductID: notifiedProductID(pref, productChange.ProductID),
+			Type:      models.ProductChangeNotification,
+			Message:   "Product details changed: " + strings.Join(productChange.Fields, ", "),
+		}
+		s.db.Create(&notification)
+	}
+}
+
+// preferencesFor selects the preferences on a product, and the canonical
+// preferences on any other listing of its canonical product.
+func (s *NotificationService) preferencesFor(productID string) *gorm.DB {
+	return s.db.Where(`product_id = ? OR (canonical AND product_id IN (
+SELECT o.product_id FROM canonical_memberships o
+JOIN canonical_memberships m ON m.canonical_product_id = o.canonical_product_id
+WHERE m.product_id = ?))`, productID, productID)
+}
+
+// notifiedProductID is the product a notification is about: the listing
+// that changed, even when the preference is on another listing of it.
+func notifiedProductID(pref models.NotificationPreference, productID string) uint {
+	if id, err := strconv.ParseUint(productID, 10, 64); err == nil {
+		return uint(id)
+	}
+	return pref.ProductID
+}
+
 func formatPrice(price float64) string {
 	return "₺" + formatFloat(price)
 }
@@ -0,0 +1,236 @@
+// Package anomaly flags suspicious variant prices: advertised original
+// prices that were never charged, discounts that only undo a recent price
+// hike, and prices far outside the variant's own history.
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type Kind string
+
+const (
+	// NeverChargedOriginal flags an advertised original price that does not
+	// appear anywhere in the recorded price history.
+	NeverChargedOriginal Kind = "never_charged_original"
+	// DiscountAfterHike flags a price drop that merely returns the price to
+	// where it was before a recent increase.
+	DiscountAfterHike Kind = "discount_after_hike"
+	// PriceOutlier flags a current price that is a statistical outlier
+	// against the variant's own history.
+	PriceOutlier Kind = "price_outlier"
+)
+
+// Kinds lists every kind Detect can report.
+var Kinds = []Kind{NeverChargedOriginal, DiscountAfterHike, PriceOutlier}
+
+// PricePoint is a price that took effect at At.
+type PricePoint struct {
+	Price float64
+	At    time.Time
+}
+
+// Input is what Detect looks at for one variant.
+type Input struct {
+	CurrentPrice  float64
+	OriginalPrice float64
+	// History holds every recorded price in effect, oldest first, ending
+	// with the current price.
+	History []PricePoint
+	Now     time.Time
+}
+
+// Flag is one detected anomaly.
+type Flag struct {
+	Kind Kind
+	// Score is the size of the anomaly: the claimed discount or hike in
+	// percent, or the outlier's z-score.
+	Score  float64
+	Detail string
+}
+
+type Config struct {
+	// Lookback bounds the history considered.
+	Lookback time.Duration
+	// MinHistory is how long a variant must have been observed before an
+	// original price can be called never charged.
+	MinHistory time.Duration
+	// HikeWindow is how soon a discount must follow a hike to be flagged.
+	HikeWindow time.Duration
+	// Tolerance is the relative difference under which two prices are
+	// treated as equal.
+	Tolerance float64
+	// OutlierThreshold is the modified z-score above which a price is an
+	// outlier.
+	OutlierThreshold float64
+	// MinOutlierPoints is the fewest earlier prices an outlier is judged
+	// against.
+	MinOutlierPoints int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Lookback:         90 * 24 * time.Hour,
+		MinHistory:       14 * 24 * time.Hour,
+		HikeWindow:       30 * 24 * time.Hour,
+		Tolerance:        0.02,
+		OutlierThreshold: 3.5,
+		MinOutlierPoints: 5,
+	}
+}
+
+// Detect returns the anomalies found for one variant.
+func Detect(in Input, cfg Config) []Flag {
+	history := make([]PricePoint, 0, len(in.History))
+	for _, p := range in.History {
+		if !p.At.Before(in.Now.Add(-cfg.Lookback)) {
+			history = append(history, p)
+		}
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	var flags []Flag
+	for _, detect := range []func([]PricePoint, Input, Config) *Flag{
+		detectNeverChargedOriginal,
+		detectDiscountAfterHike,
+		detectOutlier,
+	} {
+		if flag := detect(history, in, cfg); flag != nil {
+			flags = append(flags, *flag)
+		}
+	}
+	return flags
+}
+
+func detectNeverChargedOriginal(history []PricePoint, in Input, cfg Config) *Flag {
+	if in.OriginalPrice <= 0 || !greater(in.OriginalPrice, in.CurrentPrice, cfg.Tolerance) {
+		return nil
+	}
+	if in.Now.Sub(in.History[0].At) < cfg.MinHistory {
+		return nil
+	}
+	for _, p := range history {
+		if !greater(in.OriginalPrice, p.Price, cfg.Tolerance) {
+			return nil
+		}
+	}
+
+	highest := 0.0
+	for _, p := range history {
+		highest = math.Max(highest, p.Price)
+	}
+	return &Flag{
+		Kind:  NeverChargedOriginal,
+		Score: (in.OriginalPrice - in.CurrentPrice) / in.OriginalPrice * 100,
+		Detail: fmt.Sprintf("original price %.2f was never charged; highest recorded price is %.2f",
+			in.OriginalPrice, highest),
+	}
+}
+
+func detectDiscountAfterHike(history []PricePoint, in Input, cfg Config) *Flag {
+	// The current price must be the result of a drop
+	last := len(history) - 1
+	if last < 2 || !greater(history[last-1].Price, history[last].Price, cfg.Tolerance) {
+		return nil
+	}
+	discountAt := history[last].At
+
+	for i := last - 1; i > 0; i-- {
+		if discountAt.Sub(history[i].At) > cfg.HikeWindow {
+			break
+		}
+		if !greater(history[i].Price, history[i-1].Price, cfg.Tolerance) {
+			continue
+		}
+
+		before := history[i-1].Price
+		if greater(before, in.CurrentPrice, cfg.Tolerance) {
+			// A genuine discount below the pre-hike price
+			return nil
+		}
+		return &Flag{
+			Kind:  DiscountAfterHike,
+			Score: (history[i].Price - before) / before * 100,
+			Detail: fmt.Sprintf("price rose from %.2f to %.2f on %s before being discounted to %.2f",
+				before, history[i].Price, history[i].At.Format("2006-01-02"), in.CurrentPrice),
+		}
+	}
+	return nil
+}
+
+// detectOutlier compares the current price with the earlier prices using the
+// median absolute deviation, falling back to the standard deviation when
+// more than half the earlier prices are identical.
+func detectOutlier(history []PricePoint, in Input, cfg Config) *Flag {
+	earlier := make([]float64, 0, len(history)-1)
+	for _, p := range history[:len(history)-1] {
+		earlier = append(earlier, p.Price)
+	}
+	if len(earlier) < cfg.MinOutlierPoints {
+		return nil
+	}
+
+	med := median(earlier)
+	deviations := make([]float64, len(earlier))
+	for i, v := range earlier {
+		deviations[i] = math.Abs(v - med)
+	}
+	mad := median(deviations)
+
+	var z float64
+	method := "MAD"
+	if mad > 0 {
+		z = 0.6745 * (in.CurrentPrice - med) / mad
+	} else {
+		mean, std := meanStd(earlier)
+		if std == 0 {
+			return nil
+		}
+		z = (in.CurrentPrice - mean) / std
+		method = "standard deviation"
+	}
+	if math.Abs(z) < cfg.OutlierThreshold {
+		return nil
+	}
+
+	return &Flag{
+		Kind:  PriceOutlier,
+		Score: z,
+		Detail: fmt.Sprintf("price %.2f is %.1f deviations (%s) from the median of %.2f over %d earlier prices",
+			in.CurrentPrice, z, method, med, len(earlier)),
+	}
+}
+
+// greater reports whether a exceeds b by more than the relative tolerance.
+func greater(a, b, tolerance float64) bool {
+	return a > b*(1+tolerance)
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func meanStd(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
@@ -0,0 +1,185 @@
+package anomaly
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// at is a price that took effect daysAgo days before now.
+type at struct {
+	daysAgo int
+	price   float64
+}
+
+func input(original float64, points ...at) Input {
+	in := Input{OriginalPrice: original, Now: now}
+	for _, p := range points {
+		in.History = append(in.History, PricePoint{Price: p.price, At: now.AddDate(0, 0, -p.daysAgo)})
+	}
+	in.CurrentPrice = in.History[len(in.History)-1].Price
+	return in
+}
+
+func find(flags []Flag, kind Kind) *Flag {
+	for i := range flags {
+		if flags[i].Kind == kind {
+			return &flags[i]
+		}
+	}
+	return nil
+}
+
+func TestNeverChargedOriginal(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        Input
+		wantScore float64
+		flagged   bool
+	}{
+		{
+			name:      "original above every recorded price",
+			in:        input(150, at{30, 100}, at{20, 100}, at{10, 100}),
+			wantScore: 100.0 / 3,
+			flagged:   true,
+		},
+		{
+			name: "original was charged once",
+			in:   input(150, at{30, 100}, at{25, 150}, at{10, 100}),
+		},
+		{
+			name: "original within tolerance of a charged price",
+			in:   input(101, at{30, 100}, at{20, 80}),
+		},
+		{
+			name: "history too short to tell",
+			in:   input(150, at{7, 100}, at{3, 100}),
+		},
+		{
+			name: "no original price",
+			in:   input(0, at{30, 100}, at{10, 100}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := find(Detect(tt.in, DefaultConfig()), NeverChargedOriginal)
+			if (flag != nil) != tt.flagged {
+				t.Fatalf("flagged = %v, want %v", flag != nil, tt.flagged)
+			}
+			if flag != nil && math.Abs(flag.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %v, want %v", flag.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestDiscountAfterHike(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        Input
+		wantScore float64
+		flagged   bool
+	}{
+		{
+			name:      "discount back to the pre-hike price",
+			in:        input(0, at{40, 100}, at{20, 130}, at{5, 100}),
+			wantScore: 30,
+			flagged:   true,
+		},
+		{
+			name:      "discount above the pre-hike price",
+			in:        input(0, at{40, 100}, at{20, 130}, at{5, 120}),
+			wantScore: 30,
+			flagged:   true,
+		},
+		{
+			name: "genuine discount below the pre-hike price",
+			in:   input(0, at{40, 100}, at{20, 130}, at{5, 80}),
+		},
+		{
+			name: "hike too long before the discount",
+			in:   input(0, at{80, 100}, at{60, 130}, at{5, 100}),
+		},
+		{
+			name: "current price is a rise",
+			in:   input(0, at{40, 100}, at{20, 130}, at{5, 140}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := find(Detect(tt.in, DefaultConfig()), DiscountAfterHike)
+			if (flag != nil) != tt.flagged {
+				t.Fatalf("flagged = %v, want %v", flag != nil, tt.flagged)
+			}
+			if flag != nil && math.Abs(flag.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %v, want %v", flag.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestPriceOutlier(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         Input
+		wantScore  float64
+		wantMethod string
+		flagged    bool
+	}{
+		{
+			name:       "far above the median",
+			in:         input(0, at{60, 100}, at{50, 102}, at{40, 98}, at{30, 101}, at{20, 99}, at{10, 100}, at{1, 200}),
+			wantScore:  67.45,
+			wantMethod: "(MAD)",
+			flagged:    true,
+		},
+		{
+			name: "within the usual spread",
+			in:   input(0, at{60, 100}, at{50, 102}, at{40, 98}, at{30, 101}, at{20, 99}, at{10, 100}, at{1, 102}),
+		},
+		{
+			// More than half the earlier prices are equal, so the MAD is 0
+			name:       "standard deviation when the MAD is 0",
+			in:         input(0, at{50, 100}, at{40, 100}, at{30, 100}, at{20, 100}, at{10, 120}, at{1, 140}),
+			wantScore:  4.5,
+			wantMethod: "(standard deviation)",
+			flagged:    true,
+		},
+		{
+			name: "every earlier price equal",
+			in:   input(0, at{50, 100}, at{40, 100}, at{30, 100}, at{20, 100}, at{10, 100}, at{1, 140}),
+		},
+		{
+			name: "too few earlier prices",
+			in:   input(0, at{30, 100}, at{20, 100}, at{10, 101}, at{1, 200}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := find(Detect(tt.in, DefaultConfig()), PriceOutlier)
+			if (flag != nil) != tt.flagged {
+				t.Fatalf("flagged = %v, want %v", flag != nil, tt.flagged)
+			}
+			if flag == nil {
+				return
+			}
+			if math.Abs(flag.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %v, want %v", flag.Score, tt.wantScore)
+			}
+			if !strings.Contains(flag.Detail, tt.wantMethod) {
+				t.Errorf("detail %q does not mention %s", flag.Detail, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestDetectIgnoresHistoryBeyondLookback(t *testing.T) {
+	// The only earlier prices fall outside the 90 day lookback
+	in := input(150, at{200, 150}, at{120, 100}, at{10, 100})
+	if flag := find(Detect(in, DefaultConfig()), NeverChargedOriginal); flag == nil {
+		t.Fatal("original charged before the lookback should still be flagged")
+	}
+}
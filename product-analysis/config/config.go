@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/scoring"
 )
 
 type Config struct {
@@ -13,6 +16,21 @@ type Config struct {
 	DBPass string
 	DBName string
 	Port   int
+
+	// Scorer names the scorer whose result is reported as a product's
+	// popularity score.
+	Scorer           string
+	ScoreWeights     scoring.Weights
+	TrendingHalfLife time.Duration
+	// ScoringInterval is how often scores are recomputed.
+	ScoringInterval time.Duration
+	// ScoringHistory is how much engagement history scorers see.
+	ScoringHistory time.Duration
+	// SentimentInterval is how often new reviews are analysed.
+	SentimentInterval time.Duration
+	// MatchingInterval is how often listings are matched into canonical
+	// products.
+	MatchingInterval time.Duration
 }
 
 func LoadConfig() (*Config, error) {
@@ -26,13 +44,58 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid db port: %v", err)
 	}
 
+	var weights scoring.Weights
+	for _, w := range []struct {
+		key          string
+		defaultValue string
+		target       *float64
+	}{
+		{"SCORE_WEIGHT_VIEWS", "0.1", &weights.Views},
+		{"SCORE_WEIGHT_FAVORITES", "0.2", &weights.Favorites},
+		{"SCORE_WEIGHT_ADD_TO_CARTS", "0.3", &weights.AddToCarts},
+		{"SCORE_WEIGHT_ORDERS", "0.4", &weights.Orders},
+	} {
+		value, err := strconv.ParseFloat(getEnvOrDefault(w.key, w.defaultValue), 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid %s: must be a non-negative number", w.key)
+		}
+		*w.target = value
+	}
+
+	durations := make(map[string]time.Duration)
+	for key, defaultValue := range map[string]string{
+		"TRENDING_HALF_LIFE": "72h",
+		"SCORING_INTERVAL":   "1h",
+		"SCORING_HISTORY":    "336h",
+		"SENTIMENT_INTERVAL": "15m",
+		"MATCHING_INTERVAL":  "6h",
+	} {
+		value, err := time.ParseDuration(getEnvOrDefault(key, defaultValue))
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("invalid %s: must be a positive duration", key)
+		}
+		durations[key] = value
+	}
+
+	scorer := getEnvOrDefault("SCORER", "percentile")
+	if scorer != "percentile" && scorer != "trending" {
+		return nil, fmt.Errorf("invalid SCORER %q: must be percentile or trending", scorer)
+	}
+
 	return &Config{
-		DBHost: getEnvOrDefault("DB_HOST", "localhost"),
-		DBPort: dbPort,
-		DBUser: getEnvOrDefault("DB_USER", "postgres"),
-		DBPass: getEnvOrDefault("DB_PASS", "postgres"),
-		DBName: getEnvOrDefault("DB_NAME", "ecommerce"),
-		Port:   port,
+		DBHost:            getEnvOrDefault("DB_HOST", "localhost"),
+		DBPort:            dbPort,
+		DBUser:            getEnvOrDefault("DB_USER", "postgres"),
+		DBPass:            getEnvOrDefault("DB_PASS", "postgres"),
+		DBName:            getEnvOrDefault("DB_NAME", "ecommerce"),
+		Port:              port,
+		Scorer:            scorer,
+		ScoreWeights:      weights,
+		TrendingHalfLife:  durations["TRENDING_HALF_LIFE"],
+		ScoringInterval:   durations["SCORING_INTERVAL"],
+		ScoringHistory:    durations["SCORING_HISTORY"],
+		SentimentInterval: durations["SENTIMENT_INTERVAL"],
+		MatchingInterval:  durations["MATCHING_INTERVAL"],
 	}, nil
 }
 
@@ -0,0 +1,357 @@
+// Package forecast predicts a variant's price over the coming days from its
+// daily price series.
+//
+// Two models are fitted: a weekly seasonal naive model and simple
+// exponential smoothing on the series with day-of-week and holiday effects
+// divided out. Each is backtested on the most recent days and the one with
+// the lower mean absolute error produces the forecast.
+package forecast
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrInsufficientHistory is returned when the series is too short to fit
+// and backtest a model.
+var ErrInsufficientHistory = errors.New("not enough price history to forecast")
+
+// z80 is the standard normal quantile bounding a central 80% interval.
+const z80 = 1.2816
+
+type Config struct {
+	// MinDays is the shortest series Forecast accepts.
+	MinDays int
+	// BacktestDays is how many of the latest days are held out, one
+	// forecast origin each, to score the models.
+	BacktestDays int
+	// BacktestHorizon is how far ahead each backtest origin forecasts.
+	BacktestHorizon int
+	// DropThreshold is the relative fall below the current price that
+	// counts as a drop.
+	DropThreshold float64
+	// Holidays reports whether a day is a shopping holiday.
+	Holidays func(day time.Time) bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MinDays:         28,
+		BacktestDays:    14,
+		BacktestHorizon: 7,
+		DropThreshold:   0.01,
+		Holidays:        DefaultHolidays,
+	}
+}
+
+// Range is the forecast over the next Horizon days.
+type Range struct {
+	Horizon int
+	// Expected is the mean forecast price over the horizon.
+	Expected float64
+	// Low and High bound the 80% interval over the horizon.
+	Low  float64
+	High float64
+	// DropProbability is the chance that on at least one day the price is
+	// DropThreshold or more below the current price, taking the most likely
+	// day as the estimate.
+	DropProbability float64
+}
+
+// Backtest scores a model on the held-out days.
+type Backtest struct {
+	Model string
+	// MAE and MAPE are the mean absolute and mean absolute percentage
+	// errors over all backtest forecasts.
+	MAE  float64
+	MAPE float64
+	// Coverage is the share of actual prices inside the 80% interval.
+	Coverage float64
+	Points   int
+}
+
+type Result struct {
+	Model        string
+	CurrentPrice float64
+	Ranges       []Range
+	Backtests    []Backtest
+}
+
+// model is fitted to a daily series and forecasts h days past its end.
+type model interface {
+	name() string
+	fit(series []float64, start time.Time)
+	predict(h int) (mean, sigma float64)
+}
+
+// Forecast fits both models to a daily price series starting at start, picks
+// the one that backtests better and forecasts each horizon in days.
+func Forecast(series []float64, start time.Time, horizons []int, cfg Config) (*Result, error) {
+	if len(series) < cfg.MinDays || len(series) <= cfg.BacktestDays {
+		return nil, ErrInsufficientHistory
+	}
+
+	candidates := []func() model{
+		func() model { return &seasonalNaive{period: 7} },
+		func() model { return &smoothing{holidays: cfg.Holidays} },
+	}
+
+	result := &Result{CurrentPrice: series[len(series)-1]}
+	var best model
+	bestMAE := math.Inf(1)
+	for _, newModel := range candidates {
+		backtest := runBacktest(newModel, series, start, cfg)
+		result.Backtests = append(result.Backtests, backtest)
+
+		m := newModel()
+		m.fit(series, start)
+		if backtest.MAE < bestMAE {
+			best, bestMAE = m, backtest.MAE
+		}
+	}
+	result.Model = best.name()
+
+	floor := result.CurrentPrice * (1 - cfg.DropThreshold)
+	for _, horizon := range horizons {
+		r := Range{Horizon: horizon, Low: math.Inf(1), High: math.Inf(-1)}
+		var sum float64
+		for h := 1; h <= horizon; h++ {
+			mean, sigma := best.predict(h)
+			sum += mean
+			r.Low = math.Min(r.Low, math.Max(mean-z80*sigma, 0))
+			r.High = math.Max(r.High, mean+z80*sigma)
+			r.DropProbability = math.Max(r.DropProbability, probabilityBelow(floor, mean, sigma))
+		}
+		r.Expected = sum / float64(horizon)
+		result.Ranges = append(result.Ranges, r)
+	}
+	return result, nil
+}
+
+// runBacktest refits the model at each of the last BacktestDays origins and
+// scores its forecasts up to BacktestHorizon days ahead.
+func runBacktest(newModel func() model, series []float64, start time.Time, cfg Config) Backtest {
+	var absErr, pctErr float64
+	var covered, points int
+	name := ""
+	for origin := len(series) - cfg.BacktestDays; origin < len(series); origin++ {
+		m := newModel()
+		name = m.name()
+		m.fit(series[:origin], start)
+		for h := 1; h <= cfg.BacktestHorizon && origin+h-1 < len(series); h++ {
+			actual := series[origin+h-1]
+			mean, sigma := m.predict(h)
+			absErr += math.Abs(actual - mean)
+			if actual != 0 {
+				pctErr += math.Abs(actual-mean) / actual * 100
+			}
+			if math.Abs(actual-mean) <= z80*sigma {
+				covered++
+			}
+			points++
+		}
+	}
+
+	backtest := Backtest{Model: name, Points: points}
+	if points > 0 {
+		backtest.MAE = absErr / float64(points)
+		backtest.MAPE = pctErr / float64(points)
+		backtest.Coverage = float64(covered) / float64(points)
+	}
+	return backtest
+}
+
+// seasonalNaive forecasts each day as the same weekday in the last week.
+type seasonalNaive struct {
+	period int
+	series []float64
+	sigma  float64
+}
+
+func (m *seasonalNaive) name() string { return "seasonal_naive" }
+
+func (m *seasonalNaive) fit(series []float64, start time.Time) {
+	m.series = series
+	var diffs []float64
+	for t := m.period; t < len(series); t++ {
+		diffs = append(diffs, series[t]-series[t-m.period])
+	}
+	m.sigma = rms(diffs)
+}
+
+func (m *seasonalNaive) predict(h int) (float64, float64) {
+	n := len(m.series)
+	if n < m.period {
+		return m.series[n-1], m.sigma * math.Sqrt(float64(h))
+	}
+	seasons := (h-1)/m.period + 1
+	return m.series[n-m.period+(h-1)%m.period], m.sigma * math.Sqrt(float64(seasons))
+}
+
+// smoothing is simple exponential smoothing on the series with multiplicative
+// day-of-week and holiday effects removed, and put back on the forecast.
+type smoothing struct {
+	holidays func(day time.Time) bool
+
+	start     time.Time
+	n         int
+	weekday   [7]float64
+	holiday   float64
+	alpha     float64
+	level     float64
+	residuals float64
+}
+
+func (m *smoothing) name() string { return "exponential_smoothing" }
+
+func (m *smoothing) fit(series []float64, start time.Time) {
+	m.start, m.n = start, len(series)
+	m.estimateEffects(series)
+
+	adjusted := make([]float64, len(series))
+	for t, y := range series {
+		adjusted[t] = y / m.effect(t)
+	}
+
+	// Pick the smoothing factor with the smallest one-step-ahead error
+	bestSSE := math.Inf(1)
+	for alpha := 0.1; alpha < 0.95; alpha += 0.1 {
+		level, sse := adjusted[0], 0.0
+		for _, y := range adjusted[1:] {
+			sse += (y - level) * (y - level)
+			level = alpha*y + (1-alpha)*level
+		}
+		if sse < bestSSE {
+			bestSSE, m.alpha, m.level = sse, alpha, level
+		}
+	}
+	if len(adjusted) > 1 {
+		m.residuals = math.Sqrt(bestSSE / float64(len(adjusted)-1))
+	}
+}
+
+func (m *smoothing) predict(h int) (float64, float64) {
+	effect := m.effect(m.n - 1 + h)
+	sigma := m.residuals * math.Sqrt(1+float64(h-1)*m.alpha*m.alpha)
+	return m.level * effect, sigma * effect
+}
+
+func (m *smoothing) day(t int) time.Time {
+	return m.start.AddDate(0, 0, t)
+}
+
+func (m *smoothing) effect(t int) float64 {
+	day := m.day(t)
+	effect := m.weekday[day.Weekday()]
+	if m.holidays != nil && m.holidays(day) {
+		effect *= m.holiday
+	}
+	return effect
+}
+
+// estimateEffects sets each weekday's factor to its mean ratio to the
+// trailing weekly mean, and the holiday factor to the mean ratio on holidays.
+func (m *smoothing) estimateEffects(series []float64) {
+	var sums, counts [7]float64
+	var holidaySum, holidayCount float64
+	for t := 6; t < len(series); t++ {
+		var week float64
+		for _, y := range series[t-6 : t+1] {
+			week += y
+		}
+		week /= 7
+		if week == 0 {
+			continue
+		}
+
+		ratio := series[t] / week
+		day := m.day(t)
+		if m.holidays != nil && m.holidays(day) {
+			holidaySum += ratio
+			holidayCount++
+			continue
+		}
+		sums[day.Weekday()] += ratio
+		counts[day.Weekday()]++
+	}
+
+	for d := range m.weekday {
+		m.weekday[d] = 1
+		if counts[d] > 0 && sums[d] > 0 {
+			m.weekday[d] = sums[d] / counts[d]
+		}
+	}
+	m.holiday = 1
+	if holidayCount > 0 && holidaySum > 0 {
+		m.holiday = holidaySum / holidayCount
+	}
+}
+
+// DefaultHolidays marks the big online shopping days: New Year's Day,
+// 11.11, Black Friday and Cyber Monday.
+func DefaultHolidays(day time.Time) bool {
+	month, date := day.Month(), day.Day()
+	switch {
+	case month == time.January && date == 1:
+		return true
+	case month == time.November && date == 11:
+		return true
+	case month == time.November && day.Weekday() == time.Friday && date >= 23 && date <= 29:
+		// The day after the fourth Thursday of November
+		return true
+	case month == time.November && day.Weekday() == time.Monday && date >= 26,
+		month == time.December && day.Weekday() == time.Monday && date <= 2:
+		// Cyber Monday, three days after Black Friday
+		return true
+	}
+	return false
+}
+
+// Change is a price that took effect at At.
+type Change struct {
+	Price float64
+	At    time.Time
+}
+
+// DailySeries turns price changes into the price in effect at the end of
+// each day from start (inclusive) to end (exclusive). initial is the price
+// in effect at start.
+func DailySeries(initial float64, changes []Change, start, end time.Time) []float64 {
+	sort.SliceStable(changes, func(a, b int) bool { return changes[a].At.Before(changes[b].At) })
+
+	var series []float64
+	price, next := initial, 0
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		for next < len(changes) && changes[next].At.Before(dayEnd) {
+			price = changes[next].Price
+			next++
+		}
+		series = append(series, price)
+	}
+	return series
+}
+
+// probabilityBelow is P(X < x) for X normal with the given mean and sigma.
+func probabilityBelow(x, mean, sigma float64) float64 {
+	if sigma == 0 {
+		if mean < x {
+			return 1
+		}
+		return 0
+	}
+	return 0.5 * math.Erfc(-(x-mean)/(sigma*math.Sqrt2))
+}
+
+func rms(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
@@ -0,0 +1,148 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// start is a Monday clear of every default holiday.
+var start = time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+func TestForecastModelSelection(t *testing.T) {
+	weekly := make([]float64, 42)
+	for i := range weekly {
+		weekly[i] = 100
+		if i%7 == 5 {
+			// Saturdays are discounted
+			weekly[i] = 80
+		}
+	}
+	shifted := make([]float64, 42)
+	for i := range shifted {
+		shifted[i] = 100
+		if i >= 32 {
+			shifted[i] = 90
+		}
+	}
+
+	tests := []struct {
+		name      string
+		series    []float64
+		wantModel string
+	}{
+		{"exact weekly repetition", weekly, "seasonal_naive"},
+		{"level shift inside the backtest", shifted, "exponential_smoothing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Forecast(tt.series, start, []int{7}, DefaultConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Model != tt.wantModel {
+				t.Errorf("model = %s, want %s (backtests %+v)", result.Model, tt.wantModel, result.Backtests)
+			}
+			if len(result.Backtests) != 2 {
+				t.Errorf("got %d backtests, want one per model", len(result.Backtests))
+			}
+			if result.CurrentPrice != tt.series[len(tt.series)-1] {
+				t.Errorf("current price = %v, want %v", result.CurrentPrice, tt.series[len(tt.series)-1])
+			}
+		})
+	}
+}
+
+func TestForecastFlatSeries(t *testing.T) {
+	series := make([]float64, 35)
+	for i := range series {
+		series[i] = 50
+	}
+	result, err := Forecast(series, start, []int{1, 7}, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Ranges) != 2 {
+		t.Fatalf("got %d ranges, want 2", len(result.Ranges))
+	}
+	for _, r := range result.Ranges {
+		if math.Abs(r.Expected-50) > 1e-9 || r.DropProbability != 0 {
+			t.Errorf("horizon %d: expected %v with drop probability %v, want 50 and 0", r.Horizon, r.Expected, r.DropProbability)
+		}
+	}
+}
+
+func TestForecastInsufficientHistory(t *testing.T) {
+	_, err := Forecast(make([]float64, 27), start, []int{7}, DefaultConfig())
+	if !errors.Is(err, ErrInsufficientHistory) {
+		t.Fatalf("err = %v, want ErrInsufficientHistory", err)
+	}
+}
+
+func TestDailySeries(t *testing.T) {
+	changes := []Change{
+		// Out of order, and two on one day: the later one is in effect
+		{Price: 70, At: start.Add(3*24*time.Hour + 18*time.Hour)},
+		{Price: 90, At: start.Add(24*time.Hour + 9*time.Hour)},
+		{Price: 80, At: start.Add(3*24*time.Hour + 6*time.Hour)},
+	}
+	got := DailySeries(100, changes, start, start.AddDate(0, 0, 5))
+	want := []float64{100, 90, 90, 70, 70}
+	if len(got) != len(want) {
+		t.Fatalf("series = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("series = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDefaultHolidays(t *testing.T) {
+	tests := []struct {
+		day  string
+		want bool
+	}{
+		{"2024-01-01", true},
+		{"2024-11-11", true},
+		{"2024-11-29", true}, // Black Friday
+		{"2024-12-02", true}, // Cyber Monday
+		{"2023-11-24", true},
+		{"2023-11-27", true},
+		{"2024-11-22", false}, // a Friday, but a week early
+		{"2024-11-25", false}, // the Monday before Black Friday
+		{"2024-12-09", false},
+		{"2024-07-04", false},
+	}
+	for _, tt := range tests {
+		day, err := time.Parse("2006-01-02", tt.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := DefaultHolidays(day); got != tt.want {
+			t.Errorf("DefaultHolidays(%s) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestProbabilityBelow(t *testing.T) {
+	tests := []struct {
+		name           string
+		x, mean, sigma float64
+		want           float64
+	}{
+		{"at the mean", 100, 100, 10, 0.5},
+		{"one sigma above", 110, 100, 10, 0.8413447460685429},
+		{"one sigma below", 90, 100, 10, 0.15865525393145707},
+		{"no spread, mean below", 100, 90, 0, 1},
+		{"no spread, mean at x", 100, 100, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := probabilityBelow(tt.x, tt.mean, tt.sigma); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("probabilityBelow = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ module github.com/faisaloncode/ecommerce-crawler/product-analysis
 go 1.23.8
 
 require (
+	github.com/faisaloncode/ecommerce-crawler/identity v0.0.0-00010101000000-000000000000
+	github.com/faisaloncode/ecommerce-crawler/migrations v0.0.0-00010101000000-000000000000
+	github.com/shopspring/decimal v1.4.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
 	gorm.io/driver/postgres v1.5.11
@@ -23,3 +26,8 @@ require (
 	golang.org/x/text v0.24.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250425173222-7b384671a197 // indirect
 )
+
+replace (
+	github.com/faisaloncode/ecommerce-crawler/identity => ../identity
+	github.com/faisaloncode/ecommerce-crawler/migrations => ../migrations
+)
@@ -1,18 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
 
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/faisaloncode/ecommerce-crawler/identity"
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
 	"github.com/faisaloncode/ecommerce-crawler/product-analysis/config"
 	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
 	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/scoring"
 	"github.com/faisaloncode/ecommerce-crawler/product-analysis/service"
 )
 
@@ -29,16 +34,26 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
-	// Run migrations
-	err = db.AutoMigrate(
-		&models.ProductAnalytics{},
-		&models.PriceHistory{},
-		&models.StockHistory{},
-		&models.UpdatePriority{},
-	)
+	sqlDB, err := db.DB()
 	if err != nil {
+		log.Fatalf("Failed to get database handle: %v", err)
+	}
+
+	// `product-analysis-service migrate [up|down [n]|status]` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.RunCommand(context.Background(), sqlDB, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	// Run migrations
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	if err := migrations.VerifyModels(db, models.Tables()...); err != nil {
+		log.Fatalf("Database schema check failed: %v", err)
+	}
 
 	// Initialize gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Port))
@@ -46,10 +61,30 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(identity.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(identity.StreamServerInterceptor()),
+	)
 	productAnalysisService := service.NewProductAnalysisService(db)
 	pb.RegisterProductAnalysisServiceServer(grpcServer, productAnalysisService)
 
+	// Keep engagement snapshot history within its retention tiers
+	go productAnalysisService.StartSnapshotCompaction()
+
+	// Recompute popularity scores in the background
+	scorers := []scoring.Scorer{
+		&scoring.PercentileScorer{Weights: cfg.ScoreWeights},
+		&scoring.TrendingScorer{Weights: cfg.ScoreWeights, HalfLife: cfg.TrendingHalfLife},
+	}
+	scoringJob := service.NewScoringJob(db, scorers, cfg.Scorer, cfg.ScoringHistory)
+	go scoringJob.Start(cfg.ScoringInterval)
+
+	// Score the sentiment of newly crawled reviews
+	go service.NewSentimentJob(db).Start(cfg.SentimentInterval)
+
+	// Group listings of the same item into canonical products
+	go service.NewMatchingJob(db).Start(cfg.MatchingInterval)
+
 	// Start HTTP server for health checks
 	go func() {
 		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,209 @@
+// Package matching finds listings of the same item, such as one product
+// sold under several external IDs or re-listed by a seller, from their
+// names, brands, key attributes and image hashes.
+package matching
+
+import (
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// NameThreshold is the share of name tokens two listings must have in
+	// common to match on their names alone.
+	NameThreshold = 0.8
+	// ImageNameThreshold is the lower share of name tokens that is enough
+	// when the listings also have a near-identical image.
+	ImageNameThreshold = 0.5
+	// MaxImageDistance is how many bits two image hashes may differ in for
+	// the images to count as the same picture.
+	MaxImageDistance = 10
+)
+
+// keyAttributes are attributes that identify an item by themselves. When
+// two listings both have one, it alone decides whether they match. Names
+// are compared case-insensitively.
+var keyAttributes = []string{"barcode", "barkod", "gtin", "ean", "mpn", "model", "model kodu"}
+
+// Listing is what matching looks at for one product.
+type Listing struct {
+	ProductID uint
+	// BrandID is 0 when the brand is unknown.
+	BrandID uint
+	Name    string
+	// Attributes maps attribute names to values.
+	Attributes  map[string]string
+	ImageHashes []uint64
+	// Group is the canonical product the listing was locked into by hand,
+	// or 0 when the matcher may move it.
+	Group uint
+}
+
+// NormalizeName lowercases name, drops punctuation and returns its distinct
+// tokens in order.
+func NormalizeName(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLowerSpecial(unicode.TurkishCase, name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(fields))
+	tokens := fields[:0]
+	for _, f := range fields {
+		if !seen[f] {
+			seen[f] = true
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// Similar reports whether a and b are listings of the same item. Listings
+// of different known brands never are.
+func Similar(a, b Listing) bool {
+	if a.BrandID != 0 && b.BrandID != 0 && a.BrandID != b.BrandID {
+		return false
+	}
+	for _, key := range keyAttributes {
+		x, y := normalizeValue(attribute(a.Attributes, key)), normalizeValue(attribute(b.Attributes, key))
+		if x != "" && y != "" {
+			return x == y
+		}
+	}
+
+	shared := jaccard(NormalizeName(a.Name), NormalizeName(b.Name))
+	if shared >= NameThreshold {
+		return true
+	}
+	return shared >= ImageNameThreshold && sameImage(a.ImageHashes, b.ImageHashes)
+}
+
+// Cluster groups listings of the same item and returns the groups, each
+// sorted by product ID, largest first. Listings are only compared within
+// their brand, or within their first name token when the brand is unknown.
+// Two listings locked into different groups never end up together, and
+// every listing locked into a group does.
+func Cluster(listings []Listing) [][]Listing {
+	parent := make([]int, len(listings))
+	for i := range parent {
+		parent[i] = i
+	}
+	// locked is the group each root was locked into, if any.
+	locked := make([]uint, len(listings))
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		ri, rj := find(i), find(j)
+		if ri == rj {
+			return
+		}
+		if locked[ri] != 0 && locked[rj] != 0 && locked[ri] != locked[rj] {
+			return
+		}
+		parent[rj] = ri
+		if locked[ri] == 0 {
+			locked[ri] = locked[rj]
+		}
+	}
+
+	byGroup := make(map[uint]int)
+	blocks := make(map[string][]int)
+	for i, l := range listings {
+		locked[i] = l.Group
+		if l.Group != 0 {
+			if first, ok := byGroup[l.Group]; ok {
+				union(first, i)
+			} else {
+				byGroup[l.Group] = i
+			}
+		}
+		blocks[blockKey(l)] = append(blocks[blockKey(l)], i)
+	}
+	for _, block := range blocks {
+		for x, i := range block {
+			for _, j := range block[x+1:] {
+				if find(i) != find(j) && Similar(listings[i], listings[j]) {
+					union(i, j)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]Listing)
+	for i, l := range listings {
+		root := find(i)
+		members[root] = append(members[root], l)
+	}
+	clusters := make([][]Listing, 0, len(members))
+	for _, cluster := range members {
+		sort.Slice(cluster, func(i, j int) bool { return cluster[i].ProductID < cluster[j].ProductID })
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i]) != len(clusters[j]) {
+			return len(clusters[i]) > len(clusters[j])
+		}
+		return clusters[i][0].ProductID < clusters[j][0].ProductID
+	})
+	return clusters
+}
+
+func blockKey(l Listing) string {
+	if l.BrandID != 0 {
+		return "b" + strconv.FormatUint(uint64(l.BrandID), 10)
+	}
+	if tokens := NormalizeName(l.Name); len(tokens) > 0 {
+		return "t" + tokens[0]
+	}
+	return ""
+}
+
+func attribute(attributes map[string]string, name string) string {
+	if value, ok := attributes[name]; ok {
+		return value
+	}
+	for key, value := range attributes {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+func normalizeValue(value string) string {
+	return strings.Join(NormalizeName(value), "")
+}
+
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[t] = true
+	}
+	shared := 0
+	for _, t := range b {
+		if set[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func sameImage(a, b []uint64) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if bits.OnesCount64(x^y) <= MaxImageDistance {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -22,13 +23,26 @@ type ProductAnalytics struct {
 	UpdatedAt        time.Time
 }
 
+// PriceHistory is one price observation of a variant from a seller. A row is
+// written whenever the sale, list or basket price, the currency or the
+// promotions change; OldPrice is the previous sale price from the same seller
+// and NewPrice the current one.
 type PriceHistory struct {
-	ID         uint      `gorm:"primaryKey"`
-	VariantID  uint      `gorm:"index"`
-	OldPrice   float64
-	NewPrice   float64
-	ChangedAt  time.Time
-	CreatedAt  time.Time
+	ID          uint                `gorm:"primaryKey"`
+	VariantID   uint                `gorm:"index"`
+	SellerID    *uint
+	Currency    string              `gorm:"size:3;not null;default:TRY"`
+	OldPrice    decimal.Decimal     `gorm:"type:decimal(10,2)"`
+	NewPrice    decimal.Decimal     `gorm:"type:decimal(10,2)"`
+	ListPrice   decimal.NullDecimal `gorm:"type:decimal(10,2)"`
+	BasketPrice decimal.NullDecimal `gorm:"type:decimal(10,2)"`
+	Promotions  Labels              `gorm:"type:jsonb;not null"`
+	ChangedAt   time.Time
+	CreatedAt   time.Time
+}
+
+func (PriceHistory) TableName() string {
+	return "price_history"
 }
 
 type StockHistory struct {
@@ -40,6 +54,10 @@ type StockHistory struct {
 	CreatedAt    time.Time
 }
 
+func (StockHistory) TableName() string {
+	return "stock_history"
+}
+
 type UpdatePriority struct {
 	ID          uint      `gorm:"primaryKey"`
 	ProductID   uint      `gorm:"uniqueIndex"`
@@ -49,6 +67,108 @@ type UpdatePriority struct {
 	UpdatedAt   time.Time
 }
 
+// Engagement snapshot resolutions. Raw snapshots are written on every
+// analysis run and later compacted into hourly and then daily buckets.
+const (
+	ResolutionRaw    = "raw"
+	ResolutionHourly = "hourly"
+	ResolutionDaily  = "daily"
+)
+
+// EngagementSnapshot records a product's engagement counters at one
+// analysis run, or the last counters seen in an hourly or daily bucket.
+type EngagementSnapshot struct {
+	ID             uint      `gorm:"primaryKey"`
+	ProductID      uint      `gorm:"index"`
+	ViewCount      int
+	FavoriteCount  int
+	AddToCartCount int
+	OrderCount     int
+	CapturedAt     time.Time
+	Resolution     string    `gorm:"size:8;not null;default:raw"`
+}
+
+// ProductScore is a product's popularity score from one scorer and its rank
+// within its category.
+type ProductScore struct {
+	ProductID    uint   `gorm:"primaryKey;autoIncrement:false"`
+	Scorer       string `gorm:"primaryKey;size:32"`
+	CategoryID   *uint
+	Score        float64
+	CategoryRank int
+	ComputedAt   time.Time
+}
+
+// PriceAnomaly is a suspicious variant price. It stays open while the
+// condition holds and is resolved once an analysis no longer finds it.
+type PriceAnomaly struct {
+	ID            uint   `gorm:"primaryKey"`
+	VariantID     uint   `gorm:"index"`
+	SellerID      *uint
+	Kind          string `gorm:"size:32;not null"`
+	Score         float64
+	Detail        string              `gorm:"type:text;not null"`
+	Price         decimal.Decimal     `gorm:"type:decimal(10,2)"`
+	OriginalPrice decimal.NullDecimal `gorm:"type:decimal(10,2)"`
+	DetectedAt    time.Time
+	LastSeenAt    time.Time
+	ResolvedAt    *time.Time
+}
+
+// BuyBoxTenure is a stretch of time during which one seller's offer was the
+// cheapest in-stock offer on a variant, shipping included. The prices are
+// those of the winning offer when it won. LostAt is nil while it still holds.
+type BuyBoxTenure struct {
+	ID           uint            `gorm:"primaryKey"`
+	VariantID    uint            `gorm:"index"`
+	SellerID     uint            `gorm:"not null"`
+	Currency     string          `gorm:"size:3;not null"`
+	Price        decimal.Decimal `gorm:"type:decimal(10,2)"`
+	ShippingCost decimal.Decimal `gorm:"type:decimal(10,2)"`
+	// OfferCount is how many in-stock offers competed when it was won.
+	OfferCount int
+	WonAt      time.Time
+	LostAt     *time.Time
+}
+
+// ReviewSentiment is the offline sentiment analysis of one crawled review.
+type ReviewSentiment struct {
+	ReviewID   uint   `gorm:"primaryKey;autoIncrement:false"`
+	ProductID  uint   `gorm:"index"`
+	Language   string `gorm:"size:2;not null"`
+	Score      float64
+	Keywords   Labels `gorm:"type:jsonb;not null"`
+	AnalysedAt time.Time
+}
+
+// ReviewAspect is an aspect of the purchase, such as sizing or shipping,
+// that a review talks about.
+type ReviewAspect struct {
+	ReviewID  uint   `gorm:"primaryKey;autoIncrement:false"`
+	ProductID uint   `gorm:"index"`
+	Aspect    string `gorm:"primaryKey;size:32"`
+	Score     float64
+}
+
+// CanonicalProduct groups the listings of one item: the same product sold
+// under several external IDs or re-listed.
+type CanonicalProduct struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:500;not null"`
+	BrandID   *uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CanonicalMembership places a product in its canonical product. Locked
+// memberships were confirmed or split by hand and are kept by the matcher.
+type CanonicalMembership struct {
+	ProductID          uint `gorm:"primaryKey;autoIncrement:false"`
+	CanonicalProductID uint `gorm:"index"`
+	Locked             bool `gorm:"not null"`
+	MatchedAt          time.Time
+}
+
 // BeforeCreate will set the timestamps
 func (pa *ProductAnalytics) BeforeCreate(tx *gorm.DB) error {
 	now := time.Now()
@@ -94,3 +214,14 @@ func (up *UpdatePriority) BeforeUpdate(tx *gorm.DB) error {
 	up.UpdatedAt = time.Now()
 	return nil
 }
+
+// BeforeCreate will set the timestamps
+func (es *EngagementSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if es.CapturedAt.IsZero() {
+		es.CapturedAt = time.Now()
+	}
+	if es.Resolution == "" {
+		es.Resolution = ResolutionRaw
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package models
+
+// Tables lists the models whose tables the product analysis service owns.
+// Their schema is defined in migrations/.
+func Tables() []interface{} {
+	return []interface{}{
+		&ProductAnalytics{},
+		&PriceHistory{},
+		&StockHistory{},
+		&UpdatePriority{},
+		&EngagementSnapshot{},
+		&ProductScore{},
+		&PriceAnomaly{},
+		&BuyBoxTenure{},
+		&ReviewSentiment{},
+		&ReviewAspect{},
+		&CanonicalProduct{},
+		&CanonicalMembership{},
+	}
+}
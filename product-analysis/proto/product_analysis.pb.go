@@ -102,29 +102,41 @@ func (x *HealthResponse) GetStatus() string {
 }
 
 type ProductData struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description        string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	CategoryId         string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
-	BrandId            string                 `protobuf:"bytes,5,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
-	SellerId           string                 `protobuf:"bytes,6,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
-	RatingScore        float32                `protobuf:"fixed32,7,opt,name=rating_score,json=ratingScore,proto3" json:"rating_score,omitempty"`
-	FavoriteCount      int32                  `protobuf:"varint,8,opt,name=favorite_count,json=favoriteCount,proto3" json:"favorite_count,omitempty"`
-	CommentCount       int32                  `protobuf:"varint,9,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
-	ViewCount          int32                  `protobuf:"varint,10,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
-	AddToCartCount     int32                  `protobuf:"varint,11,opt,name=add_to_cart_count,json=addToCartCount,proto3" json:"add_to_cart_count,omitempty"`
-	OrderCount         int32                  `protobuf:"varint,12,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
-	SizeRecommendation string                 `protobuf:"bytes,13,opt,name=size_recommendation,json=sizeRecommendation,proto3" json:"size_recommendation,omitempty"`
-	EstimatedDelivery  string                 `protobuf:"bytes,14,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
-	IsActive           bool                   `protobuf:"varint,15,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	Variants           []*ProductVariant      `protobuf:"bytes,16,rep,name=variants,proto3" json:"variants,omitempty"`
-	Images             []*ProductImage        `protobuf:"bytes,17,rep,name=images,proto3" json:"images,omitempty"`
-	Attributes         []*ProductAttribute    `protobuf:"bytes,18,rep,name=attributes,proto3" json:"attributes,omitempty"`
-	SimilarProductIds  []string               `protobuf:"bytes,19,rep,name=similar_product_ids,json=similarProductIds,proto3" json:"similar_product_ids,omitempty"`
-	TopReviews         []*Review              `protobuf:"bytes,20,rep,name=top_reviews,json=topReviews,proto3" json:"top_reviews,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The product's external ID, as crawled.
+	Id                 string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name               string              `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description        string              `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CategoryId         string              `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	BrandId            string              `protobuf:"bytes,5,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	SellerId           string              `protobuf:"bytes,6,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	RatingScore        float32             `protobuf:"fixed32,7,opt,name=rating_score,json=ratingScore,proto3" json:"rating_score,omitempty"`
+	FavoriteCount      int32               `protobuf:"varint,8,opt,name=favorite_count,json=favoriteCount,proto3" json:"favorite_count,omitempty"`
+	CommentCount       int32               `protobuf:"varint,9,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`
+	ViewCount          int32               `protobuf:"varint,10,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	AddToCartCount     int32               `protobuf:"varint,11,opt,name=add_to_cart_count,json=addToCartCount,proto3" json:"add_to_cart_count,omitempty"`
+	OrderCount         int32               `protobuf:"varint,12,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	SizeRecommendation string              `protobuf:"bytes,13,opt,name=size_recommendation,json=sizeRecommendation,proto3" json:"size_recommendation,omitempty"`
+	EstimatedDelivery  string              `protobuf:"bytes,14,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	IsActive           bool                `protobuf:"varint,15,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Variants           []*ProductVariant   `protobuf:"bytes,16,rep,name=variants,proto3" json:"variants,omitempty"`
+	Images             []*ProductImage     `protobuf:"bytes,17,rep,name=images,proto3" json:"images,omitempty"`
+	Attributes         []*ProductAttribute `protobuf:"bytes,18,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	SimilarProductIds  []string            `protobuf:"bytes,19,rep,name=similar_product_ids,json=similarProductIds,proto3" json:"similar_product_ids,omitempty"`
+	TopReviews         []*Review           `protobuf:"bytes,20,rep,name=top_reviews,json=topReviews,proto3" json:"top_reviews,omitempty"`
+	SellerName         string              `protobuf:"bytes,21,opt,name=seller_name,json=sellerName,proto3" json:"seller_name,omitempty"`
+	// ISO 4217 code of the variant prices. Defaults to TRY.
+	Currency string `protobuf:"bytes,22,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Shipping cost charged by the product's seller, as an exact decimal.
+	ShippingCost string `protobuf:"bytes,23,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	// Other sellers' offers on the product's variants. The product's own
+	// seller's offers are its variants.
+	Offers    []*Offer `protobuf:"bytes,24,rep,name=offers,proto3" json:"offers,omitempty"`
+	BrandName string   `protobuf:"bytes,25,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	// The seller's rating out of 5, or 0 when the page does not show one.
+	SellerRating  float32 `protobuf:"fixed32,26,opt,name=seller_rating,json=sellerRating,proto3" json:"seller_rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProductData) Reset() {
@@ -297,23 +309,177 @@ func (x *ProductData) GetTopReviews() []*Review {
 	return nil
 }
 
+func (x *ProductData) GetSellerName() string {
+	if x != nil {
+		return x.SellerName
+	}
+	return ""
+}
+
+func (x *ProductData) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ProductData) GetShippingCost() string {
+	if x != nil {
+		return x.ShippingCost
+	}
+	return ""
+}
+
+func (x *ProductData) GetOffers() []*Offer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+func (x *ProductData) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *ProductData) GetSellerRating() float32 {
+	if x != nil {
+		return x.SellerRating
+	}
+	return 0
+}
+
+type Offer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// External IDs of the variant and the seller.
+	VariantId  string `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	SellerId   string `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	SellerName string `protobuf:"bytes,3,opt,name=seller_name,json=sellerName,proto3" json:"seller_name,omitempty"`
+	// Exact decimal prices in the product's currency.
+	Price             string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	ListPrice         string `protobuf:"bytes,5,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`
+	StockQuantity     int32  `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	ShippingCost      string `protobuf:"bytes,7,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	EstimatedDelivery string `protobuf:"bytes,8,opt,name=estimated_delivery,json=estimatedDelivery,proto3" json:"estimated_delivery,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Offer) Reset() {
+	*x = Offer{}
+	mi := &file_proto_product_analysis_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Offer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Offer) ProtoMessage() {}
+
+func (x *Offer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Offer.ProtoReflect.Descriptor instead.
+func (*Offer) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Offer) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *Offer) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *Offer) GetSellerName() string {
+	if x != nil {
+		return x.SellerName
+	}
+	return ""
+}
+
+func (x *Offer) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Offer) GetListPrice() string {
+	if x != nil {
+		return x.ListPrice
+	}
+	return ""
+}
+
+func (x *Offer) GetStockQuantity() int32 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *Offer) GetShippingCost() string {
+	if x != nil {
+		return x.ShippingCost
+	}
+	return ""
+}
+
+func (x *Offer) GetEstimatedDelivery() string {
+	if x != nil {
+		return x.EstimatedDelivery
+	}
+	return ""
+}
+
 type ProductVariant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
-	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
-	Size          string                 `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
-	Price         float32                `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
-	OriginalPrice float32                `protobuf:"fixed32,6,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
-	StockQuantity int32                  `protobuf:"varint,7,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
-	IsActive      bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The variant's external ID, unique within its product.
+	Id            string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku           string  `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	Color         string  `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	Size          string  `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	Price         float32 `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	OriginalPrice float32 `protobuf:"fixed32,6,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
+	StockQuantity int32   `protobuf:"varint,7,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	IsActive      bool    `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Exact decimal prices, for example "149.90". sale_price is what the
+	// buyer pays before basket discounts and falls back to price; list_price
+	// is the strikethrough price and falls back to original_price;
+	// basket_price is the price after coupon or basket discounts, if any.
+	ListPrice   string `protobuf:"bytes,9,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`
+	SalePrice   string `protobuf:"bytes,10,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"`
+	BasketPrice string `protobuf:"bytes,11,opt,name=basket_price,json=basketPrice,proto3" json:"basket_price,omitempty"`
+	// Promotion labels shown with the offer, such as "2nd item 50% off".
+	Promotions    []string `protobuf:"bytes,12,rep,name=promotions,proto3" json:"promotions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProductVariant) Reset() {
 	*x = ProductVariant{}
-	mi := &file_proto_product_analysis_proto_msgTypes[3]
+	mi := &file_proto_product_analysis_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -325,7 +491,7 @@ func (x *ProductVariant) String() string {
 func (*ProductVariant) ProtoMessage() {}
 
 func (x *ProductVariant) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[3]
+	mi := &file_proto_product_analysis_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +504,7 @@ func (x *ProductVariant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
 func (*ProductVariant) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{3}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ProductVariant) GetId() string {
@@ -397,6 +563,34 @@ func (x *ProductVariant) GetIsActive() bool {
 	return false
 }
 
+func (x *ProductVariant) GetListPrice() string {
+	if x != nil {
+		return x.ListPrice
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetSalePrice() string {
+	if x != nil {
+		return x.SalePrice
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetBasketPrice() string {
+	if x != nil {
+		return x.BasketPrice
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetPromotions() []string {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
 type ProductImage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
@@ -408,7 +602,7 @@ type ProductImage struct {
 
 func (x *ProductImage) Reset() {
 	*x = ProductImage{}
-	mi := &file_proto_product_analysis_proto_msgTypes[4]
+	mi := &file_proto_product_analysis_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +614,7 @@ func (x *ProductImage) String() string {
 func (*ProductImage) ProtoMessage() {}
 
 func (x *ProductImage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[4]
+	mi := &file_proto_product_analysis_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +627,7 @@ func (x *ProductImage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
 func (*ProductImage) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{4}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ProductImage) GetUrl() string {
@@ -467,7 +661,7 @@ type ProductAttribute struct {
 
 func (x *ProductAttribute) Reset() {
 	*x = ProductAttribute{}
-	mi := &file_proto_product_analysis_proto_msgTypes[5]
+	mi := &file_proto_product_analysis_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -479,7 +673,7 @@ func (x *ProductAttribute) String() string {
 func (*ProductAttribute) ProtoMessage() {}
 
 func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[5]
+	mi := &file_proto_product_analysis_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +686,7 @@ func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductAttribute.ProtoReflect.Descriptor instead.
 func (*ProductAttribute) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{5}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ProductAttribute) GetName() string {
@@ -523,7 +717,7 @@ type Review struct {
 
 func (x *Review) Reset() {
 	*x = Review{}
-	mi := &file_proto_product_analysis_proto_msgTypes[6]
+	mi := &file_proto_product_analysis_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -535,7 +729,7 @@ func (x *Review) String() string {
 func (*Review) ProtoMessage() {}
 
 func (x *Review) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[6]
+	mi := &file_proto_product_analysis_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,7 +742,7 @@ func (x *Review) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Review.ProtoReflect.Descriptor instead.
 func (*Review) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{6}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Review) GetId() string {
@@ -602,7 +796,7 @@ type AnalyzeProductRequest struct {
 
 func (x *AnalyzeProductRequest) Reset() {
 	*x = AnalyzeProductRequest{}
-	mi := &file_proto_product_analysis_proto_msgTypes[7]
+	mi := &file_proto_product_analysis_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -614,7 +808,7 @@ func (x *AnalyzeProductRequest) String() string {
 func (*AnalyzeProductRequest) ProtoMessage() {}
 
 func (x *AnalyzeProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[7]
+	mi := &file_proto_product_analysis_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +821,7 @@ func (x *AnalyzeProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeProductRequest.ProtoReflect.Descriptor instead.
 func (*AnalyzeProductRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{7}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AnalyzeProductRequest) GetProduct() *ProductData {
@@ -638,16 +832,19 @@ func (x *AnalyzeProductRequest) GetProduct() *ProductData {
 }
 
 type AnalyzeProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Notifications []string               `protobuf:"bytes,2,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Events found during analysis, as "type:key=value:...". Types are
+	// price_drop, out_of_stock, price_anomaly and buy_box_change; variant_id
+	// and seller_id are internal IDs.
+	Notifications []string `protobuf:"bytes,2,rep,name=notifications,proto3" json:"notifications,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AnalyzeProductResponse) Reset() {
 	*x = AnalyzeProductResponse{}
-	mi := &file_proto_product_analysis_proto_msgTypes[8]
+	mi := &file_proto_product_analysis_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -659,7 +856,7 @@ func (x *AnalyzeProductResponse) String() string {
 func (*AnalyzeProductResponse) ProtoMessage() {}
 
 func (x *AnalyzeProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[8]
+	mi := &file_proto_product_analysis_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +869,7 @@ func (x *AnalyzeProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AnalyzeProductResponse.ProtoReflect.Descriptor instead.
 func (*AnalyzeProductResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{8}
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AnalyzeProductResponse) GetStatus() string {
@@ -689,29 +886,28 @@ func (x *AnalyzeProductResponse) GetNotifications() []string {
 	return nil
 }
 
-type UpdateProductPriorityRequest struct {
+type AnalyzeProductsBatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	IsFavorited   bool                   `protobuf:"varint,2,opt,name=is_favorited,json=isFavorited,proto3" json:"is_favorited,omitempty"`
+	Products      []*ProductData         `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductPriorityRequest) Reset() {
-	*x = UpdateProductPriorityRequest{}
-	mi := &file_proto_product_analysis_proto_msgTypes[9]
+func (x *AnalyzeProductsBatchRequest) Reset() {
+	*x = AnalyzeProductsBatchRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductPriorityRequest) String() string {
+func (x *AnalyzeProductsBatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductPriorityRequest) ProtoMessage() {}
+func (*AnalyzeProductsBatchRequest) ProtoMessage() {}
 
-func (x *UpdateProductPriorityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[9]
+func (x *AnalyzeProductsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,47 +918,48 @@ func (x *UpdateProductPriorityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductPriorityRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductPriorityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *UpdateProductPriorityRequest) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
+// Deprecated: Use AnalyzeProductsBatchRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeProductsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateProductPriorityRequest) GetIsFavorited() bool {
+func (x *AnalyzeProductsBatchRequest) GetProducts() []*ProductData {
 	if x != nil {
-		return x.IsFavorited
+		return x.Products
 	}
-	return false
+	return nil
 }
 
-type UpdateProductPriorityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+// AnalyzeProductResult is the outcome of analysing one product of a batch.
+type AnalyzeProductResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The product's external ID.
+	ProductId string `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// success or error.
+	Status        string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Notifications []string `protobuf:"bytes,3,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	// The gRPC status code name and message of a failed analysis.
+	ErrorCode     string `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductPriorityResponse) Reset() {
-	*x = UpdateProductPriorityResponse{}
-	mi := &file_proto_product_analysis_proto_msgTypes[10]
+func (x *AnalyzeProductResult) Reset() {
+	*x = AnalyzeProductResult{}
+	mi := &file_proto_product_analysis_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductPriorityResponse) String() string {
+func (x *AnalyzeProductResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductPriorityResponse) ProtoMessage() {}
+func (*AnalyzeProductResult) ProtoMessage() {}
 
-func (x *UpdateProductPriorityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[10]
+func (x *AnalyzeProductResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -773,40 +970,70 @@ func (x *UpdateProductPriorityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductPriorityResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductPriorityResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AnalyzeProductResult.ProtoReflect.Descriptor instead.
+func (*AnalyzeProductResult) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UpdateProductPriorityResponse) GetStatus() string {
+func (x *AnalyzeProductResult) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AnalyzeProductResult) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-type GetProductAnalyticsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+func (x *AnalyzeProductResult) GetNotifications() []string {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *AnalyzeProductResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *AnalyzeProductResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AnalyzeProductsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*AnalyzeProductResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Succeeded     int32                   `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                   `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductAnalyticsRequest) Reset() {
-	*x = GetProductAnalyticsRequest{}
-	mi := &file_proto_product_analysis_proto_msgTypes[11]
+func (x *AnalyzeProductsResponse) Reset() {
+	*x = AnalyzeProductsResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductAnalyticsRequest) String() string {
+func (x *AnalyzeProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductAnalyticsRequest) ProtoMessage() {}
+func (*AnalyzeProductsResponse) ProtoMessage() {}
 
-func (x *GetProductAnalyticsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[11]
+func (x *AnalyzeProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,44 +1044,2957 @@ func (x *GetProductAnalyticsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductAnalyticsRequest.ProtoReflect.Descriptor instead.
-func (*GetProductAnalyticsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use AnalyzeProductsResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetProductAnalyticsRequest) GetProductId() string {
+func (x *AnalyzeProductsResponse) GetResults() []*AnalyzeProductResult {
 	if x != nil {
-		return x.ProductId
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-type GetProductAnalyticsResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	PriceTrend         float32                `protobuf:"fixed32,1,opt,name=price_trend,json=priceTrend,proto3" json:"price_trend,omitempty"`
-	StockTrend         float32                `protobuf:"fixed32,2,opt,name=stock_trend,json=stockTrend,proto3" json:"stock_trend,omitempty"`
-	FavoriteCountTrend int32                  `protobuf:"varint,3,opt,name=favorite_count_trend,json=favoriteCountTrend,proto3" json:"favorite_count_trend,omitempty"`
-	PopularityScore    float32                `protobuf:"fixed32,4,opt,name=popularity_score,json=popularityScore,proto3" json:"popularity_score,omitempty"`
-	PriceHistory       []*PriceHistory        `protobuf:"bytes,5,rep,name=price_history,json=priceHistory,proto3" json:"price_history,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *AnalyzeProductsResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
 }
 
-func (x *GetProductAnalyticsResponse) Reset() {
-	*x = GetProductAnalyticsResponse{}
-	mi := &file_proto_product_analysis_proto_msgTypes[12]
+func (x *AnalyzeProductsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// UpdateProductPriorityRequest names a product whose favorites or
+// notification preferences changed. Its priority is derived from them.
+type UpdateProductPriorityRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Ignored; kept for older callers.
+	//
+	// Deprecated: Marked as deprecated in proto/product_analysis.proto.
+	IsFavorited   bool `protobuf:"varint,2,opt,name=is_favorited,json=isFavorited,proto3" json:"is_favorited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductPriorityRequest) Reset() {
+	*x = UpdateProductPriorityRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductPriorityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductPriorityRequest) ProtoMessage() {}
+
+func (x *UpdateProductPriorityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductPriorityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductPriorityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateProductPriorityRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in proto/product_analysis.proto.
+func (x *UpdateProductPriorityRequest) GetIsFavorited() bool {
+	if x != nil {
+		return x.IsFavorited
+	}
+	return false
+}
+
+type UpdateProductPriorityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductPriorityResponse) Reset() {
+	*x = UpdateProductPriorityResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductPriorityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductPriorityResponse) ProtoMessage() {}
+
+func (x *UpdateProductPriorityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductPriorityResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductPriorityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateProductPriorityResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetProductAnalyticsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Number of days the windowed figures cover. Defaults to 30, at most 365.
+	WindowDays int32 `protobuf:"varint,2,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// Restricts price history and trends to one seller. When empty every
+	// seller's prices are included.
+	SellerId      string `protobuf:"bytes,3,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductAnalyticsRequest) Reset() {
+	*x = GetProductAnalyticsRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetProductAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductAnalyticsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetProductAnalyticsRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type GetProductAnalyticsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Percent change of the average variant price over the window.
+	PriceTrend float32 `protobuf:"fixed32,1,opt,name=price_trend,json=priceTrend,proto3" json:"price_trend,omitempty"`
+	// Percent change of total stock over the window.
+	StockTrend float32 `protobuf:"fixed32,2,opt,name=stock_trend,json=stockTrend,proto3" json:"stock_trend,omitempty"`
+	// Favorites gained over the window.
+	FavoriteCountTrend int32 `protobuf:"varint,3,opt,name=favorite_count_trend,json=favoriteCountTrend,proto3" json:"favorite_count_trend,omitempty"`
+	// Score from the configured primary scorer, in [0, 1].
+	PopularityScore float32 `protobuf:"fixed32,4,opt,name=popularity_score,json=popularityScore,proto3" json:"popularity_score,omitempty"`
+	// Price changes within the window, newest first.
+	PriceHistory []*PriceHistory `protobuf:"bytes,5,rep,name=price_history,json=priceHistory,proto3" json:"price_history,omitempty"`
+	WindowDays   int32           `protobuf:"varint,6,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// Price change over the fixed 7, 30 and 90 day windows.
+	PriceChanges []*PriceChange `protobuf:"bytes,7,rep,name=price_changes,json=priceChanges,proto3" json:"price_changes,omitempty"`
+	// Lowest, highest and mean variant price seen in the window.
+	MinPrice float32 `protobuf:"fixed32,8,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice float32 `protobuf:"fixed32,9,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	AvgPrice float32 `protobuf:"fixed32,10,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	// Units sold per day, inferred from stock decreases in the window.
+	StockVelocity float32 `protobuf:"fixed32,11,opt,name=stock_velocity,json=stockVelocity,proto3" json:"stock_velocity,omitempty"`
+	// Favorites gained per day over the window.
+	FavoriteGrowthRate float32 `protobuf:"fixed32,12,opt,name=favorite_growth_rate,json=favoriteGrowthRate,proto3" json:"favorite_growth_rate,omitempty"`
+	// Score and in-category rank from each scorer, as of the last scoring run.
+	Scores []*ProductScore `protobuf:"bytes,13,rep,name=scores,proto3" json:"scores,omitempty"`
+	// Open price anomaly flags on the product's variants.
+	Anomalies []*PriceAnomaly `protobuf:"bytes,14,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	// Sentiment of the product's analysed reviews.
+	ReviewSentiment *ReviewSentiment `protobuf:"bytes,15,opt,name=review_sentiment,json=reviewSentiment,proto3" json:"review_sentiment,omitempty"`
+	// The canonical product the product is a listing of. Empty until the
+	// matcher has run.
+	CanonicalProductId string `protobuf:"bytes,16,opt,name=canonical_product_id,json=canonicalProductId,proto3" json:"canonical_product_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetProductAnalyticsResponse) Reset() {
+	*x = GetProductAnalyticsResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductAnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductAnalyticsResponse) ProtoMessage() {}
+
+func (x *GetProductAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetProductAnalyticsResponse) GetPriceTrend() float32 {
+	if x != nil {
+		return x.PriceTrend
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetStockTrend() float32 {
+	if x != nil {
+		return x.StockTrend
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetFavoriteCountTrend() int32 {
+	if x != nil {
+		return x.FavoriteCountTrend
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetPopularityScore() float32 {
+	if x != nil {
+		return x.PopularityScore
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetPriceHistory() []*PriceHistory {
+	if x != nil {
+		return x.PriceHistory
+	}
+	return nil
+}
+
+func (x *GetProductAnalyticsResponse) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetPriceChanges() []*PriceChange {
+	if x != nil {
+		return x.PriceChanges
+	}
+	return nil
+}
+
+func (x *GetProductAnalyticsResponse) GetMinPrice() float32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetMaxPrice() float32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetAvgPrice() float32 {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetStockVelocity() float32 {
+	if x != nil {
+		return x.StockVelocity
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetFavoriteGrowthRate() float32 {
+	if x != nil {
+		return x.FavoriteGrowthRate
+	}
+	return 0
+}
+
+func (x *GetProductAnalyticsResponse) GetScores() []*ProductScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *GetProductAnalyticsResponse) GetAnomalies() []*PriceAnomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+func (x *GetProductAnalyticsResponse) GetReviewSentiment() *ReviewSentiment {
+	if x != nil {
+		return x.ReviewSentiment
+	}
+	return nil
+}
+
+func (x *GetProductAnalyticsResponse) GetCanonicalProductId() string {
+	if x != nil {
+		return x.CanonicalProductId
+	}
+	return ""
+}
+
+type PriceAnomaly struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VariantId string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// "never_charged_original", "discount_after_hike" or "price_outlier".
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The claimed discount or hike in percent, or the outlier's z-score.
+	Score         float32 `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`
+	Detail        string  `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	Price         float32 `protobuf:"fixed32,5,opt,name=price,proto3" json:"price,omitempty"`
+	OriginalPrice float32 `protobuf:"fixed32,6,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
+	DetectedAt    string  `protobuf:"bytes,7,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	LastSeenAt    string  `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	SellerId      string  `protobuf:"bytes,9,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceAnomaly) Reset() {
+	*x = PriceAnomaly{}
+	mi := &file_proto_product_analysis_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceAnomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceAnomaly) ProtoMessage() {}
+
+func (x *PriceAnomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceAnomaly.ProtoReflect.Descriptor instead.
+func (*PriceAnomaly) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PriceAnomaly) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *PriceAnomaly) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PriceAnomaly) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PriceAnomaly) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *PriceAnomaly) GetPrice() float32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceAnomaly) GetOriginalPrice() float32 {
+	if x != nil {
+		return x.OriginalPrice
+	}
+	return 0
+}
+
+func (x *PriceAnomaly) GetDetectedAt() string {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return ""
+}
+
+func (x *PriceAnomaly) GetLastSeenAt() string {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return ""
+}
+
+func (x *PriceAnomaly) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type ProductScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scorer        string                 `protobuf:"bytes,1,opt,name=scorer,proto3" json:"scorer,omitempty"`
+	Score         float32                `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	CategoryRank  int32                  `protobuf:"varint,3,opt,name=category_rank,json=categoryRank,proto3" json:"category_rank,omitempty"`
+	ComputedAt    string                 `protobuf:"bytes,4,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductScore) Reset() {
+	*x = ProductScore{}
+	mi := &file_proto_product_analysis_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductScore) ProtoMessage() {}
+
+func (x *ProductScore) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductScore.ProtoReflect.Descriptor instead.
+func (*ProductScore) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ProductScore) GetScorer() string {
+	if x != nil {
+		return x.Scorer
+	}
+	return ""
+}
+
+func (x *ProductScore) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ProductScore) GetCategoryRank() int32 {
+	if x != nil {
+		return x.CategoryRank
+	}
+	return 0
+}
+
+func (x *ProductScore) GetComputedAt() string {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return ""
+}
+
+type PriceChange struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WindowDays int32                  `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// Percent change of the average variant price.
+	ChangePercent float32 `protobuf:"fixed32,2,opt,name=change_percent,json=changePercent,proto3" json:"change_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_proto_product_analysis_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PriceChange) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *PriceChange) GetChangePercent() float32 {
+	if x != nil {
+		return x.ChangePercent
+	}
+	return 0
+}
+
+// PriceHistory is one price observation: the sale price changed from
+// old_price to new_price, or the list or basket price or promotions did.
+// Prices are exact decimal strings; the float fields repeat the sale prices
+// for older clients.
+type PriceHistory struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VariantId string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	OldPrice  float32                `protobuf:"fixed32,2,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
+	NewPrice  float32                `protobuf:"fixed32,3,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
+	ChangedAt string                 `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	// Empty when the seller is unknown.
+	SellerId      string   `protobuf:"bytes,5,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Currency      string   `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	OldSalePrice  string   `protobuf:"bytes,7,opt,name=old_sale_price,json=oldSalePrice,proto3" json:"old_sale_price,omitempty"`
+	SalePrice     string   `protobuf:"bytes,8,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"`
+	ListPrice     string   `protobuf:"bytes,9,opt,name=list_price,json=listPrice,proto3" json:"list_price,omitempty"`
+	BasketPrice   string   `protobuf:"bytes,10,opt,name=basket_price,json=basketPrice,proto3" json:"basket_price,omitempty"`
+	Promotions    []string `protobuf:"bytes,11,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceHistory) Reset() {
+	*x = PriceHistory{}
+	mi := &file_proto_product_analysis_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceHistory) ProtoMessage() {}
+
+func (x *PriceHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceHistory.ProtoReflect.Descriptor instead.
+func (*PriceHistory) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PriceHistory) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetOldPrice() float32 {
+	if x != nil {
+		return x.OldPrice
+	}
+	return 0
+}
+
+func (x *PriceHistory) GetNewPrice() float32 {
+	if x != nil {
+		return x.NewPrice
+	}
+	return 0
+}
+
+func (x *PriceHistory) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetOldSalePrice() string {
+	if x != nil {
+		return x.OldSalePrice
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetSalePrice() string {
+	if x != nil {
+		return x.SalePrice
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetListPrice() string {
+	if x != nil {
+		return x.ListPrice
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetBasketPrice() string {
+	if x != nil {
+		return x.BasketPrice
+	}
+	return ""
+}
+
+func (x *PriceHistory) GetPromotions() []string {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type GetEngagementSeriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// RFC 3339 bounds of the series. Default to the last 30 days.
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// "raw", "hourly" or "daily". When empty the resolution is picked from
+	// the length of the range.
+	Resolution    string `protobuf:"bytes,4,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngagementSeriesRequest) Reset() {
+	*x = GetEngagementSeriesRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngagementSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngagementSeriesRequest) ProtoMessage() {}
+
+func (x *GetEngagementSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngagementSeriesRequest.ProtoReflect.Descriptor instead.
+func (*GetEngagementSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetEngagementSeriesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetEngagementSeriesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetEngagementSeriesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetEngagementSeriesRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+type GetEngagementSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resolution    string                 `protobuf:"bytes,1,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Points        []*EngagementPoint     `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEngagementSeriesResponse) Reset() {
+	*x = GetEngagementSeriesResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEngagementSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEngagementSeriesResponse) ProtoMessage() {}
+
+func (x *GetEngagementSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEngagementSeriesResponse.ProtoReflect.Descriptor instead.
+func (*GetEngagementSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetEngagementSeriesResponse) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *GetEngagementSeriesResponse) GetPoints() []*EngagementPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type EngagementPoint struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CapturedAt     string                 `protobuf:"bytes,1,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	ViewCount      int32                  `protobuf:"varint,2,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	FavoriteCount  int32                  `protobuf:"varint,3,opt,name=favorite_count,json=favoriteCount,proto3" json:"favorite_count,omitempty"`
+	AddToCartCount int32                  `protobuf:"varint,4,opt,name=add_to_cart_count,json=addToCartCount,proto3" json:"add_to_cart_count,omitempty"`
+	OrderCount     int32                  `protobuf:"varint,5,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EngagementPoint) Reset() {
+	*x = EngagementPoint{}
+	mi := &file_proto_product_analysis_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngagementPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngagementPoint) ProtoMessage() {}
+
+func (x *EngagementPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngagementPoint.ProtoReflect.Descriptor instead.
+func (*EngagementPoint) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EngagementPoint) GetCapturedAt() string {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return ""
+}
+
+func (x *EngagementPoint) GetViewCount() int32 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *EngagementPoint) GetFavoriteCount() int32 {
+	if x != nil {
+		return x.FavoriteCount
+	}
+	return 0
+}
+
+func (x *EngagementPoint) GetAddToCartCount() int32 {
+	if x != nil {
+		return x.AddToCartCount
+	}
+	return 0
+}
+
+func (x *EngagementPoint) GetOrderCount() int32 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+type ListTrendingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Restricts the ranking to one category. When empty every category is
+	// ranked separately.
+	CategoryId string `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Growth is measured over this many days. Defaults to 7, at most 365.
+	WindowDays int32 `protobuf:"varint,2,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// "views", "favorites", "add_to_carts" or "orders". Defaults to "views".
+	Metric string `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`
+	// Products returned per category. Defaults to 10, at most 100.
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrendingRequest) Reset() {
+	*x = ListTrendingRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrendingRequest) ProtoMessage() {}
+
+func (x *ListTrendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrendingRequest.ProtoReflect.Descriptor instead.
+func (*ListTrendingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTrendingRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListTrendingRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *ListTrendingRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *ListTrendingRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTrendingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowDays    int32                  `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	Metric        string                 `protobuf:"bytes,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	Products      []*TrendingProduct     `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrendingResponse) Reset() {
+	*x = ListTrendingResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrendingResponse) ProtoMessage() {}
+
+func (x *ListTrendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrendingResponse.ProtoReflect.Descriptor instead.
+func (*ListTrendingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTrendingResponse) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *ListTrendingResponse) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *ListTrendingResponse) GetProducts() []*TrendingProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type TrendingProduct struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductId  string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// 1-based rank within the category by growth of the requested metric.
+	Rank int32 `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Growth of each counter over the window.
+	ViewGrowth      int32 `protobuf:"varint,4,opt,name=view_growth,json=viewGrowth,proto3" json:"view_growth,omitempty"`
+	FavoriteGrowth  int32 `protobuf:"varint,5,opt,name=favorite_growth,json=favoriteGrowth,proto3" json:"favorite_growth,omitempty"`
+	AddToCartGrowth int32 `protobuf:"varint,6,opt,name=add_to_cart_growth,json=addToCartGrowth,proto3" json:"add_to_cart_growth,omitempty"`
+	OrderGrowth     int32 `protobuf:"varint,7,opt,name=order_growth,json=orderGrowth,proto3" json:"order_growth,omitempty"`
+	// Percent growth of the requested metric; 0 when it started at zero.
+	GrowthPercent float32 `protobuf:"fixed32,8,opt,name=growth_percent,json=growthPercent,proto3" json:"growth_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingProduct) Reset() {
+	*x = TrendingProduct{}
+	mi := &file_proto_product_analysis_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingProduct) ProtoMessage() {}
+
+func (x *TrendingProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingProduct.ProtoReflect.Descriptor instead.
+func (*TrendingProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TrendingProduct) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TrendingProduct) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *TrendingProduct) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TrendingProduct) GetViewGrowth() int32 {
+	if x != nil {
+		return x.ViewGrowth
+	}
+	return 0
+}
+
+func (x *TrendingProduct) GetFavoriteGrowth() int32 {
+	if x != nil {
+		return x.FavoriteGrowth
+	}
+	return 0
+}
+
+func (x *TrendingProduct) GetAddToCartGrowth() int32 {
+	if x != nil {
+		return x.AddToCartGrowth
+	}
+	return 0
+}
+
+func (x *TrendingProduct) GetOrderGrowth() int32 {
+	if x != nil {
+		return x.OrderGrowth
+	}
+	return 0
+}
+
+func (x *TrendingProduct) GetGrowthPercent() float32 {
+	if x != nil {
+		return x.GrowthPercent
+	}
+	return 0
+}
+
+type ListTopMoversRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId string                 `protobuf:"bytes,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Price change is measured over this many days. Defaults to 7, at most 365.
+	WindowDays int32 `protobuf:"varint,2,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	// "down" ranks the largest price drops first, "up" the largest rises.
+	// Defaults to "down".
+	Direction string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	// Products returned per category. Defaults to 10, at most 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only compares prices from this seller when set.
+	SellerId      string `protobuf:"bytes,5,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopMoversRequest) Reset() {
+	*x = ListTopMoversRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopMoversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopMoversRequest) ProtoMessage() {}
+
+func (x *ListTopMoversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopMoversRequest.ProtoReflect.Descriptor instead.
+func (*ListTopMoversRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTopMoversRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListTopMoversRequest) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *ListTopMoversRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListTopMoversRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTopMoversRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type ListTopMoversResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WindowDays    int32                  `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Products      []*PriceMover          `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopMoversResponse) Reset() {
+	*x = ListTopMoversResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopMoversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopMoversResponse) ProtoMessage() {}
+
+func (x *ListTopMoversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopMoversResponse.ProtoReflect.Descriptor instead.
+func (*ListTopMoversResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTopMoversResponse) GetWindowDays() int32 {
+	if x != nil {
+		return x.WindowDays
+	}
+	return 0
+}
+
+func (x *ListTopMoversResponse) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ListTopMoversResponse) GetProducts() []*PriceMover {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type PriceMover struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductId  string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CategoryId string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Rank       int32                  `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Average variant price at the start of the window and now.
+	StartPrice    float32 `protobuf:"fixed32,4,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	CurrentPrice  float32 `protobuf:"fixed32,5,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	ChangePercent float32 `protobuf:"fixed32,6,opt,name=change_percent,json=changePercent,proto3" json:"change_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceMover) Reset() {
+	*x = PriceMover{}
+	mi := &file_proto_product_analysis_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceMover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceMover) ProtoMessage() {}
+
+func (x *PriceMover) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceMover.ProtoReflect.Descriptor instead.
+func (*PriceMover) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PriceMover) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceMover) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *PriceMover) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PriceMover) GetStartPrice() float32 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *PriceMover) GetCurrentPrice() float32 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *PriceMover) GetChangePercent() float32 {
+	if x != nil {
+		return x.ChangePercent
+	}
+	return 0
+}
+
+type GetPriceForecastRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Restricts the forecast to one variant. When empty every variant of the
+	// product is forecast.
+	VariantId string `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// Restricts the forecast to one seller. When empty every seller's price
+	// series is forecast separately.
+	SellerId      string `protobuf:"bytes,3,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceForecastRequest) Reset() {
+	*x = GetPriceForecastRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceForecastRequest) ProtoMessage() {}
+
+func (x *GetPriceForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceForecastRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetPriceForecastRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceForecastRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *GetPriceForecastRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type GetPriceForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Forecasts     []*VariantForecast     `protobuf:"bytes,1,rep,name=forecasts,proto3" json:"forecasts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceForecastResponse) Reset() {
+	*x = GetPriceForecastResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceForecastResponse) ProtoMessage() {}
+
+func (x *GetPriceForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceForecastResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetPriceForecastResponse) GetForecasts() []*VariantForecast {
+	if x != nil {
+		return x.Forecasts
+	}
+	return nil
+}
+
+type VariantForecast struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VariantId string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// The seller whose prices were forecast, empty when unknown.
+	SellerId string `protobuf:"bytes,7,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// The model that backtested best and produced the ranges:
+	// "seasonal_naive" or "exponential_smoothing". Empty when unavailable.
+	Model        string  `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	CurrentPrice float32 `protobuf:"fixed32,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	// Forecasts for the next 7 and 30 days.
+	Ranges []*ForecastRange `protobuf:"bytes,4,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	// Backtest metrics for every model considered.
+	Backtests []*BacktestMetrics `protobuf:"bytes,5,rep,name=backtests,proto3" json:"backtests,omitempty"`
+	// Set instead of the forecast when the variant cannot be forecast, for
+	// example because it has too little history.
+	UnavailableReason string `protobuf:"bytes,6,opt,name=unavailable_reason,json=unavailableReason,proto3" json:"unavailable_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VariantForecast) Reset() {
+	*x = VariantForecast{}
+	mi := &file_proto_product_analysis_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantForecast) ProtoMessage() {}
+
+func (x *VariantForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantForecast.ProtoReflect.Descriptor instead.
+func (*VariantForecast) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *VariantForecast) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *VariantForecast) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *VariantForecast) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *VariantForecast) GetCurrentPrice() float32 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *VariantForecast) GetRanges() []*ForecastRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *VariantForecast) GetBacktests() []*BacktestMetrics {
+	if x != nil {
+		return x.Backtests
+	}
+	return nil
+}
+
+func (x *VariantForecast) GetUnavailableReason() string {
+	if x != nil {
+		return x.UnavailableReason
+	}
+	return ""
+}
+
+type ForecastRange struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	HorizonDays int32                  `protobuf:"varint,1,opt,name=horizon_days,json=horizonDays,proto3" json:"horizon_days,omitempty"`
+	// Mean forecast price over the horizon.
+	ExpectedPrice float32 `protobuf:"fixed32,2,opt,name=expected_price,json=expectedPrice,proto3" json:"expected_price,omitempty"`
+	// 80% interval over the horizon.
+	LowPrice  float32 `protobuf:"fixed32,3,opt,name=low_price,json=lowPrice,proto3" json:"low_price,omitempty"`
+	HighPrice float32 `protobuf:"fixed32,4,opt,name=high_price,json=highPrice,proto3" json:"high_price,omitempty"`
+	// Chance the price falls at least 1% below the current price within the
+	// horizon.
+	DropProbability float32 `protobuf:"fixed32,5,opt,name=drop_probability,json=dropProbability,proto3" json:"drop_probability,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ForecastRange) Reset() {
+	*x = ForecastRange{}
+	mi := &file_proto_product_analysis_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRange) ProtoMessage() {}
+
+func (x *ForecastRange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRange.ProtoReflect.Descriptor instead.
+func (*ForecastRange) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ForecastRange) GetHorizonDays() int32 {
+	if x != nil {
+		return x.HorizonDays
+	}
+	return 0
+}
+
+func (x *ForecastRange) GetExpectedPrice() float32 {
+	if x != nil {
+		return x.ExpectedPrice
+	}
+	return 0
+}
+
+func (x *ForecastRange) GetLowPrice() float32 {
+	if x != nil {
+		return x.LowPrice
+	}
+	return 0
+}
+
+func (x *ForecastRange) GetHighPrice() float32 {
+	if x != nil {
+		return x.HighPrice
+	}
+	return 0
+}
+
+func (x *ForecastRange) GetDropProbability() float32 {
+	if x != nil {
+		return x.DropProbability
+	}
+	return 0
+}
+
+type BacktestMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Model string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Mae   float32                `protobuf:"fixed32,2,opt,name=mae,proto3" json:"mae,omitempty"`
+	Mape  float32                `protobuf:"fixed32,3,opt,name=mape,proto3" json:"mape,omitempty"`
+	// Share of actual prices inside the 80% interval.
+	IntervalCoverage float32 `protobuf:"fixed32,4,opt,name=interval_coverage,json=intervalCoverage,proto3" json:"interval_coverage,omitempty"`
+	Points           int32   `protobuf:"varint,5,opt,name=points,proto3" json:"points,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BacktestMetrics) Reset() {
+	*x = BacktestMetrics{}
+	mi := &file_proto_product_analysis_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestMetrics) ProtoMessage() {}
+
+func (x *BacktestMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestMetrics.ProtoReflect.Descriptor instead.
+func (*BacktestMetrics) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BacktestMetrics) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *BacktestMetrics) GetMae() float32 {
+	if x != nil {
+		return x.Mae
+	}
+	return 0
+}
+
+func (x *BacktestMetrics) GetMape() float32 {
+	if x != nil {
+		return x.Mape
+	}
+	return 0
+}
+
+func (x *BacktestMetrics) GetIntervalCoverage() float32 {
+	if x != nil {
+		return x.IntervalCoverage
+	}
+	return 0
+}
+
+func (x *BacktestMetrics) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type GetBuyBoxHistoryRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Restricts the history to one variant. When empty every variant of the
+	// product is included.
+	VariantId string `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// RFC3339 bounds of the history. to defaults to now and from to 30 days
+	// before to.
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuyBoxHistoryRequest) Reset() {
+	*x = GetBuyBoxHistoryRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuyBoxHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuyBoxHistoryRequest) ProtoMessage() {}
+
+func (x *GetBuyBoxHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuyBoxHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBuyBoxHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetBuyBoxHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetBuyBoxHistoryRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *GetBuyBoxHistoryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetBuyBoxHistoryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type GetBuyBoxHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*VariantBuyBox       `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuyBoxHistoryResponse) Reset() {
+	*x = GetBuyBoxHistoryResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuyBoxHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuyBoxHistoryResponse) ProtoMessage() {}
+
+func (x *GetBuyBoxHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuyBoxHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetBuyBoxHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetBuyBoxHistoryResponse) GetVariants() []*VariantBuyBox {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type VariantBuyBox struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	VariantId string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// The current holder, unset when no seller has the variant in stock.
+	Current *BuyBoxTenure `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	// Every tenure overlapping the requested range, oldest first.
+	Tenures []*BuyBoxTenure `protobuf:"bytes,3,rep,name=tenures,proto3" json:"tenures,omitempty"`
+	// Each seller's share of the range during which it held the buy box.
+	Shares        []*BuyBoxShare `protobuf:"bytes,4,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VariantBuyBox) Reset() {
+	*x = VariantBuyBox{}
+	mi := &file_proto_product_analysis_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VariantBuyBox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VariantBuyBox) ProtoMessage() {}
+
+func (x *VariantBuyBox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VariantBuyBox.ProtoReflect.Descriptor instead.
+func (*VariantBuyBox) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VariantBuyBox) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *VariantBuyBox) GetCurrent() *BuyBoxTenure {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *VariantBuyBox) GetTenures() []*BuyBoxTenure {
+	if x != nil {
+		return x.Tenures
+	}
+	return nil
+}
+
+func (x *VariantBuyBox) GetShares() []*BuyBoxShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type BuyBoxTenure struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SellerId string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Currency string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Exact decimal prices of the winning offer when it won.
+	Price        string `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	ShippingCost string `protobuf:"bytes,4,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	TotalPrice   string `protobuf:"bytes,5,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	OfferCount   int32  `protobuf:"varint,6,opt,name=offer_count,json=offerCount,proto3" json:"offer_count,omitempty"`
+	WonAt        string `protobuf:"bytes,7,opt,name=won_at,json=wonAt,proto3" json:"won_at,omitempty"`
+	// Empty while the seller still holds the buy box.
+	LostAt        string `protobuf:"bytes,8,opt,name=lost_at,json=lostAt,proto3" json:"lost_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyBoxTenure) Reset() {
+	*x = BuyBoxTenure{}
+	mi := &file_proto_product_analysis_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyBoxTenure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyBoxTenure) ProtoMessage() {}
+
+func (x *BuyBoxTenure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyBoxTenure.ProtoReflect.Descriptor instead.
+func (*BuyBoxTenure) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BuyBoxTenure) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetShippingCost() string {
+	if x != nil {
+		return x.ShippingCost
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetTotalPrice() string {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetOfferCount() int32 {
+	if x != nil {
+		return x.OfferCount
+	}
+	return 0
+}
+
+func (x *BuyBoxTenure) GetWonAt() string {
+	if x != nil {
+		return x.WonAt
+	}
+	return ""
+}
+
+func (x *BuyBoxTenure) GetLostAt() string {
+	if x != nil {
+		return x.LostAt
+	}
+	return ""
+}
+
+type BuyBoxShare struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	SellerId string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	// Fraction of the range, between 0 and 1.
+	Share         float32 `protobuf:"fixed32,2,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyBoxShare) Reset() {
+	*x = BuyBoxShare{}
+	mi := &file_proto_product_analysis_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyBoxShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyBoxShare) ProtoMessage() {}
+
+func (x *BuyBoxShare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyBoxShare.ProtoReflect.Descriptor instead.
+func (*BuyBoxShare) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BuyBoxShare) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *BuyBoxShare) GetShare() float32 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+type ListReviewsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Zero-based page of reviews, newest first.
+	Page    int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage int32 `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	// Only list reviews with this rating, 1 to 5. 0 lists every review.
+	Rating int32 `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	// Bucket of the rating trend: "week" or "month" (default).
+	TrendInterval string `protobuf:"bytes,5,opt,name=trend_interval,json=trendInterval,proto3" json:"trend_interval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListReviewsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetTrendInterval() string {
+	if x != nil {
+		return x.TrendInterval
+	}
+	return ""
+}
+
+type RatingCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        int32                  `protobuf:"varint,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingCount) Reset() {
+	*x = RatingCount{}
+	mi := &file_proto_product_analysis_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingCount) ProtoMessage() {}
+
+func (x *RatingCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingCount.ProtoReflect.Descriptor instead.
+func (*RatingCount) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RatingCount) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RatingCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type RatingTrendPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart   string                 `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	ReviewCount   int32                  `protobuf:"varint,2,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	AverageRating float32                `protobuf:"fixed32,3,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingTrendPoint) Reset() {
+	*x = RatingTrendPoint{}
+	mi := &file_proto_product_analysis_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingTrendPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingTrendPoint) ProtoMessage() {}
+
+func (x *RatingTrendPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingTrendPoint.ProtoReflect.Descriptor instead.
+func (*RatingTrendPoint) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RatingTrendPoint) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *RatingTrendPoint) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *RatingTrendPoint) GetAverageRating() float32 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+type ListReviewsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Reviews []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	// Reviews matching the rating filter.
+	Total int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// The remaining fields cover every review of the product, regardless of
+	// the rating filter.
+	ReviewCount   int32   `protobuf:"varint,3,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	AverageRating float32 `protobuf:"fixed32,4,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	// Review counts for each rating from 1 to 5.
+	Distribution []*RatingCount `protobuf:"bytes,5,rep,name=distribution,proto3" json:"distribution,omitempty"`
+	// Oldest period first; periods without reviews are omitted.
+	Trend         []*RatingTrendPoint `protobuf:"bytes,6,rep,name=trend,proto3" json:"trend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetAverageRating() float32 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *ListReviewsResponse) GetDistribution() []*RatingCount {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetTrend() []*RatingTrendPoint {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+// ReviewSentiment aggregates the offline sentiment analysis of a product's
+// or a brand's reviews. Scores are in (-1, 1).
+type ReviewSentiment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewCount   int32                  `protobuf:"varint,1,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	AverageScore  float32                `protobuf:"fixed32,2,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	PositiveCount int32                  `protobuf:"varint,3,opt,name=positive_count,json=positiveCount,proto3" json:"positive_count,omitempty"`
+	NeutralCount  int32                  `protobuf:"varint,4,opt,name=neutral_count,json=neutralCount,proto3" json:"neutral_count,omitempty"`
+	NegativeCount int32                  `protobuf:"varint,5,opt,name=negative_count,json=negativeCount,proto3" json:"negative_count,omitempty"`
+	// Aspects mentioned, most complained about first.
+	Aspects []*AspectSentiment `protobuf:"bytes,6,rep,name=aspects,proto3" json:"aspects,omitempty"`
+	// Most frequent review keywords, most frequent first.
+	Keywords      []*KeywordCount `protobuf:"bytes,7,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewSentiment) Reset() {
+	*x = ReviewSentiment{}
+	mi := &file_proto_product_analysis_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewSentiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewSentiment) ProtoMessage() {}
+
+func (x *ReviewSentiment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewSentiment.ProtoReflect.Descriptor instead.
+func (*ReviewSentiment) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReviewSentiment) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *ReviewSentiment) GetAverageScore() float32 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+func (x *ReviewSentiment) GetPositiveCount() int32 {
+	if x != nil {
+		return x.PositiveCount
+	}
+	return 0
+}
+
+func (x *ReviewSentiment) GetNeutralCount() int32 {
+	if x != nil {
+		return x.NeutralCount
+	}
+	return 0
+}
+
+func (x *ReviewSentiment) GetNegativeCount() int32 {
+	if x != nil {
+		return x.NegativeCount
+	}
+	return 0
+}
+
+func (x *ReviewSentiment) GetAspects() []*AspectSentiment {
+	if x != nil {
+		return x.Aspects
+	}
+	return nil
+}
+
+func (x *ReviewSentiment) GetKeywords() []*KeywordCount {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+type AspectSentiment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of sizing, shipping, quality, price, packaging or service.
+	Aspect        string  `protobuf:"bytes,1,opt,name=aspect,proto3" json:"aspect,omitempty"`
+	MentionCount  int32   `protobuf:"varint,2,opt,name=mention_count,json=mentionCount,proto3" json:"mention_count,omitempty"`
+	NegativeCount int32   `protobuf:"varint,3,opt,name=negative_count,json=negativeCount,proto3" json:"negative_count,omitempty"`
+	AverageScore  float32 `protobuf:"fixed32,4,opt,name=average_score,json=averageScore,proto3" json:"average_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AspectSentiment) Reset() {
+	*x = AspectSentiment{}
+	mi := &file_proto_product_analysis_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AspectSentiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AspectSentiment) ProtoMessage() {}
+
+func (x *AspectSentiment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AspectSentiment.ProtoReflect.Descriptor instead.
+func (*AspectSentiment) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AspectSentiment) GetAspect() string {
+	if x != nil {
+		return x.Aspect
+	}
+	return ""
+}
+
+func (x *AspectSentiment) GetMentionCount() int32 {
+	if x != nil {
+		return x.MentionCount
+	}
+	return 0
+}
+
+func (x *AspectSentiment) GetNegativeCount() int32 {
+	if x != nil {
+		return x.NegativeCount
+	}
+	return 0
+}
+
+func (x *AspectSentiment) GetAverageScore() float32 {
+	if x != nil {
+		return x.AverageScore
+	}
+	return 0
+}
+
+type KeywordCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keyword       string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeywordCount) Reset() {
+	*x = KeywordCount{}
+	mi := &file_proto_product_analysis_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeywordCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeywordCount) ProtoMessage() {}
+
+func (x *KeywordCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeywordCount.ProtoReflect.Descriptor instead.
+func (*KeywordCount) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *KeywordCount) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *KeywordCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetBrandSentimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrandId       string                 `protobuf:"bytes,1,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrandSentimentRequest) Reset() {
+	*x = GetBrandSentimentRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrandSentimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrandSentimentRequest) ProtoMessage() {}
+
+func (x *GetBrandSentimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrandSentimentRequest.ProtoReflect.Descriptor instead.
+func (*GetBrandSentimentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetBrandSentimentRequest) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+type GetBrandSentimentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BrandId       string                 `protobuf:"bytes,1,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	Sentiment     *ReviewSentiment       `protobuf:"bytes,2,opt,name=sentiment,proto3" json:"sentiment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrandSentimentResponse) Reset() {
+	*x = GetBrandSentimentResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrandSentimentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrandSentimentResponse) ProtoMessage() {}
+
+func (x *GetBrandSentimentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrandSentimentResponse.ProtoReflect.Descriptor instead.
+func (*GetBrandSentimentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetBrandSentimentResponse) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *GetBrandSentimentResponse) GetSentiment() *ReviewSentiment {
+	if x != nil {
+		return x.Sentiment
+	}
+	return nil
+}
+
+type GetSimilarProductsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Defaults to 10, at most 50.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only return neighbours priced below the product.
+	CheaperOnly   bool `protobuf:"varint,3,opt,name=cheaper_only,json=cheaperOnly,proto3" json:"cheaper_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarProductsRequest) Reset() {
+	*x = GetSimilarProductsRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarProductsRequest) ProtoMessage() {}
+
+func (x *GetSimilarProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetSimilarProductsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetSimilarProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetSimilarProductsRequest) GetCheaperOnly() bool {
+	if x != nil {
+		return x.CheaperOnly
+	}
+	return false
+}
+
+type SimilarProduct struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// "crawled" for products the site lists as similar, "computed" for
+	// products found by shared category, attributes and price band.
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// Computed similarity in [0, 1]. Crawled neighbours outside the
+	// product's category or price band score 0.
+	Score float32 `protobuf:"fixed32,4,opt,name=score,proto3" json:"score,omitempty"`
+	// Lowest in-stock offer price, as an exact decimal. Empty when the
+	// neighbour has no in-stock offer.
+	Price    string `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Currency string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Neighbour price minus the product's price. Empty unless both are known
+	// in the same currency.
+	PriceDifference        string  `protobuf:"bytes,7,opt,name=price_difference,json=priceDifference,proto3" json:"price_difference,omitempty"`
+	PriceDifferencePercent float32 `protobuf:"fixed32,8,opt,name=price_difference_percent,json=priceDifferencePercent,proto3" json:"price_difference_percent,omitempty"`
+	Cheaper                bool    `protobuf:"varint,9,opt,name=cheaper,proto3" json:"cheaper,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SimilarProduct) Reset() {
+	*x = SimilarProduct{}
+	mi := &file_proto_product_analysis_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarProduct) ProtoMessage() {}
+
+func (x *SimilarProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarProduct.ProtoReflect.Descriptor instead.
+func (*SimilarProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SimilarProduct) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SimilarProduct) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetPriceDifference() string {
+	if x != nil {
+		return x.PriceDifference
+	}
+	return ""
+}
+
+func (x *SimilarProduct) GetPriceDifferencePercent() float32 {
+	if x != nil {
+		return x.PriceDifferencePercent
+	}
+	return 0
+}
+
+func (x *SimilarProduct) GetCheaper() bool {
+	if x != nil {
+		return x.Cheaper
+	}
+	return false
+}
+
+type GetSimilarProductsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The product's lowest in-stock offer price and its currency.
+	Price    string `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Crawled neighbours first, then computed ones, each most similar first.
+	Similar       []*SimilarProduct `protobuf:"bytes,3,rep,name=similar,proto3" json:"similar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarProductsResponse) Reset() {
+	*x = GetSimilarProductsResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarProductsResponse) ProtoMessage() {}
+
+func (x *GetSimilarProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetSimilarProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetSimilarProductsResponse) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *GetSimilarProductsResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetSimilarProductsResponse) GetSimilar() []*SimilarProduct {
+	if x != nil {
+		return x.Similar
+	}
+	return nil
+}
+
+type GetCanonicalProductRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CanonicalProductId string                 `protobuf:"bytes,1,opt,name=canonical_product_id,json=canonicalProductId,proto3" json:"canonical_product_id,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetCanonicalProductRequest) Reset() {
+	*x = GetCanonicalProductRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductAnalyticsResponse) String() string {
+func (x *GetCanonicalProductRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductAnalyticsResponse) ProtoMessage() {}
+func (*GetCanonicalProductRequest) ProtoMessage() {}
 
-func (x *GetProductAnalyticsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[12]
+func (x *GetCanonicalProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -865,71 +4005,149 @@ func (x *GetProductAnalyticsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductAnalyticsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductAnalyticsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetCanonicalProductRequest.ProtoReflect.Descriptor instead.
+func (*GetCanonicalProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *GetProductAnalyticsResponse) GetPriceTrend() float32 {
+func (x *GetCanonicalProductRequest) GetCanonicalProductId() string {
 	if x != nil {
-		return x.PriceTrend
+		return x.CanonicalProductId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetProductAnalyticsResponse) GetStockTrend() float32 {
+type ConfirmCanonicalProductRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CanonicalProductId string                 `protobuf:"bytes,1,opt,name=canonical_product_id,json=canonicalProductId,proto3" json:"canonical_product_id,omitempty"`
+	// Products to lock into the canonical product, moving them from their
+	// current one if needed. When empty its current listings are locked.
+	ProductIds    []string `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmCanonicalProductRequest) Reset() {
+	*x = ConfirmCanonicalProductRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmCanonicalProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmCanonicalProductRequest) ProtoMessage() {}
+
+func (x *ConfirmCanonicalProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[53]
 	if x != nil {
-		return x.StockTrend
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GetProductAnalyticsResponse) GetFavoriteCountTrend() int32 {
+// Deprecated: Use ConfirmCanonicalProductRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmCanonicalProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ConfirmCanonicalProductRequest) GetCanonicalProductId() string {
 	if x != nil {
-		return x.FavoriteCountTrend
+		return x.CanonicalProductId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetProductAnalyticsResponse) GetPopularityScore() float32 {
+func (x *ConfirmCanonicalProductRequest) GetProductIds() []string {
 	if x != nil {
-		return x.PopularityScore
+		return x.ProductIds
 	}
-	return 0
+	return nil
 }
 
-func (x *GetProductAnalyticsResponse) GetPriceHistory() []*PriceHistory {
+type SplitCanonicalProductRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	CanonicalProductId string                 `protobuf:"bytes,1,opt,name=canonical_product_id,json=canonicalProductId,proto3" json:"canonical_product_id,omitempty"`
+	// Listings of the canonical product to move into a new one. Both groups
+	// are locked afterwards.
+	ProductIds    []string `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitCanonicalProductRequest) Reset() {
+	*x = SplitCanonicalProductRequest{}
+	mi := &file_proto_product_analysis_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitCanonicalProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitCanonicalProductRequest) ProtoMessage() {}
+
+func (x *SplitCanonicalProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[54]
 	if x != nil {
-		return x.PriceHistory
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitCanonicalProductRequest.ProtoReflect.Descriptor instead.
+func (*SplitCanonicalProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SplitCanonicalProductRequest) GetCanonicalProductId() string {
+	if x != nil {
+		return x.CanonicalProductId
+	}
+	return ""
+}
+
+func (x *SplitCanonicalProductRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
 	}
 	return nil
 }
 
-type PriceHistory struct {
+type SplitCanonicalProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VariantId     string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
-	OldPrice      float32                `protobuf:"fixed32,2,opt,name=old_price,json=oldPrice,proto3" json:"old_price,omitempty"`
-	NewPrice      float32                `protobuf:"fixed32,3,opt,name=new_price,json=newPrice,proto3" json:"new_price,omitempty"`
-	ChangedAt     string                 `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Remaining     *CanonicalProduct      `protobuf:"bytes,1,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Split         *CanonicalProduct      `protobuf:"bytes,2,opt,name=split,proto3" json:"split,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PriceHistory) Reset() {
-	*x = PriceHistory{}
-	mi := &file_proto_product_analysis_proto_msgTypes[13]
+func (x *SplitCanonicalProductResponse) Reset() {
+	*x = SplitCanonicalProductResponse{}
+	mi := &file_proto_product_analysis_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PriceHistory) String() string {
+func (x *SplitCanonicalProductResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PriceHistory) ProtoMessage() {}
+func (*SplitCanonicalProductResponse) ProtoMessage() {}
 
-func (x *PriceHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_analysis_proto_msgTypes[13]
+func (x *SplitCanonicalProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -940,35 +4158,219 @@ func (x *PriceHistory) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PriceHistory.ProtoReflect.Descriptor instead.
-func (*PriceHistory) Descriptor() ([]byte, []int) {
-	return file_proto_product_analysis_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SplitCanonicalProductResponse.ProtoReflect.Descriptor instead.
+func (*SplitCanonicalProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *PriceHistory) GetVariantId() string {
+func (x *SplitCanonicalProductResponse) GetRemaining() *CanonicalProduct {
 	if x != nil {
-		return x.VariantId
+		return x.Remaining
+	}
+	return nil
+}
+
+func (x *SplitCanonicalProductResponse) GetSplit() *CanonicalProduct {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+type CanonicalProduct struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BrandId  string                 `protobuf:"bytes,3,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	Listings []*CanonicalListing    `protobuf:"bytes,4,rep,name=listings,proto3" json:"listings,omitempty"`
+	// Lowest in-stock offer price across the listings, as an exact decimal,
+	// and the listing offering it. Empty when no listing is in stock.
+	Price             string `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Currency          string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	CheapestProductId string `protobuf:"bytes,7,opt,name=cheapest_product_id,json=cheapestProductId,proto3" json:"cheapest_product_id,omitempty"`
+	// Sentiment of the reviews of every listing.
+	ReviewSentiment *ReviewSentiment `protobuf:"bytes,8,opt,name=review_sentiment,json=reviewSentiment,proto3" json:"review_sentiment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CanonicalProduct) Reset() {
+	*x = CanonicalProduct{}
+	mi := &file_proto_product_analysis_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CanonicalProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanonicalProduct) ProtoMessage() {}
+
+func (x *CanonicalProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanonicalProduct.ProtoReflect.Descriptor instead.
+func (*CanonicalProduct) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CanonicalProduct) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PriceHistory) GetOldPrice() float32 {
+func (x *CanonicalProduct) GetName() string {
 	if x != nil {
-		return x.OldPrice
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *PriceHistory) GetNewPrice() float32 {
+func (x *CanonicalProduct) GetBrandId() string {
 	if x != nil {
-		return x.NewPrice
+		return x.BrandId
 	}
-	return 0
+	return ""
 }
 
-func (x *PriceHistory) GetChangedAt() string {
+func (x *CanonicalProduct) GetListings() []*CanonicalListing {
 	if x != nil {
-		return x.ChangedAt
+		return x.Listings
+	}
+	return nil
+}
+
+func (x *CanonicalProduct) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CanonicalProduct) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CanonicalProduct) GetCheapestProductId() string {
+	if x != nil {
+		return x.CheapestProductId
+	}
+	return ""
+}
+
+func (x *CanonicalProduct) GetReviewSentiment() *ReviewSentiment {
+	if x != nil {
+		return x.ReviewSentiment
+	}
+	return nil
+}
+
+type CanonicalListing struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductId  string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ExternalId string                 `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Set for listings confirmed or split by hand, which the matcher never
+	// moves.
+	Locked    bool   `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`
+	MatchedAt string `protobuf:"bytes,5,opt,name=matched_at,json=matchedAt,proto3" json:"matched_at,omitempty"`
+	// Lowest in-stock offer price of the listing.
+	Price         string `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	Currency      string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CanonicalListing) Reset() {
+	*x = CanonicalListing{}
+	mi := &file_proto_product_analysis_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CanonicalListing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanonicalListing) ProtoMessage() {}
+
+func (x *CanonicalListing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_analysis_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanonicalListing.ProtoReflect.Descriptor instead.
+func (*CanonicalListing) Descriptor() ([]byte, []int) {
+	return file_proto_product_analysis_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CanonicalListing) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CanonicalListing) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *CanonicalListing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CanonicalListing) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *CanonicalListing) GetMatchedAt() string {
+	if x != nil {
+		return x.MatchedAt
+	}
+	return ""
+}
+
+func (x *CanonicalListing) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *CanonicalListing) GetCurrency() string {
+	if x != nil {
+		return x.Currency
 	}
 	return ""
 }
@@ -980,7 +4382,7 @@ const file_proto_product_analysis_proto_rawDesc = "" +
 	"\x1cproto/product_analysis.proto\x12\x10product_analysis\"\x0f\n" +
 	"\rHealthRequest\"(\n" +
 	"\x0eHealthResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"\xa8\x06\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xff\a\n" +
 	"\vProductData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1008,7 +4410,27 @@ const file_proto_product_analysis_proto_rawDesc = "" +
 	"attributes\x12.\n" +
 	"\x13similar_product_ids\x18\x13 \x03(\tR\x11similarProductIds\x129\n" +
 	"\vtop_reviews\x18\x14 \x03(\v2\x18.product_analysis.ReviewR\n" +
-	"topReviews\"\xdd\x01\n" +
+	"topReviews\x12\x1f\n" +
+	"\vseller_name\x18\x15 \x01(\tR\n" +
+	"sellerName\x12\x1a\n" +
+	"\bcurrency\x18\x16 \x01(\tR\bcurrency\x12#\n" +
+	"\rshipping_cost\x18\x17 \x01(\tR\fshippingCost\x12/\n" +
+	"\x06offers\x18\x18 \x03(\v2\x17.product_analysis.OfferR\x06offers\x12\x1d\n" +
+	"\n" +
+	"brand_name\x18\x19 \x01(\tR\tbrandName\x12#\n" +
+	"\rseller_rating\x18\x1a \x01(\x02R\fsellerRating\"\x94\x02\n" +
+	"\x05Offer\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x1f\n" +
+	"\vseller_name\x18\x03 \x01(\tR\n" +
+	"sellerName\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\x05 \x01(\tR\tlistPrice\x12%\n" +
+	"\x0estock_quantity\x18\x06 \x01(\x05R\rstockQuantity\x12#\n" +
+	"\rshipping_cost\x18\a \x01(\tR\fshippingCost\x12-\n" +
+	"\x12estimated_delivery\x18\b \x01(\tR\x11estimatedDelivery\"\xde\x02\n" +
 	"\x0eProductVariant\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
 	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x14\n" +
@@ -1017,7 +4439,16 @@ const file_proto_product_analysis_proto_rawDesc = "" +
 	"\x05price\x18\x05 \x01(\x02R\x05price\x12%\n" +
 	"\x0eoriginal_price\x18\x06 \x01(\x02R\roriginalPrice\x12%\n" +
 	"\x0estock_quantity\x18\a \x01(\x05R\rstockQuantity\x12\x1b\n" +
-	"\tis_active\x18\b \x01(\bR\bisActive\"Z\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\t \x01(\tR\tlistPrice\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\n" +
+	" \x01(\tR\tsalePrice\x12!\n" +
+	"\fbasket_price\x18\v \x01(\tR\vbasketPrice\x12\x1e\n" +
+	"\n" +
+	"promotions\x18\f \x03(\tR\n" +
+	"promotions\"Z\n" +
 	"\fProductImage\x12\x10\n" +
 	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1d\n" +
 	"\n" +
@@ -1038,16 +4469,33 @@ const file_proto_product_analysis_proto_rawDesc = "" +
 	"\aproduct\x18\x01 \x01(\v2\x1d.product_analysis.ProductDataR\aproduct\"V\n" +
 	"\x16AnalyzeProductResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12$\n" +
-	"\rnotifications\x18\x02 \x03(\tR\rnotifications\"`\n" +
+	"\rnotifications\x18\x02 \x03(\tR\rnotifications\"X\n" +
+	"\x1bAnalyzeProductsBatchRequest\x129\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1d.product_analysis.ProductDataR\bproducts\"\xa8\x01\n" +
+	"\x14AnalyzeProductResult\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12$\n" +
+	"\rnotifications\x18\x03 \x03(\tR\rnotifications\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x91\x01\n" +
+	"\x17AnalyzeProductsResponse\x12@\n" +
+	"\aresults\x18\x01 \x03(\v2&.product_analysis.AnalyzeProductResultR\aresults\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"d\n" +
 	"\x1cUpdateProductPriorityRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
-	"\fis_favorited\x18\x02 \x01(\bR\visFavorited\"7\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12%\n" +
+	"\fis_favorited\x18\x02 \x01(\bB\x02\x18\x01R\visFavorited\"7\n" +
 	"\x1dUpdateProductPriorityResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\";\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"y\n" +
 	"\x1aGetProductAnalyticsRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\tR\tproductId\"\x81\x02\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vwindow_days\x18\x02 \x01(\x05R\n" +
+	"windowDays\x12\x1b\n" +
+	"\tseller_id\x18\x03 \x01(\tR\bsellerId\"\x8c\x06\n" +
 	"\x1bGetProductAnalyticsResponse\x12\x1f\n" +
 	"\vprice_trend\x18\x01 \x01(\x02R\n" +
 	"priceTrend\x12\x1f\n" +
@@ -1055,19 +4503,304 @@ const file_proto_product_analysis_proto_rawDesc = "" +
 	"stockTrend\x120\n" +
 	"\x14favorite_count_trend\x18\x03 \x01(\x05R\x12favoriteCountTrend\x12)\n" +
 	"\x10popularity_score\x18\x04 \x01(\x02R\x0fpopularityScore\x12C\n" +
-	"\rprice_history\x18\x05 \x03(\v2\x1e.product_analysis.PriceHistoryR\fpriceHistory\"\x86\x01\n" +
+	"\rprice_history\x18\x05 \x03(\v2\x1e.product_analysis.PriceHistoryR\fpriceHistory\x12\x1f\n" +
+	"\vwindow_days\x18\x06 \x01(\x05R\n" +
+	"windowDays\x12B\n" +
+	"\rprice_changes\x18\a \x03(\v2\x1d.product_analysis.PriceChangeR\fpriceChanges\x12\x1b\n" +
+	"\tmin_price\x18\b \x01(\x02R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\t \x01(\x02R\bmaxPrice\x12\x1b\n" +
+	"\tavg_price\x18\n" +
+	" \x01(\x02R\bavgPrice\x12%\n" +
+	"\x0estock_velocity\x18\v \x01(\x02R\rstockVelocity\x120\n" +
+	"\x14favorite_growth_rate\x18\f \x01(\x02R\x12favoriteGrowthRate\x126\n" +
+	"\x06scores\x18\r \x03(\v2\x1e.product_analysis.ProductScoreR\x06scores\x12<\n" +
+	"\tanomalies\x18\x0e \x03(\v2\x1e.product_analysis.PriceAnomalyR\tanomalies\x12L\n" +
+	"\x10review_sentiment\x18\x0f \x01(\v2!.product_analysis.ReviewSentimentR\x0freviewSentiment\x120\n" +
+	"\x14canonical_product_id\x18\x10 \x01(\tR\x12canonicalProductId\"\x8c\x02\n" +
+	"\fPriceAnomaly\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x02R\x05score\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x02R\x05price\x12%\n" +
+	"\x0eoriginal_price\x18\x06 \x01(\x02R\roriginalPrice\x12\x1f\n" +
+	"\vdetected_at\x18\a \x01(\tR\n" +
+	"detectedAt\x12 \n" +
+	"\flast_seen_at\x18\b \x01(\tR\n" +
+	"lastSeenAt\x12\x1b\n" +
+	"\tseller_id\x18\t \x01(\tR\bsellerId\"\x82\x01\n" +
+	"\fProductScore\x12\x16\n" +
+	"\x06scorer\x18\x01 \x01(\tR\x06scorer\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\x12#\n" +
+	"\rcategory_rank\x18\x03 \x01(\x05R\fcategoryRank\x12\x1f\n" +
+	"\vcomputed_at\x18\x04 \x01(\tR\n" +
+	"computedAt\"U\n" +
+	"\vPriceChange\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\x05R\n" +
+	"windowDays\x12%\n" +
+	"\x0echange_percent\x18\x02 \x01(\x02R\rchangePercent\"\xe6\x02\n" +
 	"\fPriceHistory\x12\x1d\n" +
 	"\n" +
 	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x1b\n" +
 	"\told_price\x18\x02 \x01(\x02R\boldPrice\x12\x1b\n" +
 	"\tnew_price\x18\x03 \x01(\x02R\bnewPrice\x12\x1d\n" +
 	"\n" +
-	"changed_at\x18\x04 \x01(\tR\tchangedAt2\xc0\x03\n" +
+	"changed_at\x18\x04 \x01(\tR\tchangedAt\x12\x1b\n" +
+	"\tseller_id\x18\x05 \x01(\tR\bsellerId\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12$\n" +
+	"\x0eold_sale_price\x18\a \x01(\tR\foldSalePrice\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\b \x01(\tR\tsalePrice\x12\x1d\n" +
+	"\n" +
+	"list_price\x18\t \x01(\tR\tlistPrice\x12!\n" +
+	"\fbasket_price\x18\n" +
+	" \x01(\tR\vbasketPrice\x12\x1e\n" +
+	"\n" +
+	"promotions\x18\v \x03(\tR\n" +
+	"promotions\"\x7f\n" +
+	"\x1aGetEngagementSeriesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x04 \x01(\tR\n" +
+	"resolution\"x\n" +
+	"\x1bGetEngagementSeriesResponse\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x01 \x01(\tR\n" +
+	"resolution\x129\n" +
+	"\x06points\x18\x02 \x03(\v2!.product_analysis.EngagementPointR\x06points\"\xc4\x01\n" +
+	"\x0fEngagementPoint\x12\x1f\n" +
+	"\vcaptured_at\x18\x01 \x01(\tR\n" +
+	"capturedAt\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x02 \x01(\x05R\tviewCount\x12%\n" +
+	"\x0efavorite_count\x18\x03 \x01(\x05R\rfavoriteCount\x12)\n" +
+	"\x11add_to_cart_count\x18\x04 \x01(\x05R\x0eaddToCartCount\x12\x1f\n" +
+	"\vorder_count\x18\x05 \x01(\x05R\n" +
+	"orderCount\"\x85\x01\n" +
+	"\x13ListTrendingRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x1f\n" +
+	"\vwindow_days\x18\x02 \x01(\x05R\n" +
+	"windowDays\x12\x16\n" +
+	"\x06metric\x18\x03 \x01(\tR\x06metric\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x8e\x01\n" +
+	"\x14ListTrendingResponse\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\x05R\n" +
+	"windowDays\x12\x16\n" +
+	"\x06metric\x18\x02 \x01(\tR\x06metric\x12=\n" +
+	"\bproducts\x18\x03 \x03(\v2!.product_analysis.TrendingProductR\bproducts\"\xa6\x02\n" +
+	"\x0fTrendingProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x05R\x04rank\x12\x1f\n" +
+	"\vview_growth\x18\x04 \x01(\x05R\n" +
+	"viewGrowth\x12'\n" +
+	"\x0ffavorite_growth\x18\x05 \x01(\x05R\x0efavoriteGrowth\x12+\n" +
+	"\x12add_to_cart_growth\x18\x06 \x01(\x05R\x0faddToCartGrowth\x12!\n" +
+	"\forder_growth\x18\a \x01(\x05R\vorderGrowth\x12%\n" +
+	"\x0egrowth_percent\x18\b \x01(\x02R\rgrowthPercent\"\xa9\x01\n" +
+	"\x14ListTopMoversRequest\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\tR\n" +
+	"categoryId\x12\x1f\n" +
+	"\vwindow_days\x18\x02 \x01(\x05R\n" +
+	"windowDays\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tseller_id\x18\x05 \x01(\tR\bsellerId\"\x90\x01\n" +
+	"\x15ListTopMoversResponse\x12\x1f\n" +
+	"\vwindow_days\x18\x01 \x01(\x05R\n" +
+	"windowDays\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x128\n" +
+	"\bproducts\x18\x03 \x03(\v2\x1c.product_analysis.PriceMoverR\bproducts\"\xcd\x01\n" +
+	"\n" +
+	"PriceMover\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x05R\x04rank\x12\x1f\n" +
+	"\vstart_price\x18\x04 \x01(\x02R\n" +
+	"startPrice\x12#\n" +
+	"\rcurrent_price\x18\x05 \x01(\x02R\fcurrentPrice\x12%\n" +
+	"\x0echange_percent\x18\x06 \x01(\x02R\rchangePercent\"t\n" +
+	"\x17GetPriceForecastRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\x12\x1b\n" +
+	"\tseller_id\x18\x03 \x01(\tR\bsellerId\"[\n" +
+	"\x18GetPriceForecastResponse\x12?\n" +
+	"\tforecasts\x18\x01 \x03(\v2!.product_analysis.VariantForecastR\tforecasts\"\xb1\x02\n" +
+	"\x0fVariantForecast\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12\x1b\n" +
+	"\tseller_id\x18\a \x01(\tR\bsellerId\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12#\n" +
+	"\rcurrent_price\x18\x03 \x01(\x02R\fcurrentPrice\x127\n" +
+	"\x06ranges\x18\x04 \x03(\v2\x1f.product_analysis.ForecastRangeR\x06ranges\x12?\n" +
+	"\tbacktests\x18\x05 \x03(\v2!.product_analysis.BacktestMetricsR\tbacktests\x12-\n" +
+	"\x12unavailable_reason\x18\x06 \x01(\tR\x11unavailableReason\"\xc0\x01\n" +
+	"\rForecastRange\x12!\n" +
+	"\fhorizon_days\x18\x01 \x01(\x05R\vhorizonDays\x12%\n" +
+	"\x0eexpected_price\x18\x02 \x01(\x02R\rexpectedPrice\x12\x1b\n" +
+	"\tlow_price\x18\x03 \x01(\x02R\blowPrice\x12\x1d\n" +
+	"\n" +
+	"high_price\x18\x04 \x01(\x02R\thighPrice\x12)\n" +
+	"\x10drop_probability\x18\x05 \x01(\x02R\x0fdropProbability\"\x92\x01\n" +
+	"\x0fBacktestMetrics\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x10\n" +
+	"\x03mae\x18\x02 \x01(\x02R\x03mae\x12\x12\n" +
+	"\x04mape\x18\x03 \x01(\x02R\x04mape\x12+\n" +
+	"\x11interval_coverage\x18\x04 \x01(\x02R\x10intervalCoverage\x12\x16\n" +
+	"\x06points\x18\x05 \x01(\x05R\x06points\"{\n" +
+	"\x17GetBuyBoxHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"W\n" +
+	"\x18GetBuyBoxHistoryResponse\x12;\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1f.product_analysis.VariantBuyBoxR\bvariants\"\xd9\x01\n" +
+	"\rVariantBuyBox\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x128\n" +
+	"\acurrent\x18\x02 \x01(\v2\x1e.product_analysis.BuyBoxTenureR\acurrent\x128\n" +
+	"\atenures\x18\x03 \x03(\v2\x1e.product_analysis.BuyBoxTenureR\atenures\x125\n" +
+	"\x06shares\x18\x04 \x03(\v2\x1d.product_analysis.BuyBoxShareR\x06shares\"\xf4\x01\n" +
+	"\fBuyBoxTenure\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\tR\x05price\x12#\n" +
+	"\rshipping_cost\x18\x04 \x01(\tR\fshippingCost\x12\x1f\n" +
+	"\vtotal_price\x18\x05 \x01(\tR\n" +
+	"totalPrice\x12\x1f\n" +
+	"\voffer_count\x18\x06 \x01(\x05R\n" +
+	"offerCount\x12\x15\n" +
+	"\x06won_at\x18\a \x01(\tR\x05wonAt\x12\x17\n" +
+	"\alost_at\x18\b \x01(\tR\x06lostAt\"@\n" +
+	"\vBuyBoxShare\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x14\n" +
+	"\x05share\x18\x02 \x01(\x02R\x05share\"\xa1\x01\n" +
+	"\x12ListReviewsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\x05R\aperPage\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12%\n" +
+	"\x0etrend_interval\x18\x05 \x01(\tR\rtrendInterval\";\n" +
+	"\vRatingCount\x12\x16\n" +
+	"\x06rating\x18\x01 \x01(\x05R\x06rating\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x7f\n" +
+	"\x10RatingTrendPoint\x12!\n" +
+	"\fperiod_start\x18\x01 \x01(\tR\vperiodStart\x12!\n" +
+	"\freview_count\x18\x02 \x01(\x05R\vreviewCount\x12%\n" +
+	"\x0eaverage_rating\x18\x03 \x01(\x02R\raverageRating\"\xa6\x02\n" +
+	"\x13ListReviewsResponse\x122\n" +
+	"\areviews\x18\x01 \x03(\v2\x18.product_analysis.ReviewR\areviews\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12!\n" +
+	"\freview_count\x18\x03 \x01(\x05R\vreviewCount\x12%\n" +
+	"\x0eaverage_rating\x18\x04 \x01(\x02R\raverageRating\x12A\n" +
+	"\fdistribution\x18\x05 \x03(\v2\x1d.product_analysis.RatingCountR\fdistribution\x128\n" +
+	"\x05trend\x18\x06 \x03(\v2\".product_analysis.RatingTrendPointR\x05trend\"\xc5\x02\n" +
+	"\x0fReviewSentiment\x12!\n" +
+	"\freview_count\x18\x01 \x01(\x05R\vreviewCount\x12#\n" +
+	"\raverage_score\x18\x02 \x01(\x02R\faverageScore\x12%\n" +
+	"\x0epositive_count\x18\x03 \x01(\x05R\rpositiveCount\x12#\n" +
+	"\rneutral_count\x18\x04 \x01(\x05R\fneutralCount\x12%\n" +
+	"\x0enegative_count\x18\x05 \x01(\x05R\rnegativeCount\x12;\n" +
+	"\aaspects\x18\x06 \x03(\v2!.product_analysis.AspectSentimentR\aaspects\x12:\n" +
+	"\bkeywords\x18\a \x03(\v2\x1e.product_analysis.KeywordCountR\bkeywords\"\x9a\x01\n" +
+	"\x0fAspectSentiment\x12\x16\n" +
+	"\x06aspect\x18\x01 \x01(\tR\x06aspect\x12#\n" +
+	"\rmention_count\x18\x02 \x01(\x05R\fmentionCount\x12%\n" +
+	"\x0enegative_count\x18\x03 \x01(\x05R\rnegativeCount\x12#\n" +
+	"\raverage_score\x18\x04 \x01(\x02R\faverageScore\">\n" +
+	"\fKeywordCount\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"5\n" +
+	"\x18GetBrandSentimentRequest\x12\x19\n" +
+	"\bbrand_id\x18\x01 \x01(\tR\abrandId\"w\n" +
+	"\x19GetBrandSentimentResponse\x12\x19\n" +
+	"\bbrand_id\x18\x01 \x01(\tR\abrandId\x12?\n" +
+	"\tsentiment\x18\x02 \x01(\v2!.product_analysis.ReviewSentimentR\tsentiment\"s\n" +
+	"\x19GetSimilarProductsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12!\n" +
+	"\fcheaper_only\x18\x03 \x01(\bR\vcheaperOnly\"\xa2\x02\n" +
+	"\x0eSimilarProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x02R\x05score\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12)\n" +
+	"\x10price_difference\x18\a \x01(\tR\x0fpriceDifference\x128\n" +
+	"\x18price_difference_percent\x18\b \x01(\x02R\x16priceDifferencePercent\x12\x18\n" +
+	"\acheaper\x18\t \x01(\bR\acheaper\"\x8a\x01\n" +
+	"\x1aGetSimilarProductsResponse\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12:\n" +
+	"\asimilar\x18\x03 \x03(\v2 .product_analysis.SimilarProductR\asimilar\"N\n" +
+	"\x1aGetCanonicalProductRequest\x120\n" +
+	"\x14canonical_product_id\x18\x01 \x01(\tR\x12canonicalProductId\"s\n" +
+	"\x1eConfirmCanonicalProductRequest\x120\n" +
+	"\x14canonical_product_id\x18\x01 \x01(\tR\x12canonicalProductId\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\tR\n" +
+	"productIds\"q\n" +
+	"\x1cSplitCanonicalProductRequest\x120\n" +
+	"\x14canonical_product_id\x18\x01 \x01(\tR\x12canonicalProductId\x12\x1f\n" +
+	"\vproduct_ids\x18\x02 \x03(\tR\n" +
+	"productIds\"\x9b\x01\n" +
+	"\x1dSplitCanonicalProductResponse\x12@\n" +
+	"\tremaining\x18\x01 \x01(\v2\".product_analysis.CanonicalProductR\tremaining\x128\n" +
+	"\x05split\x18\x02 \x01(\v2\".product_analysis.CanonicalProductR\x05split\"\xc1\x02\n" +
+	"\x10CanonicalProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bbrand_id\x18\x03 \x01(\tR\abrandId\x12>\n" +
+	"\blistings\x18\x04 \x03(\v2\".product_analysis.CanonicalListingR\blistings\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12.\n" +
+	"\x13cheapest_product_id\x18\a \x01(\tR\x11cheapestProductId\x12L\n" +
+	"\x10review_sentiment\x18\b \x01(\v2!.product_analysis.ReviewSentimentR\x0freviewSentiment\"\xcf\x01\n" +
+	"\x10CanonicalListing\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vexternal_id\x18\x02 \x01(\tR\n" +
+	"externalId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06locked\x18\x04 \x01(\bR\x06locked\x12\x1d\n" +
+	"\n" +
+	"matched_at\x18\x05 \x01(\tR\tmatchedAt\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\tR\x05price\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency2\xcf\x0e\n" +
 	"\x16ProductAnalysisService\x12M\n" +
 	"\x06Health\x12\x1f.product_analysis.HealthRequest\x1a .product_analysis.HealthResponse\"\x00\x12e\n" +
-	"\x0eAnalyzeProduct\x12'.product_analysis.AnalyzeProductRequest\x1a(.product_analysis.AnalyzeProductResponse\"\x00\x12z\n" +
+	"\x0eAnalyzeProduct\x12'.product_analysis.AnalyzeProductRequest\x1a(.product_analysis.AnalyzeProductResponse\"\x00\x12i\n" +
+	"\x0fAnalyzeProducts\x12'.product_analysis.AnalyzeProductRequest\x1a).product_analysis.AnalyzeProductsResponse\"\x00(\x01\x12r\n" +
+	"\x14AnalyzeProductsBatch\x12-.product_analysis.AnalyzeProductsBatchRequest\x1a).product_analysis.AnalyzeProductsResponse\"\x00\x12z\n" +
 	"\x15UpdateProductPriority\x12..product_analysis.UpdateProductPriorityRequest\x1a/.product_analysis.UpdateProductPriorityResponse\"\x00\x12t\n" +
-	"\x13GetProductAnalytics\x12,.product_analysis.GetProductAnalyticsRequest\x1a-.product_analysis.GetProductAnalyticsResponse\"\x00BBZ@github.com/faisaloncode/ecommerce-crawler/product-analysis/protob\x06proto3"
+	"\x13GetProductAnalytics\x12,.product_analysis.GetProductAnalyticsRequest\x1a-.product_analysis.GetProductAnalyticsResponse\"\x00\x12t\n" +
+	"\x13GetEngagementSeries\x12,.product_analysis.GetEngagementSeriesRequest\x1a-.product_analysis.GetEngagementSeriesResponse\"\x00\x12_\n" +
+	"\fListTrending\x12%.product_analysis.ListTrendingRequest\x1a&.product_analysis.ListTrendingResponse\"\x00\x12b\n" +
+	"\rListTopMovers\x12&.product_analysis.ListTopMoversRequest\x1a'.product_analysis.ListTopMoversResponse\"\x00\x12k\n" +
+	"\x10GetPriceForecast\x12).product_analysis.GetPriceForecastRequest\x1a*.product_analysis.GetPriceForecastResponse\"\x00\x12k\n" +
+	"\x10GetBuyBoxHistory\x12).product_analysis.GetBuyBoxHistoryRequest\x1a*.product_analysis.GetBuyBoxHistoryResponse\"\x00\x12\\\n" +
+	"\vListReviews\x12$.product_analysis.ListReviewsRequest\x1a%.product_analysis.ListReviewsResponse\"\x00\x12n\n" +
+	"\x11GetBrandSentiment\x12*.product_analysis.GetBrandSentimentRequest\x1a+.product_analysis.GetBrandSentimentResponse\"\x00\x12q\n" +
+	"\x12GetSimilarProducts\x12+.product_analysis.GetSimilarProductsRequest\x1a,.product_analysis.GetSimilarProductsResponse\"\x00\x12i\n" +
+	"\x13GetCanonicalProduct\x12,.product_analysis.GetCanonicalProductRequest\x1a\".product_analysis.CanonicalProduct\"\x00\x12q\n" +
+	"\x17ConfirmCanonicalProduct\x120.product_analysis.ConfirmCanonicalProductRequest\x1a\".product_analysis.CanonicalProduct\"\x00\x12z\n" +
+	"\x15SplitCanonicalProduct\x12..product_analysis.SplitCanonicalProductRequest\x1a/.product_analysis.SplitCanonicalProductResponse\"\x00BBZ@github.com/faisaloncode/ecommerce-crawler/product-analysis/protob\x06proto3"
 
 var (
 	file_proto_product_analysis_proto_rawDescOnce sync.Once
@@ -1081,43 +4814,141 @@ func file_proto_product_analysis_proto_rawDescGZIP() []byte {
 	return file_proto_product_analysis_proto_rawDescData
 }
 
-var file_proto_product_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_product_analysis_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_proto_product_analysis_proto_goTypes = []any{
-	(*HealthRequest)(nil),                 // 0: product_analysis.HealthRequest
-	(*HealthResponse)(nil),                // 1: product_analysis.HealthResponse
-	(*ProductData)(nil),                   // 2: product_analysis.ProductData
-	(*ProductVariant)(nil),                // 3: product_analysis.ProductVariant
-	(*ProductImage)(nil),                  // 4: product_analysis.ProductImage
-	(*ProductAttribute)(nil),              // 5: product_analysis.ProductAttribute
-	(*Review)(nil),                        // 6: product_analysis.Review
-	(*AnalyzeProductRequest)(nil),         // 7: product_analysis.AnalyzeProductRequest
-	(*AnalyzeProductResponse)(nil),        // 8: product_analysis.AnalyzeProductResponse
-	(*UpdateProductPriorityRequest)(nil),  // 9: product_analysis.UpdateProductPriorityRequest
-	(*UpdateProductPriorityResponse)(nil), // 10: product_analysis.UpdateProductPriorityResponse
-	(*GetProductAnalyticsRequest)(nil),    // 11: product_analysis.GetProductAnalyticsRequest
-	(*GetProductAnalyticsResponse)(nil),   // 12: product_analysis.GetProductAnalyticsResponse
-	(*PriceHistory)(nil),                  // 13: product_analysis.PriceHistory
+	(*HealthRequest)(nil),                  // 0: product_analysis.HealthRequest
+	(*HealthResponse)(nil),                 // 1: product_analysis.HealthResponse
+	(*ProductData)(nil),                    // 2: product_analysis.ProductData
+	(*Offer)(nil),                          // 3: product_analysis.Offer
+	(*ProductVariant)(nil),                 // 4: product_analysis.ProductVariant
+	(*ProductImage)(nil),                   // 5: product_analysis.ProductImage
+	(*ProductAttribute)(nil),               // 6: product_analysis.ProductAttribute
+	(*Review)(nil),                         // 7: product_analysis.Review
+	(*AnalyzeProductRequest)(nil),          // 8: product_analysis.AnalyzeProductRequest
+	(*AnalyzeProductResponse)(nil),         // 9: product_analysis.AnalyzeProductResponse
+	(*AnalyzeProductsBatchRequest)(nil),    // 10: product_analysis.AnalyzeProductsBatchRequest
+	(*AnalyzeProductResult)(nil),           // 11: product_analysis.AnalyzeProductResult
+	(*AnalyzeProductsResponse)(nil),        // 12: product_analysis.AnalyzeProductsResponse
+	(*UpdateProductPriorityRequest)(nil),   // 13: product_analysis.UpdateProductPriorityRequest
+	(*UpdateProductPriorityResponse)(nil),  // 14: product_analysis.UpdateProductPriorityResponse
+	(*GetProductAnalyticsRequest)(nil),     // 15: product_analysis.GetProductAnalyticsRequest
+	(*GetProductAnalyticsResponse)(nil),    // 16: product_analysis.GetProductAnalyticsResponse
+	(*PriceAnomaly)(nil),                   // 17: product_analysis.PriceAnomaly
+	(*ProductScore)(nil),                   // 18: product_analysis.ProductScore
+	(*PriceChange)(nil),                    // 19: product_analysis.PriceChange
+	(*PriceHistory)(nil),                   // 20: product_analysis.PriceHistory
+	(*GetEngagementSeriesRequest)(nil),     // 21: product_analysis.GetEngagementSeriesRequest
+	(*GetEngagementSeriesResponse)(nil),    // 22: product_analysis.GetEngagementSeriesResponse
+	(*EngagementPoint)(nil),                // 23: product_analysis.EngagementPoint
+	(*ListTrendingRequest)(nil),            // 24: product_analysis.ListTrendingRequest
+	(*ListTrendingResponse)(nil),           // 25: product_analysis.ListTrendingResponse
+	(*TrendingProduct)(nil),                // 26: product_analysis.TrendingProduct
+	(*ListTopMoversRequest)(nil),           // 27: product_analysis.ListTopMoversRequest
+	(*ListTopMoversResponse)(nil),          // 28: product_analysis.ListTopMoversResponse
+	(*PriceMover)(nil),                     // 29: product_analysis.PriceMover
+	(*GetPriceForecastRequest)(nil),        // 30: product_analysis.GetPriceForecastRequest
+	(*GetPriceForecastResponse)(nil),       // 31: product_analysis.GetPriceForecastResponse
+	(*VariantForecast)(nil),                // 32: product_analysis.VariantForecast
+	(*ForecastRange)(nil),                  // 33: product_analysis.ForecastRange
+	(*BacktestMetrics)(nil),                // 34: product_analysis.BacktestMetrics
+	(*GetBuyBoxHistoryRequest)(nil),        // 35: product_analysis.GetBuyBoxHistoryRequest
+	(*GetBuyBoxHistoryResponse)(nil),       // 36: product_analysis.GetBuyBoxHistoryResponse
+	(*VariantBuyBox)(nil),                  // 37: product_analysis.VariantBuyBox
+	(*BuyBoxTenure)(nil),                   // 38: product_analysis.BuyBoxTenure
+	(*BuyBoxShare)(nil),                    // 39: product_analysis.BuyBoxShare
+	(*ListReviewsRequest)(nil),             // 40: product_analysis.ListReviewsRequest
+	(*RatingCount)(nil),                    // 41: product_analysis.RatingCount
+	(*RatingTrendPoint)(nil),               // 42: product_analysis.RatingTrendPoint
+	(*ListReviewsResponse)(nil),            // 43: product_analysis.ListReviewsResponse
+	(*ReviewSentiment)(nil),                // 44: product_analysis.ReviewSentiment
+	(*AspectSentiment)(nil),                // 45: product_analysis.AspectSentiment
+	(*KeywordCount)(nil),                   // 46: product_analysis.KeywordCount
+	(*GetBrandSentimentRequest)(nil),       // 47: product_analysis.GetBrandSentimentRequest
+	(*GetBrandSentimentResponse)(nil),      // 48: product_analysis.GetBrandSentimentResponse
+	(*GetSimilarProductsRequest)(nil),      // 49: product_analysis.GetSimilarProductsRequest
+	(*SimilarProduct)(nil),                 // 50: product_analysis.SimilarProduct
+	(*GetSimilarProductsResponse)(nil),     // 51: product_analysis.GetSimilarProductsResponse
+	(*GetCanonicalProductRequest)(nil),     // 52: product_analysis.GetCanonicalProductRequest
+	(*ConfirmCanonicalProductRequest)(nil), // 53: product_analysis.ConfirmCanonicalProductRequest
+	(*SplitCanonicalProductRequest)(nil),   // 54: product_analysis.SplitCanonicalProductRequest
+	(*SplitCanonicalProductResponse)(nil),  // 55: product_analysis.SplitCanonicalProductResponse
+	(*CanonicalProduct)(nil),               // 56: product_analysis.CanonicalProduct
+	(*CanonicalListing)(nil),               // 57: product_analysis.CanonicalListing
 }
 var file_proto_product_analysis_proto_depIdxs = []int32{
-	3,  // 0: product_analysis.ProductData.variants:type_name -> product_analysis.ProductVariant
-	4,  // 1: product_analysis.ProductData.images:type_name -> product_analysis.ProductImage
-	5,  // 2: product_analysis.ProductData.attributes:type_name -> product_analysis.ProductAttribute
-	6,  // 3: product_analysis.ProductData.top_reviews:type_name -> product_analysis.Review
-	2,  // 4: product_analysis.AnalyzeProductRequest.product:type_name -> product_analysis.ProductData
-	13, // 5: product_analysis.GetProductAnalyticsResponse.price_history:type_name -> product_analysis.PriceHistory
-	0,  // 6: product_analysis.ProductAnalysisService.Health:input_type -> product_analysis.HealthRequest
-	7,  // 7: product_analysis.ProductAnalysisService.AnalyzeProduct:input_type -> product_analysis.AnalyzeProductRequest
-	9,  // 8: product_analysis.ProductAnalysisService.UpdateProductPriority:input_type -> product_analysis.UpdateProductPriorityRequest
-	11, // 9: product_analysis.ProductAnalysisService.GetProductAnalytics:input_type -> product_analysis.GetProductAnalyticsRequest
-	1,  // 10: product_analysis.ProductAnalysisService.Health:output_type -> product_analysis.HealthResponse
-	8,  // 11: product_analysis.ProductAnalysisService.AnalyzeProduct:output_type -> product_analysis.AnalyzeProductResponse
-	10, // 12: product_analysis.ProductAnalysisService.UpdateProductPriority:output_type -> product_analysis.UpdateProductPriorityResponse
-	12, // 13: product_analysis.ProductAnalysisService.GetProductAnalytics:output_type -> product_analysis.GetProductAnalyticsResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	4,  // 0: product_analysis.ProductData.variants:type_name -> product_analysis.ProductVariant
+	5,  // 1: product_analysis.ProductData.images:type_name -> product_analysis.ProductImage
+	6,  // 2: product_analysis.ProductData.attributes:type_name -> product_analysis.ProductAttribute
+	7,  // 3: product_analysis.ProductData.top_reviews:type_name -> product_analysis.Review
+	3,  // 4: product_analysis.ProductData.offers:type_name -> product_analysis.Offer
+	2,  // 5: product_analysis.AnalyzeProductRequest.product:type_name -> product_analysis.ProductData
+	2,  // 6: product_analysis.AnalyzeProductsBatchRequest.products:type_name -> product_analysis.ProductData
+	11, // 7: product_analysis.AnalyzeProductsResponse.results:type_name -> product_analysis.AnalyzeProductResult
+	20, // 8: product_analysis.GetProductAnalyticsResponse.price_history:type_name -> product_analysis.PriceHistory
+	19, // 9: product_analysis.GetProductAnalyticsResponse.price_changes:type_name -> product_analysis.PriceChange
+	18, // 10: product_analysis.GetProductAnalyticsResponse.scores:type_name -> product_analysis.ProductScore
+	17, // 11: product_analysis.GetProductAnalyticsResponse.anomalies:type_name -> product_analysis.PriceAnomaly
+	44, // 12: product_analysis.GetProductAnalyticsResponse.review_sentiment:type_name -> product_analysis.ReviewSentiment
+	23, // 13: product_analysis.GetEngagementSeriesResponse.points:type_name -> product_analysis.EngagementPoint
+	26, // 14: product_analysis.ListTrendingResponse.products:type_name -> product_analysis.TrendingProduct
+	29, // 15: product_analysis.ListTopMoversResponse.products:type_name -> product_analysis.PriceMover
+	32, // 16: product_analysis.GetPriceForecastResponse.forecasts:type_name -> product_analysis.VariantForecast
+	33, // 17: product_analysis.VariantForecast.ranges:type_name -> product_analysis.ForecastRange
+	34, // 18: product_analysis.VariantForecast.backtests:type_name -> product_analysis.BacktestMetrics
+	37, // 19: product_analysis.GetBuyBoxHistoryResponse.variants:type_name -> product_analysis.VariantBuyBox
+	38, // 20: product_analysis.VariantBuyBox.current:type_name -> product_analysis.BuyBoxTenure
+	38, // 21: product_analysis.VariantBuyBox.tenures:type_name -> product_analysis.BuyBoxTenure
+	39, // 22: product_analysis.VariantBuyBox.shares:type_name -> product_analysis.BuyBoxShare
+	7,  // 23: product_analysis.ListReviewsResponse.reviews:type_name -> product_analysis.Review
+	41, // 24: product_analysis.ListReviewsResponse.distribution:type_name -> product_analysis.RatingCount
+	42, // 25: product_analysis.ListReviewsResponse.trend:type_name -> product_analysis.RatingTrendPoint
+	45, // 26: product_analysis.ReviewSentiment.aspects:type_name -> product_analysis.AspectSentiment
+	46, // 27: product_analysis.ReviewSentiment.keywords:type_name -> product_analysis.KeywordCount
+	44, // 28: product_analysis.GetBrandSentimentResponse.sentiment:type_name -> product_analysis.ReviewSentiment
+	50, // 29: product_analysis.GetSimilarProductsResponse.similar:type_name -> product_analysis.SimilarProduct
+	56, // 30: product_analysis.SplitCanonicalProductResponse.remaining:type_name -> product_analysis.CanonicalProduct
+	56, // 31: product_analysis.SplitCanonicalProductResponse.split:type_name -> product_analysis.CanonicalProduct
+	57, // 32: product_analysis.CanonicalProduct.listings:type_name -> product_analysis.CanonicalListing
+	44, // 33: product_analysis.CanonicalProduct.review_sentiment:type_name -> product_analysis.ReviewSentiment
+	0,  // 34: product_analysis.ProductAnalysisService.Health:input_type -> product_analysis.HealthRequest
+	8,  // 35: product_analysis.ProductAnalysisService.AnalyzeProduct:input_type -> product_analysis.AnalyzeProductRequest
+	8,  // 36: product_analysis.ProductAnalysisService.AnalyzeProducts:input_type -> product_analysis.AnalyzeProductRequest
+	10, // 37: product_analysis.ProductAnalysisService.AnalyzeProductsBatch:input_type -> product_analysis.AnalyzeProductsBatchRequest
+	13, // 38: product_analysis.ProductAnalysisService.UpdateProductPriority:input_type -> product_analysis.UpdateProductPriorityRequest
+	15, // 39: product_analysis.ProductAnalysisService.GetProductAnalytics:input_type -> product_analysis.GetProductAnalyticsRequest
+	21, // 40: product_analysis.ProductAnalysisService.GetEngagementSeries:input_type -> product_analysis.GetEngagementSeriesRequest
+	24, // 41: product_analysis.ProductAnalysisService.ListTrending:input_type -> product_analysis.ListTrendingRequest
+	27, // 42: product_analysis.ProductAnalysisService.ListTopMovers:input_type -> product_analysis.ListTopMoversRequest
+	30, // 43: product_analysis.ProductAnalysisService.GetPriceForecast:input_type -> product_analysis.GetPriceForecastRequest
+	35, // 44: product_analysis.ProductAnalysisService.GetBuyBoxHistory:input_type -> product_analysis.GetBuyBoxHistoryRequest
+	40, // 45: product_analysis.ProductAnalysisService.ListReviews:input_type -> product_analysis.ListReviewsRequest
+	47, // 46: product_analysis.ProductAnalysisService.GetBrandSentiment:input_type -> product_analysis.GetBrandSentimentRequest
+	49, // 47: product_analysis.ProductAnalysisService.GetSimilarProducts:input_type -> product_analysis.GetSimilarProductsRequest
+	52, // 48: product_analysis.ProductAnalysisService.GetCanonicalProduct:input_type -> product_analysis.GetCanonicalProductRequest
+	53, // 49: product_analysis.ProductAnalysisService.ConfirmCanonicalProduct:input_type -> product_analysis.ConfirmCanonicalProductRequest
+	54, // 50: product_analysis.ProductAnalysisService.SplitCanonicalProduct:input_type -> product_analysis.SplitCanonicalProductRequest
+	1,  // 51: product_analysis.ProductAnalysisService.Health:output_type -> product_analysis.HealthResponse
+	9,  // 52: product_analysis.ProductAnalysisService.AnalyzeProduct:output_type -> product_analysis.AnalyzeProductResponse
+	12, // 53: product_analysis.ProductAnalysisService.AnalyzeProducts:output_type -> product_analysis.AnalyzeProductsResponse
+	12, // 54: product_analysis.ProductAnalysisService.AnalyzeProductsBatch:output_type -> product_analysis.AnalyzeProductsResponse
+	14, // 55: product_analysis.ProductAnalysisService.UpdateProductPriority:output_type -> product_analysis.UpdateProductPriorityResponse
+	16, // 56: product_analysis.ProductAnalysisService.GetProductAnalytics:output_type -> product_analysis.GetProductAnalyticsResponse
+	22, // 57: product_analysis.ProductAnalysisService.GetEngagementSeries:output_type -> product_analysis.GetEngagementSeriesResponse
+	25, // 58: product_analysis.ProductAnalysisService.ListTrending:output_type -> product_analysis.ListTrendingResponse
+	28, // 59: product_analysis.ProductAnalysisService.ListTopMovers:output_type -> product_analysis.ListTopMoversResponse
+	31, // 60: product_analysis.ProductAnalysisService.GetPriceForecast:output_type -> product_analysis.GetPriceForecastResponse
+	36, // 61: product_analysis.ProductAnalysisService.GetBuyBoxHistory:output_type -> product_analysis.GetBuyBoxHistoryResponse
+	43, // 62: product_analysis.ProductAnalysisService.ListReviews:output_type -> product_analysis.ListReviewsResponse
+	48, // 63: product_analysis.ProductAnalysisService.GetBrandSentiment:output_type -> product_analysis.GetBrandSentimentResponse
+	51, // 64: product_analysis.ProductAnalysisService.GetSimilarProducts:output_type -> product_analysis.GetSimilarProductsResponse
+	56, // 65: product_analysis.ProductAnalysisService.GetCanonicalProduct:output_type -> product_analysis.CanonicalProduct
+	56, // 66: product_analysis.ProductAnalysisService.ConfirmCanonicalProduct:output_type -> product_analysis.CanonicalProduct
+	55, // 67: product_analysis.ProductAnalysisService.SplitCanonicalProduct:output_type -> product_analysis.SplitCanonicalProductResponse
+	51, // [51:68] is the sub-list for method output_type
+	34, // [34:51] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_proto_product_analysis_proto_init() }
@@ -1131,7 +4962,7 @@ func file_proto_product_analysis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_product_analysis_proto_rawDesc), len(file_proto_product_analysis_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductAnalysisService_Health_FullMethodName                = "/product_analysis.ProductAnalysisService/Health"
-	ProductAnalysisService_AnalyzeProduct_FullMethodName        = "/product_analysis.ProductAnalysisService/AnalyzeProduct"
-	ProductAnalysisService_UpdateProductPriority_FullMethodName = "/product_analysis.ProductAnalysisService/UpdateProductPriority"
-	ProductAnalysisService_GetProductAnalytics_FullMethodName   = "/product_analysis.ProductAnalysisService/GetProductAnalytics"
+	ProductAnalysisService_Health_FullMethodName                  = "/product_analysis.ProductAnalysisService/Health"
+	ProductAnalysisService_AnalyzeProduct_FullMethodName          = "/product_analysis.ProductAnalysisService/AnalyzeProduct"
+	ProductAnalysisService_AnalyzeProducts_FullMethodName         = "/product_analysis.ProductAnalysisService/AnalyzeProducts"
+	ProductAnalysisService_AnalyzeProductsBatch_FullMethodName    = "/product_analysis.ProductAnalysisService/AnalyzeProductsBatch"
+	ProductAnalysisService_UpdateProductPriority_FullMethodName   = "/product_analysis.ProductAnalysisService/UpdateProductPriority"
+	ProductAnalysisService_GetProductAnalytics_FullMethodName     = "/product_analysis.ProductAnalysisService/GetProductAnalytics"
+	ProductAnalysisService_GetEngagementSeries_FullMethodName     = "/product_analysis.ProductAnalysisService/GetEngagementSeries"
+	ProductAnalysisService_ListTrending_FullMethodName            = "/product_analysis.ProductAnalysisService/ListTrending"
+	ProductAnalysisService_ListTopMovers_FullMethodName           = "/product_analysis.ProductAnalysisService/ListTopMovers"
+	ProductAnalysisService_GetPriceForecast_FullMethodName        = "/product_analysis.ProductAnalysisService/GetPriceForecast"
+	ProductAnalysisService_GetBuyBoxHistory_FullMethodName        = "/product_analysis.ProductAnalysisService/GetBuyBoxHistory"
+	ProductAnalysisService_ListReviews_FullMethodName             = "/product_analysis.ProductAnalysisService/ListReviews"
+	ProductAnalysisService_GetBrandSentiment_FullMethodName       = "/product_analysis.ProductAnalysisService/GetBrandSentiment"
+	ProductAnalysisService_GetSimilarProducts_FullMethodName      = "/product_analysis.ProductAnalysisService/GetSimilarProducts"
+	ProductAnalysisService_GetCanonicalProduct_FullMethodName     = "/product_analysis.ProductAnalysisService/GetCanonicalProduct"
+	ProductAnalysisService_ConfirmCanonicalProduct_FullMethodName = "/product_analysis.ProductAnalysisService/ConfirmCanonicalProduct"
+	ProductAnalysisService_SplitCanonicalProduct_FullMethodName   = "/product_analysis.ProductAnalysisService/SplitCanonicalProduct"
 )
 
 // ProductAnalysisServiceClient is the client API for ProductAnalysisService service.
@@ -31,8 +44,23 @@ const (
 type ProductAnalysisServiceClient interface {
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 	AnalyzeProduct(ctx context.Context, in *AnalyzeProductRequest, opts ...grpc.CallOption) (*AnalyzeProductResponse, error)
+	// AnalyzeProducts analyses every product sent on the stream and replies
+	// with one result per product, in the order received, once it closes.
+	AnalyzeProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AnalyzeProductRequest, AnalyzeProductsResponse], error)
+	AnalyzeProductsBatch(ctx context.Context, in *AnalyzeProductsBatchRequest, opts ...grpc.CallOption) (*AnalyzeProductsResponse, error)
 	UpdateProductPriority(ctx context.Context, in *UpdateProductPriorityRequest, opts ...grpc.CallOption) (*UpdateProductPriorityResponse, error)
 	GetProductAnalytics(ctx context.Context, in *GetProductAnalyticsRequest, opts ...grpc.CallOption) (*GetProductAnalyticsResponse, error)
+	GetEngagementSeries(ctx context.Context, in *GetEngagementSeriesRequest, opts ...grpc.CallOption) (*GetEngagementSeriesResponse, error)
+	ListTrending(ctx context.Context, in *ListTrendingRequest, opts ...grpc.CallOption) (*ListTrendingResponse, error)
+	ListTopMovers(ctx context.Context, in *ListTopMoversRequest, opts ...grpc.CallOption) (*ListTopMoversResponse, error)
+	GetPriceForecast(ctx context.Context, in *GetPriceForecastRequest, opts ...grpc.CallOption) (*GetPriceForecastResponse, error)
+	GetBuyBoxHistory(ctx context.Context, in *GetBuyBoxHistoryRequest, opts ...grpc.CallOption) (*GetBuyBoxHistoryResponse, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	GetBrandSentiment(ctx context.Context, in *GetBrandSentimentRequest, opts ...grpc.CallOption) (*GetBrandSentimentResponse, error)
+	GetSimilarProducts(ctx context.Context, in *GetSimilarProductsRequest, opts ...grpc.CallOption) (*GetSimilarProductsResponse, error)
+	GetCanonicalProduct(ctx context.Context, in *GetCanonicalProductRequest, opts ...grpc.CallOption) (*CanonicalProduct, error)
+	ConfirmCanonicalProduct(ctx context.Context, in *ConfirmCanonicalProductRequest, opts ...grpc.CallOption) (*CanonicalProduct, error)
+	SplitCanonicalProduct(ctx context.Context, in *SplitCanonicalProductRequest, opts ...grpc.CallOption) (*SplitCanonicalProductResponse, error)
 }
 
 type productAnalysisServiceClient struct {
@@ -63,6 +91,29 @@ func (c *productAnalysisServiceClient) AnalyzeProduct(ctx context.Context, in *A
 	return out, nil
 }
 
+func (c *productAnalysisServiceClient) AnalyzeProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AnalyzeProductRequest, AnalyzeProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProductAnalysisService_ServiceDesc.Streams[0], ProductAnalysisService_AnalyzeProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AnalyzeProductRequest, AnalyzeProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductAnalysisService_AnalyzeProductsClient = grpc.ClientStreamingClient[AnalyzeProductRequest, AnalyzeProductsResponse]
+
+func (c *productAnalysisServiceClient) AnalyzeProductsBatch(ctx context.Context, in *AnalyzeProductsBatchRequest, opts ...grpc.CallOption) (*AnalyzeProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnalyzeProductsResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_AnalyzeProductsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productAnalysisServiceClient) UpdateProductPriority(ctx context.Context, in *UpdateProductPriorityRequest, opts ...grpc.CallOption) (*UpdateProductPriorityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateProductPriorityResponse)
@@ -83,14 +134,139 @@ func (c *productAnalysisServiceClient) GetProductAnalytics(ctx context.Context,
 	return out, nil
 }
 
+func (c *productAnalysisServiceClient) GetEngagementSeries(ctx context.Context, in *GetEngagementSeriesRequest, opts ...grpc.CallOption) (*GetEngagementSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEngagementSeriesResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetEngagementSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) ListTrending(ctx context.Context, in *ListTrendingRequest, opts ...grpc.CallOption) (*ListTrendingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrendingResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_ListTrending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) ListTopMovers(ctx context.Context, in *ListTopMoversRequest, opts ...grpc.CallOption) (*ListTopMoversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopMoversResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_ListTopMovers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) GetPriceForecast(ctx context.Context, in *GetPriceForecastRequest, opts ...grpc.CallOption) (*GetPriceForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceForecastResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetPriceForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) GetBuyBoxHistory(ctx context.Context, in *GetBuyBoxHistoryRequest, opts ...grpc.CallOption) (*GetBuyBoxHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBuyBoxHistoryResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetBuyBoxHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_ListReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) GetBrandSentiment(ctx context.Context, in *GetBrandSentimentRequest, opts ...grpc.CallOption) (*GetBrandSentimentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBrandSentimentResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetBrandSentiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) GetSimilarProducts(ctx context.Context, in *GetSimilarProductsRequest, opts ...grpc.CallOption) (*GetSimilarProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimilarProductsResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetSimilarProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) GetCanonicalProduct(ctx context.Context, in *GetCanonicalProductRequest, opts ...grpc.CallOption) (*CanonicalProduct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CanonicalProduct)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_GetCanonicalProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) ConfirmCanonicalProduct(ctx context.Context, in *ConfirmCanonicalProductRequest, opts ...grpc.CallOption) (*CanonicalProduct, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CanonicalProduct)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_ConfirmCanonicalProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productAnalysisServiceClient) SplitCanonicalProduct(ctx context.Context, in *SplitCanonicalProductRequest, opts ...grpc.CallOption) (*SplitCanonicalProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitCanonicalProductResponse)
+	err := c.cc.Invoke(ctx, ProductAnalysisService_SplitCanonicalProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductAnalysisServiceServer is the server API for ProductAnalysisService service.
 // All implementations must embed UnimplementedProductAnalysisServiceServer
 // for forward compatibility.
 type ProductAnalysisServiceServer interface {
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	AnalyzeProduct(context.Context, *AnalyzeProductRequest) (*AnalyzeProductResponse, error)
+	// AnalyzeProducts analyses every product sent on the stream and replies
+	// with one result per product, in the order received, once it closes.
+	AnalyzeProducts(grpc.ClientStreamingServer[AnalyzeProductRequest, AnalyzeProductsResponse]) error
+	AnalyzeProductsBatch(context.Context, *AnalyzeProductsBatchRequest) (*AnalyzeProductsResponse, error)
 	UpdateProductPriority(context.Context, *UpdateProductPriorityRequest) (*UpdateProductPriorityResponse, error)
 	GetProductAnalytics(context.Context, *GetProductAnalyticsRequest) (*GetProductAnalyticsResponse, error)
+	GetEngagementSeries(context.Context, *GetEngagementSeriesRequest) (*GetEngagementSeriesResponse, error)
+	ListTrending(context.Context, *ListTrendingRequest) (*ListTrendingResponse, error)
+	ListTopMovers(context.Context, *ListTopMoversRequest) (*ListTopMoversResponse, error)
+	GetPriceForecast(context.Context, *GetPriceForecastRequest) (*GetPriceForecastResponse, error)
+	GetBuyBoxHistory(context.Context, *GetBuyBoxHistoryRequest) (*GetBuyBoxHistoryResponse, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	GetBrandSentiment(context.Context, *GetBrandSentimentRequest) (*GetBrandSentimentResponse, error)
+	GetSimilarProducts(context.Context, *GetSimilarProductsRequest) (*GetSimilarProductsResponse, error)
+	GetCanonicalProduct(context.Context, *GetCanonicalProductRequest) (*CanonicalProduct, error)
+	ConfirmCanonicalProduct(context.Context, *ConfirmCanonicalProductRequest) (*CanonicalProduct, error)
+	SplitCanonicalProduct(context.Context, *SplitCanonicalProductRequest) (*SplitCanonicalProductResponse, error)
 	mustEmbedUnimplementedProductAnalysisServiceServer()
 }
 
@@ -107,12 +283,51 @@ func (UnimplementedProductAnalysisServiceServer) Health(context.Context, *Health
 func (UnimplementedProductAnalysisServiceServer) AnalyzeProduct(context.Context, *AnalyzeProductRequest) (*AnalyzeProductResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeProduct not implemented")
 }
+func (UnimplementedProductAnalysisServiceServer) AnalyzeProducts(grpc.ClientStreamingServer[AnalyzeProductRequest, AnalyzeProductsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method AnalyzeProducts not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) AnalyzeProductsBatch(context.Context, *AnalyzeProductsBatchRequest) (*AnalyzeProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnalyzeProductsBatch not implemented")
+}
 func (UnimplementedProductAnalysisServiceServer) UpdateProductPriority(context.Context, *UpdateProductPriorityRequest) (*UpdateProductPriorityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProductPriority not implemented")
 }
 func (UnimplementedProductAnalysisServiceServer) GetProductAnalytics(context.Context, *GetProductAnalyticsRequest) (*GetProductAnalyticsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProductAnalytics not implemented")
 }
+func (UnimplementedProductAnalysisServiceServer) GetEngagementSeries(context.Context, *GetEngagementSeriesRequest) (*GetEngagementSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngagementSeries not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) ListTrending(context.Context, *ListTrendingRequest) (*ListTrendingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrending not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) ListTopMovers(context.Context, *ListTopMoversRequest) (*ListTopMoversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopMovers not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) GetPriceForecast(context.Context, *GetPriceForecastRequest) (*GetPriceForecastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceForecast not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) GetBuyBoxHistory(context.Context, *GetBuyBoxHistoryRequest) (*GetBuyBoxHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuyBoxHistory not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) GetBrandSentiment(context.Context, *GetBrandSentimentRequest) (*GetBrandSentimentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrandSentiment not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) GetSimilarProducts(context.Context, *GetSimilarProductsRequest) (*GetSimilarProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarProducts not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) GetCanonicalProduct(context.Context, *GetCanonicalProductRequest) (*CanonicalProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCanonicalProduct not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) ConfirmCanonicalProduct(context.Context, *ConfirmCanonicalProductRequest) (*CanonicalProduct, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmCanonicalProduct not implemented")
+}
+func (UnimplementedProductAnalysisServiceServer) SplitCanonicalProduct(context.Context, *SplitCanonicalProductRequest) (*SplitCanonicalProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SplitCanonicalProduct not implemented")
+}
 func (UnimplementedProductAnalysisServiceServer) mustEmbedUnimplementedProductAnalysisServiceServer() {
 }
 func (UnimplementedProductAnalysisServiceServer) testEmbeddedByValue() {}
@@ -171,6 +386,31 @@ func _ProductAnalysisService_AnalyzeProduct_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductAnalysisService_AnalyzeProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductAnalysisServiceServer).AnalyzeProducts(&grpc.GenericServerStream[AnalyzeProductRequest, AnalyzeProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProductAnalysisService_AnalyzeProductsServer = grpc.ClientStreamingServer[AnalyzeProductRequest, AnalyzeProductsResponse]
+
+func _ProductAnalysisService_AnalyzeProductsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyzeProductsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).AnalyzeProductsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_AnalyzeProductsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).AnalyzeProductsBatch(ctx, req.(*AnalyzeProductsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductAnalysisService_UpdateProductPriority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateProductPriorityRequest)
 	if err := dec(in); err != nil {
@@ -207,6 +447,204 @@ func _ProductAnalysisService_GetProductAnalytics_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductAnalysisService_GetEngagementSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEngagementSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetEngagementSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetEngagementSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetEngagementSeries(ctx, req.(*GetEngagementSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_ListTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).ListTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_ListTrending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).ListTrending(ctx, req.(*ListTrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_ListTopMovers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopMoversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).ListTopMovers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_ListTopMovers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).ListTopMovers(ctx, req.(*ListTopMoversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_GetPriceForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetPriceForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetPriceForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetPriceForecast(ctx, req.(*GetPriceForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_GetBuyBoxHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuyBoxHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetBuyBoxHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetBuyBoxHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetBuyBoxHistory(ctx, req.(*GetBuyBoxHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_ListReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_GetBrandSentiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrandSentimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetBrandSentiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetBrandSentiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetBrandSentiment(ctx, req.(*GetBrandSentimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_GetSimilarProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetSimilarProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetSimilarProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetSimilarProducts(ctx, req.(*GetSimilarProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_GetCanonicalProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCanonicalProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).GetCanonicalProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_GetCanonicalProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).GetCanonicalProduct(ctx, req.(*GetCanonicalProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_ConfirmCanonicalProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmCanonicalProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).ConfirmCanonicalProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_ConfirmCanonicalProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).ConfirmCanonicalProduct(ctx, req.(*ConfirmCanonicalProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductAnalysisService_SplitCanonicalProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitCanonicalProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductAnalysisServiceServer).SplitCanonicalProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductAnalysisService_SplitCanonicalProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductAnalysisServiceServer).SplitCanonicalProduct(ctx, req.(*SplitCanonicalProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductAnalysisService_ServiceDesc is the grpc.ServiceDesc for ProductAnalysisService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -222,6 +660,10 @@ var ProductAnalysisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AnalyzeProduct",
 			Handler:    _ProductAnalysisService_AnalyzeProduct_Handler,
 		},
+		{
+			MethodName: "AnalyzeProductsBatch",
+			Handler:    _ProductAnalysisService_AnalyzeProductsBatch_Handler,
+		},
 		{
 			MethodName: "UpdateProductPriority",
 			Handler:    _ProductAnalysisService_UpdateProductPriority_Handler,
@@ -230,7 +672,57 @@ var ProductAnalysisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProductAnalytics",
 			Handler:    _ProductAnalysisService_GetProductAnalytics_Handler,
 		},
+		{
+			MethodName: "GetEngagementSeries",
+			Handler:    _ProductAnalysisService_GetEngagementSeries_Handler,
+		},
+		{
+			MethodName: "ListTrending",
+			Handler:    _ProductAnalysisService_ListTrending_Handler,
+		},
+		{
+			MethodName: "ListTopMovers",
+			Handler:    _ProductAnalysisService_ListTopMovers_Handler,
+		},
+		{
+			MethodName: "GetPriceForecast",
+			Handler:    _ProductAnalysisService_GetPriceForecast_Handler,
+		},
+		{
+			MethodName: "GetBuyBoxHistory",
+			Handler:    _ProductAnalysisService_GetBuyBoxHistory_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _ProductAnalysisService_ListReviews_Handler,
+		},
+		{
+			MethodName: "GetBrandSentiment",
+			Handler:    _ProductAnalysisService_GetBrandSentiment_Handler,
+		},
+		{
+			MethodName: "GetSimilarProducts",
+			Handler:    _ProductAnalysisService_GetSimilarProducts_Handler,
+		},
+		{
+			MethodName: "GetCanonicalProduct",
+			Handler:    _ProductAnalysisService_GetCanonicalProduct_Handler,
+		},
+		{
+			MethodName: "ConfirmCanonicalProduct",
+			Handler:    _ProductAnalysisService_ConfirmCanonicalProduct_Handler,
+		},
+		{
+			MethodName: "SplitCanonicalProduct",
+			Handler:    _ProductAnalysisService_SplitCanonicalProduct_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AnalyzeProducts",
+			Handler:       _ProductAnalysisService_AnalyzeProducts_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/product_analysis.proto",
 }
@@ -0,0 +1,163 @@
+// Package scoring ranks products by popularity.
+//
+// A Scorer is given every product in one category and returns a score in
+// [0, 1] for each. Scores are percentiles within the category, so a product
+// scoring 0.9 is ahead of 90% of its category whatever the category's
+// absolute traffic, and scores from different categories can be compared.
+package scoring
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Counters are a product's engagement counters, or increments of them.
+type Counters struct {
+	Views      float64
+	Favorites  float64
+	AddToCarts float64
+	Orders     float64
+}
+
+// Increment is the growth of a product's counters between two snapshots.
+type Increment struct {
+	At time.Time
+	Counters
+}
+
+// Signals are the inputs a Scorer sees for one product.
+type Signals struct {
+	ProductID  uint
+	CategoryID uint
+	// Totals are the latest counters.
+	Totals Counters
+	// Increments are the counter increases over the recent history, oldest
+	// first.
+	Increments []Increment
+}
+
+// Weights set how much each counter contributes to a score. They need not
+// sum to one.
+type Weights struct {
+	Views      float64
+	Favorites  float64
+	AddToCarts float64
+	Orders     float64
+}
+
+func (w Weights) total() float64 {
+	return w.Views + w.Favorites + w.AddToCarts + w.Orders
+}
+
+// Scorer scores the products of one category.
+type Scorer interface {
+	// Name identifies the scorer in stored scores.
+	Name() string
+	// Score returns a score in [0, 1] for each product, in order.
+	Score(products []Signals, now time.Time) []float64
+}
+
+// PercentileScorer scores products by the weighted percentile rank of their
+// total counters within the category.
+type PercentileScorer struct {
+	Weights Weights
+}
+
+func (s *PercentileScorer) Name() string { return "percentile" }
+
+func (s *PercentileScorer) Score(products []Signals, now time.Time) []float64 {
+	counters := make([]Counters, len(products))
+	for i, p := range products {
+		counters[i] = p.Totals
+	}
+	return weightedPercentiles(counters, s.Weights)
+}
+
+// TrendingScorer scores products by recent growth. Each increment counts
+// for less the older it is, halving every HalfLife, and the decayed sums are
+// ranked within the category like PercentileScorer does.
+type TrendingScorer struct {
+	Weights  Weights
+	HalfLife time.Duration
+}
+
+func (s *TrendingScorer) Name() string { return "trending" }
+
+func (s *TrendingScorer) Score(products []Signals, now time.Time) []float64 {
+	counters := make([]Counters, len(products))
+	for i, p := range products {
+		for _, inc := range p.Increments {
+			decay := math.Exp2(-now.Sub(inc.At).Hours() / s.HalfLife.Hours())
+			counters[i].Views += inc.Views * decay
+			counters[i].Favorites += inc.Favorites * decay
+			counters[i].AddToCarts += inc.AddToCarts * decay
+			counters[i].Orders += inc.Orders * decay
+		}
+	}
+	return weightedPercentiles(counters, s.Weights)
+}
+
+// Rank returns the 1-based rank of each score, highest first. Ties keep
+// their input order.
+func Rank(scores []float64) []int {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	ranks := make([]int, len(scores))
+	for rank, i := range order {
+		ranks[i] = rank + 1
+	}
+	return ranks
+}
+
+func weightedPercentiles(counters []Counters, w Weights) []float64 {
+	total := w.total()
+	scores := make([]float64, len(counters))
+	if total == 0 || len(counters) == 0 {
+		return scores
+	}
+
+	signal := func(weight float64, value func(Counters) float64) {
+		if weight == 0 {
+			return
+		}
+		values := make([]float64, len(counters))
+		for i, c := range counters {
+			values[i] = value(c)
+		}
+		for i, p := range percentiles(values) {
+			scores[i] += weight * p
+		}
+	}
+	signal(w.Views, func(c Counters) float64 { return c.Views })
+	signal(w.Favorites, func(c Counters) float64 { return c.Favorites })
+	signal(w.AddToCarts, func(c Counters) float64 { return c.AddToCarts })
+	signal(w.Orders, func(c Counters) float64 { return c.Orders })
+
+	for i := range scores {
+		scores[i] /= total
+	}
+	return scores
+}
+
+// percentiles returns the mid-rank percentile of each value: the share of
+// values below it plus half the share equal to it.
+func percentiles(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	n := float64(len(values))
+	result := make([]float64, len(values))
+	for i, v := range values {
+		below := sort.SearchFloat64s(sorted, v)
+		equal := sort.SearchFloat64s(sorted, math.Nextafter(v, math.Inf(1))) - below
+		result[i] = (float64(below) + float64(equal)/2) / n
+	}
+	return result
+}
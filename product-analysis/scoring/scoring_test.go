@@ -0,0 +1,85 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPercentiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   []float64
+	}{
+		{"single", []float64{7}, []float64{0.5}},
+		{"distinct", []float64{3, 1, 2}, []float64{5.0 / 6, 1.0 / 6, 0.5}},
+		{"ties share the mid rank", []float64{1, 2, 2, 3}, []float64{0.125, 0.5, 0.5, 0.875}},
+		{"all equal", []float64{5, 5, 5}, []float64{0.5, 0.5, 0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := percentiles(tt.values)
+			assertScores(t, got, tt.want)
+		})
+	}
+}
+
+func TestPercentileScorer(t *testing.T) {
+	products := []Signals{
+		{ProductID: 1, Totals: Counters{Views: 100, Orders: 1}},
+		{ProductID: 2, Totals: Counters{Views: 10, Orders: 5}},
+	}
+	tests := []struct {
+		name    string
+		weights Weights
+		want    []float64
+	}{
+		{"views only", Weights{Views: 1}, []float64{0.75, 0.25}},
+		{"orders only", Weights{Orders: 1}, []float64{0.25, 0.75}},
+		{"weights are normalised", Weights{Views: 3, Orders: 1}, []float64{0.625, 0.375}},
+		{"no weights", Weights{}, []float64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &PercentileScorer{Weights: tt.weights}
+			assertScores(t, s.Score(products, time.Now()), tt.want)
+		})
+	}
+}
+
+func TestTrendingScorerDecaysOldIncrements(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	halfLife := 24 * time.Hour
+	products := []Signals{
+		// 10 views two half-lives ago count as 2.5
+		{ProductID: 1, Increments: []Increment{{At: now.Add(-2 * halfLife), Counters: Counters{Views: 10}}}},
+		// 4 views now count in full
+		{ProductID: 2, Increments: []Increment{{At: now, Counters: Counters{Views: 4}}}},
+		{ProductID: 3},
+	}
+	s := &TrendingScorer{Weights: Weights{Views: 1}, HalfLife: halfLife}
+	assertScores(t, s.Score(products, now), []float64{0.5, 5.0 / 6, 1.0 / 6})
+}
+
+func TestRank(t *testing.T) {
+	got := Rank([]float64{0.5, 0.9, 0.5, 0.1})
+	want := []int{2, 1, 3, 4}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Rank = %v, want %v", got, want)
+		}
+	}
+}
+
+func assertScores(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("scores = %v, want %v", got, want)
+		}
+	}
+}
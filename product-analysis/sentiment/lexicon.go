@@ -0,0 +1,150 @@
+package sentiment
+
+// lexicon holds the word lists of one language. Words are lowercase.
+type lexicon struct {
+	// opinions maps an opinion word, or with stemming a stem, to its
+	// valence: positive for praise and negative for complaints.
+	opinions map[string]float64
+	// phrases maps opinion phrases of two or three words to their valence.
+	// They take precedence over their words.
+	phrases      map[string]float64
+	negators     map[string]bool
+	intensifiers map[string]bool
+	// contrasts are conjunctions that split a sentence into clauses.
+	contrasts map[string]bool
+	aspects   map[string]Aspect
+	stopwords map[string]bool
+	// stemming matches lexicon stems as word prefixes, for agglutinative
+	// languages.
+	stemming bool
+	// postposedNegation means negators follow what they negate.
+	postposedNegation bool
+}
+
+var lexicons = map[Language]*lexicon{
+	English: {
+		opinions: map[string]float64{
+			"good": 1.5, "great": 2, "excellent": 2.5, "amazing": 2.5, "awesome": 2.5,
+			"fantastic": 2.5, "superb": 2.5, "perfect": 2.5, "perfectly": 2, "best": 2,
+			"love": 2, "loved": 2, "loves": 2, "like": 1, "liked": 1, "nice": 1.5,
+			"beautiful": 2, "lovely": 2, "happy": 1.5, "pleased": 1.5, "satisfied": 1.5,
+			"recommend": 1.5, "recommended": 1.5, "comfortable": 1.5, "comfy": 1.5,
+			"soft": 1, "sturdy": 1.5, "durable": 1.5, "fast": 1, "quick": 1, "quickly": 1,
+			"fine": 0.5, "worth": 1, "bargain": 1.5, "thanks": 1, "thank": 1,
+			"bad": -1.5, "poor": -1.5, "poorly": -1.5, "terrible": -2.5, "awful": -2.5,
+			"horrible": -2.5, "worst": -2.5, "hate": -2.5, "hated": -2.5, "useless": -2,
+			"waste": -2, "broken": -2, "broke": -2, "damaged": -2, "defective": -2,
+			"faulty": -2, "ripped": -2, "torn": -2, "fake": -2, "disappointed": -2,
+			"disappointing": -2, "disappointment": -2, "flimsy": -1.5, "cheaply": -1,
+			"uncomfortable": -1.5, "wrong": -1.5, "missing": -1.5, "late": -1.5,
+			"delayed": -1.5, "slow": -1, "overpriced": -1.5, "expensive": -1,
+			"faded": -1.5, "scratched": -1.5, "stained": -1.5, "smells": -1, "smelly": -1.5,
+			"rude": -2, "problem": -1, "problems": -1, "issue": -1, "issues": -1,
+			"returned": -1, "refund": -1, "tight": -1, "itchy": -1.5,
+		},
+		phrases: map[string]float64{
+			"too small": -1.5, "too big": -1.5, "too large": -1.5, "too tight": -1.5,
+			"too loose": -1.5, "too short": -1.5, "too long": -1.5, "runs small": -1.5,
+			"runs large": -1.5, "runs big": -1.5, "true to size": 1.5, "fits well": 1.5,
+			"fits perfectly": 2, "value for money": 1.5, "never arrived": -2.5,
+			"not worth": -1.5, "fell apart": -2, "came apart": -2, "high quality": 2,
+			"low quality": -2, "poor quality": -2, "cheap quality": -2,
+		},
+		negators: set("not", "no", "never", "nothing", "hardly", "don't", "dont",
+			"doesn't", "doesnt", "didn't", "didnt", "isn't", "isnt", "wasn't", "wasnt",
+			"aren't", "arent", "weren't", "werent", "won't", "wont", "can't", "cant",
+			"couldn't", "couldnt", "wouldn't", "wouldnt", "without"),
+		intensifiers: set("very", "really", "so", "extremely", "super", "too", "absolutely",
+			"totally", "highly", "incredibly", "quite"),
+		contrasts: set("but", "however", "although", "though", "yet"),
+		aspects: map[string]Aspect{
+			"size": Sizing, "sizes": Sizing, "sizing": Sizing, "fit": Sizing, "fits": Sizing,
+			"fitting": Sizing, "small": Sizing, "smaller": Sizing, "large": Sizing,
+			"larger": Sizing, "big": Sizing, "bigger": Sizing, "tight": Sizing,
+			"loose": Sizing, "length": Sizing, "narrow": Sizing, "wide": Sizing,
+			"shipping": Shipping, "delivery": Shipping, "delivered": Shipping,
+			"arrived": Shipping, "arrive": Shipping, "courier": Shipping, "cargo": Shipping,
+			"shipment": Shipping, "shipped": Shipping, "late": Shipping, "delayed": Shipping,
+			"quality": Quality, "material": Quality, "materials": Quality, "fabric": Quality,
+			"stitching": Quality, "seams": Quality, "durable": Quality, "sturdy": Quality,
+			"flimsy": Quality, "broke": Quality, "broken": Quality, "defective": Quality,
+			"faulty": Quality, "ripped": Quality, "torn": Quality, "faded": Quality,
+			"price": Price, "priced": Price, "cheap": Price, "expensive": Price,
+			"overpriced": Price, "worth": Price, "value": Price, "money": Price,
+			"bargain": Price, "discount": Price,
+			"packaging": Packaging, "packaged": Packaging, "package": Packaging,
+			"box": Packaging, "wrapped": Packaging, "wrapping": Packaging,
+			"seller": Service, "service": Service, "support": Service, "customer": Service,
+			"refund": Service, "return": Service, "returned": Service, "rude": Service,
+		},
+		stopwords: set("the", "a", "an", "and", "or", "is", "it", "it's", "its", "was",
+			"were", "be", "been", "are", "this", "that", "these", "those", "for", "with",
+			"to", "of", "in", "on", "at", "as", "my", "i", "i'm", "me", "we", "you", "they",
+			"he", "she", "them", "his", "her", "our", "your", "their", "have", "has", "had",
+			"but", "if", "so", "than", "then", "very", "just", "also", "from", "after",
+			"about", "would", "will", "can", "could", "did", "does", "do", "get", "got",
+			"one", "all", "any", "some", "much", "more", "what", "when", "which", "who",
+			"product", "item", "bought", "buy", "order", "ordered"),
+	},
+	Turkish: {
+		opinions: map[string]float64{
+			"güzel": 2, "harika": 2.5, "mükemmel": 2.5, "muhteşem": 2.5, "süper": 2,
+			"kusursuz": 2.5, "iyi": 1.5, "iyiydi": 1.5, "iyidir": 1.5, "beğen": 2,
+			"bayıl": 2.5, "memnun": 2, "tavsiye": 1.5, "öneri": 1.5, "kaliteli": 2,
+			"hızlı": 1.5, "sağlam": 1.5, "rahat": 1.5, "şık": 1.5, "şıktı": 1.5,
+			"teşekkür": 1, "sorunsuz": 2, "uygun": 1, "değer": 1, "özenli": 1.5,
+			"yumuşak": 1, "tatmin": 1.5,
+			"kötü": -2, "berbat": -2.5, "rezalet": -2.5, "rezil": -2.5, "beğenme": -2,
+			"kalitesiz": -2, "bozuk": -2, "bozul": -2, "kırık": -2, "kırıl": -2,
+			"yırtık": -2, "yırtıl": -2, "hasarlı": -2, "defolu": -2, "sahte": -2,
+			"gecik": -1.5, "geç": -1.5, "pişman": -2, "iade": -1, "sorun": -1.5,
+			"problem": -1.5, "maalesef": -1, "malesef": -1, "pahalı": -1, "değmez": -1.5,
+			"yavaş": -1, "eksik": -1.5, "yanlış": -1.5, "ilgisiz": -1.5, "gelmedi": -2,
+			"soldu": -1.5, "solmuş": -1.5, "kokuyor": -1, "kokusu": -1, "dar": -1,
+			"dardı": -1, "vasat": -1, "rahatsız": -1.5, "uygunsuz": -1.5, "çöp": -2,
+			"ulaşmadı": -2,
+		},
+		phrases: map[string]float64{
+			"küçük geldi": -1.5, "büyük geldi": -1.5, "dar geldi": -1.5, "bol geldi": -1,
+			"kısa geldi": -1.5, "uzun geldi": -1, "kalıbı küçük": -1.5, "kalıbı büyük": -1.5,
+			"tam oldu": 1.5, "tam kalıp": 1.5, "hayal kırıklığı": -2, "fiyat performans": 1.5,
+			"paranıza değer": 1.5, "elime ulaşmadı": -2.5,
+		},
+		negators: set("değil", "değildi", "değilim", "değildir", "yok", "yoktu",
+			"kalmadım", "kalmadık", "kalmadı", "olmadı", "olmamış", "olmuyor", "olmaz",
+			"etmedi", "etmiyor", "etmiyorum", "etmem", "etmiyoruz"),
+		intensifiers: set("çok", "gerçekten", "aşırı", "oldukça", "fazla", "cidden",
+			"hiç", "epey", "tam"),
+		contrasts: set("ama", "fakat", "ancak", "lakin", "yalnız"),
+		aspects: map[string]Aspect{
+			"beden": Sizing, "kalıp": Sizing, "kalıb": Sizing, "numara": Sizing,
+			"boy": Sizing, "boyu": Sizing, "dar": Sizing, "bol": Sizing, "küçük": Sizing,
+			"büyük": Sizing, "uzun": Sizing, "kısa": Sizing,
+			"kargo": Shipping, "teslim": Shipping, "kurye": Shipping, "gönderi": Shipping,
+			"gecik": Shipping, "geç": Shipping, "ulaştı": Shipping, "paketleme": Packaging,
+			"kalite": Quality, "kumaş": Quality, "malzeme": Quality, "dikiş": Quality,
+			"sağlam": Quality, "bozuk": Quality, "bozul": Quality, "kırık": Quality,
+			"kırıl": Quality, "yırtık": Quality, "yırtıl": Quality, "defolu": Quality,
+			"fiyat": Price, "pahalı": Price, "ucuz": Price, "para": Price, "değer": Price,
+			"değmez": Price, "indirim": Price,
+			"paket": Packaging, "ambalaj": Packaging, "kutu": Packaging,
+			"satıcı": Service, "mağaza": Service, "iade": Service, "müşteri": Service,
+			"hizmet": Service, "iletişim": Service,
+		},
+		stopwords: set("ve", "bir", "bu", "şu", "o", "da", "de", "ile", "için", "gibi",
+			"ama", "çok", "daha", "en", "mi", "mı", "mu", "mü", "ben", "biz", "siz", "ne",
+			"ki", "diye", "olarak", "kadar", "sonra", "önce", "her", "hem", "ya", "veya",
+			"ürün", "ürünü", "ürünün", "aldım", "aldık", "sipariş", "geldi", "bence",
+			"gayet", "bile", "ise", "şey", "tek", "zaten", "artık"),
+		stemming:          true,
+		postposedNegation: true,
+	},
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
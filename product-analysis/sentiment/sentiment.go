@@ -0,0 +1,298 @@
+// Package sentiment scores review text and finds the aspects of a purchase
+// it talks about, such as sizing or shipping. It is rule- and lexicon-based,
+// supports Turkish and English, and needs no external service.
+package sentiment
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Language string
+
+const (
+	English Language = "en"
+	Turkish Language = "tr"
+)
+
+type Aspect string
+
+const (
+	Sizing    Aspect = "sizing"
+	Shipping  Aspect = "shipping"
+	Quality   Aspect = "quality"
+	Price     Aspect = "price"
+	Packaging Aspect = "packaging"
+	Service   Aspect = "service"
+)
+
+// Aspects lists every aspect Analyze can report, in reporting order.
+var Aspects = []Aspect{Sizing, Shipping, Quality, Price, Packaging, Service}
+
+const (
+	// Labels of a score; see Label.
+	Positive = "positive"
+	Neutral  = "neutral"
+	Negative = "negative"
+
+	// NeutralBand is how far from 0 a score must be to count as an opinion.
+	NeutralBand = 0.1
+	// normalization squashes a summed valence into (-1, 1); larger values
+	// need more opinion words to approach the ends.
+	normalization = 4
+	// negationWindow is how many words from an opinion a negator may be:
+	// before it in English, after it in Turkish.
+	negationWindow = 3
+	// minStemLength is the shortest Turkish lexicon stem matched as a prefix
+	// of a word. Shorter entries only match whole words.
+	minStemLength = 4
+	maxKeywords   = 5
+)
+
+// AspectMention is an aspect a review talks about, with the score of the
+// clauses mentioning it.
+type AspectMention struct {
+	Aspect Aspect
+	Score  float64
+}
+
+type Result struct {
+	Language Language
+	// Score is the review's sentiment in (-1, 1); 0 when it holds no
+	// opinion words.
+	Score float64
+	// Aspects holds each aspect mentioned, in Aspects order.
+	Aspects []AspectMention
+	// Keywords are the review's most frequent content words, most frequent
+	// first.
+	Keywords []string
+}
+
+// Label names the sentiment of a score.
+func Label(score float64) string {
+	switch {
+	case score > NeutralBand:
+		return Positive
+	case score < -NeutralBand:
+		return Negative
+	default:
+		return Neutral
+	}
+}
+
+// Analyze scores text and extracts its aspects and keywords.
+func Analyze(text string) Result {
+	lang := DetectLanguage(text)
+	lex := lexicons[lang]
+	if lang == Turkish {
+		text = strings.ToLowerSpecial(unicode.TurkishCase, text)
+	} else {
+		text = strings.ToLower(text)
+	}
+
+	result := Result{Language: lang}
+	var total float64
+	aspectScores := make(map[Aspect][]float64)
+	frequency := make(map[string]int)
+	for _, clause := range clauses(text, lex) {
+		valence := lex.valence(clause)
+		total += valence
+		mentioned := make(map[Aspect]bool)
+		for _, word := range clause {
+			if aspect, ok := lex.aspect(word); ok && !mentioned[aspect] {
+				mentioned[aspect] = true
+				aspectScores[aspect] = append(aspectScores[aspect], normalize(valence))
+			}
+			if lex.isKeyword(word) {
+				frequency[word]++
+			}
+		}
+	}
+
+	result.Score = normalize(total)
+	for _, aspect := range Aspects {
+		if scores, ok := aspectScores[aspect]; ok {
+			var sum float64
+			for _, s := range scores {
+				sum += s
+			}
+			result.Aspects = append(result.Aspects, AspectMention{Aspect: aspect, Score: sum / float64(len(scores))})
+		}
+	}
+	result.Keywords = topKeywords(frequency)
+	return result
+}
+
+// DetectLanguage tells Turkish from English by Turkish-only letters and
+// common function words. Text with no evidence either way is English.
+func DetectLanguage(text string) Language {
+	var turkish, english int
+	for _, r := range text {
+		if strings.ContainsRune("çğıöşüÇĞİÖŞÜ", r) {
+			turkish++
+		}
+	}
+	for _, word := range words(strings.ToLower(text)) {
+		if lexicons[Turkish].stopwords[word] {
+			turkish++
+		}
+		if lexicons[English].stopwords[word] {
+			english++
+		}
+	}
+	if turkish > english {
+		return Turkish
+	}
+	return English
+}
+
+func normalize(valence float64) float64 {
+	return valence / math.Sqrt(valence*valence+normalization)
+}
+
+// clauses splits lowercased text into clauses of words, on sentence
+// punctuation and on contrastive conjunctions such as "but", whose sides
+// usually carry opposite opinions.
+func clauses(text string, lex *lexicon) [][]string {
+	var result [][]string
+	for _, sentence := range strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(".!?;\n", r)
+	}) {
+		var clause []string
+		for _, word := range words(sentence) {
+			if lex.contrasts[word] {
+				if len(clause) > 0 {
+					result = append(result, clause)
+				}
+				clause = nil
+				continue
+			}
+			clause = append(clause, word)
+		}
+		if len(clause) > 0 {
+			result = append(result, clause)
+		}
+	}
+	return result
+}
+
+// words splits text into words of letters, digits and apostrophes.
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func topKeywords(frequency map[string]int) []string {
+	keywords := make([]string, 0, len(frequency))
+	for word := range frequency {
+		keywords = append(keywords, word)
+	}
+	sort.Slice(keywords, func(a, b int) bool {
+		if frequency[keywords[a]] != frequency[keywords[b]] {
+			return frequency[keywords[a]] > frequency[keywords[b]]
+		}
+		return keywords[a] < keywords[b]
+	})
+	if len(keywords) > maxKeywords {
+		keywords = keywords[:maxKeywords]
+	}
+	return keywords
+}
+
+// valence sums the valence of a clause's opinion words and phrases, scaled
+// by the intensifiers before them and flipped by negators.
+func (lex *lexicon) valence(clause []string) float64 {
+	var total float64
+	for i := 0; i < len(clause); i++ {
+		value, length := lex.opinion(clause, i)
+		if value == 0 {
+			continue
+		}
+		if i > 0 && lex.intensifiers[clause[i-1]] {
+			value *= 1.5
+		}
+		if lex.negated(clause, i, length) {
+			value = -value * 0.75
+		}
+		total += value
+		i += length - 1
+	}
+	return total
+}
+
+// opinion returns the valence of the phrase or word starting at clause[i]
+// and how many words it spans.
+func (lex *lexicon) opinion(clause []string, i int) (float64, int) {
+	for length := 3; length > 1; length-- {
+		if i+length <= len(clause) {
+			if value, ok := lex.phrases[strings.Join(clause[i:i+length], " ")]; ok {
+				return value, length
+			}
+		}
+	}
+	if value, ok := lookup(lex, lex.opinions, clause[i]); ok {
+		return value, 1
+	}
+	return 0, 1
+}
+
+// negated reports whether the opinion at clause[i:i+length] is negated.
+// English negators come before the opinion; Turkish ones, like "değil",
+// follow it.
+func (lex *lexicon) negated(clause []string, i, length int) bool {
+	if lex.postposedNegation {
+		for j := i + length; j < len(clause) && j < i+length+negationWindow; j++ {
+			if _, ok := lookup(lex, lex.negators, clause[j]); ok {
+				return true
+			}
+		}
+		return false
+	}
+	for j := i - 1; j >= 0 && j >= i-negationWindow; j-- {
+		if _, ok := lookup(lex, lex.negators, clause[j]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (lex *lexicon) aspect(word string) (Aspect, bool) {
+	return lookup(lex, lex.aspects, word)
+}
+
+func (lex *lexicon) isKeyword(word string) bool {
+	if utf8.RuneCountInString(word) < 3 || lex.stopwords[word] || lex.intensifiers[word] {
+		return false
+	}
+	if _, ok := lookup(lex, lex.negators, word); ok {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup finds word in entries. With stemming, the longest entry of at
+// least minStemLength letters that prefixes the word also matches, so
+// "güzeldi" matches "güzel" while "beğenmedim" matches "beğenme" rather
+// than "beğen".
+func lookup[V any](lex *lexicon, entries map[string]V, word string) (V, bool) {
+	if value, ok := entries[word]; ok || !lex.stemming {
+		return value, ok
+	}
+	runes := []rune(word)
+	for n := len(runes) - 1; n >= minStemLength; n-- {
+		if value, ok := entries[string(runes[:n])]; ok {
+			return value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+)
+
+const (
+	defaultWindowDays = 30
+	maxWindowDays     = 365
+)
+
+// priceWindow summarises a product's variant prices over a window.
+type priceWindow struct {
+	ChangePercent float64
+	Min           float64
+	Max           float64
+	Avg           float64
+	History       []models.PriceHistory
+}
+
+// stockWindow summarises a product's variant stock over a window.
+type stockWindow struct {
+	ChangePercent float64
+	// Velocity is units sold per day, inferred from stock decreases.
+	Velocity float64
+}
+
+// favoriteWindow summarises favorite growth over a window.
+type favoriteWindow struct {
+	Gained      int
+	RatePerDay  float64
+	LatestCount int
+}
+
+// offerPrice is the sale price in effect for a variant from one seller at
+// some instant.
+type offerPrice struct {
+	VariantID uint
+	SellerID  *uint
+	NewPrice  decimal.Decimal
+}
+
+// variantStock is the stock in effect for a variant at some instant.
+type variantStock struct {
+	VariantID   uint
+	NewQuantity int
+}
+
+func variantIDsQuery(db *gorm.DB, productID uint) *gorm.DB {
+	return db.Table("product_variants").Select("id").Where("product_id = ?", productID)
+}
+
+// computePriceWindow compares each offer's sale price at the start of the
+// window with its latest price, where an offer is a variant sold by one
+// seller. An offer first seen inside the window starts at the old price of
+// its first change. sellerID restricts the window to one seller's offers.
+func computePriceWindow(db *gorm.DB, productID uint, sellerID *uint, since time.Time) (*priceWindow, error) {
+	var startPrices []offerPrice
+	if err := whereSeller(db.Table("price_history"), sellerID).
+		Select("DISTINCT ON (variant_id, seller_id) variant_id, seller_id, new_price").
+		Where("variant_id IN (?) AND changed_at <= ?", variantIDsQuery(db, productID), since).
+		Order("variant_id, seller_id, changed_at DESC").
+		Scan(&startPrices).Error; err != nil {
+		return nil, err
+	}
+
+	var history []models.PriceHistory
+	if err := whereSeller(db, sellerID).
+		Where("variant_id IN (?) AND changed_at > ?", variantIDsQuery(db, productID), since).
+		Order("changed_at ASC").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	start := make(map[offerKey]float64, len(startPrices))
+	for _, p := range startPrices {
+		start[newOfferKey(p.VariantID, p.SellerID)] = p.NewPrice.InexactFloat64()
+	}
+	current := make(map[offerKey]float64, len(start))
+	for key, price := range start {
+		current[key] = price
+	}
+
+	window := &priceWindow{Min: math.Inf(1), Max: math.Inf(-1)}
+	var sum float64
+	var points int
+	observe := func(price float64) {
+		window.Min = math.Min(window.Min, price)
+		window.Max = math.Max(window.Max, price)
+		sum += price
+		points++
+	}
+	for _, price := range start {
+		observe(price)
+	}
+	for _, h := range history {
+		key := newOfferKey(h.VariantID, h.SellerID)
+		if _, ok := start[key]; !ok {
+			start[key] = h.OldPrice.InexactFloat64()
+			observe(start[key])
+		}
+		current[key] = h.NewPrice.InexactFloat64()
+		observe(current[key])
+	}
+
+	if points == 0 {
+		return &priceWindow{}, nil
+	}
+	window.Avg = sum / float64(points)
+	window.ChangePercent = percentChange(meanOf(start), meanOf(current))
+
+	// Newest first, as GetProductAnalytics has always returned it
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	window.History = history
+	return window, nil
+}
+
+// computeStockWindow compares total stock at the start of the window with
+// the latest stock and averages the decreases in between over the days
+// observed. Restocks do not count as sales.
+func computeStockWindow(db *gorm.DB, productID uint, since, now time.Time) (*stockWindow, error) {
+	var startStock []variantStock
+	if err := db.Raw(`SELECT DISTINCT ON (variant_id) variant_id, new_quantity
+		FROM stock_history
+		WHERE variant_id IN (?) AND changed_at <= ?
+		ORDER BY variant_id, changed_at DESC`, variantIDsQuery(db, productID), since).
+		Scan(&startStock).Error; err != nil {
+		return nil, err
+	}
+
+	var history []models.StockHistory
+	if err := db.Where("variant_id IN (?) AND changed_at > ?", variantIDsQuery(db, productID), since).
+		Order("changed_at ASC").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	start := make(map[uint]int, len(startStock))
+	for _, s := range startStock {
+		start[s.VariantID] = s.NewQuantity
+	}
+	current := make(map[uint]int, len(start))
+	for id, quantity := range start {
+		current[id] = quantity
+	}
+
+	observedFrom := now
+	if len(startStock) > 0 {
+		observedFrom = since
+	}
+
+	sold := 0
+	for _, h := range history {
+		if _, ok := start[h.VariantID]; !ok {
+			start[h.VariantID] = h.OldQuantity
+		}
+		if h.ChangedAt.Before(observedFrom) {
+			observedFrom = h.ChangedAt
+		}
+		if h.NewQuantity < h.OldQuantity {
+			sold += h.OldQuantity - h.NewQuantity
+		}
+		current[h.VariantID] = h.NewQuantity
+	}
+
+	startTotal, currentTotal := 0, 0
+	for id := range start {
+		startTotal += start[id]
+		currentTotal += current[id]
+	}
+
+	return &stockWindow{
+		ChangePercent: percentChange(float64(startTotal), float64(currentTotal)),
+		Velocity:      float64(sold) / observedDays(observedFrom, now),
+	}, nil
+}
+
+// computeFavoriteWindow compares the favorite count at the start of the
+// window, or the first snapshot inside it, with the latest snapshot.
+func computeFavoriteWindow(db *gorm.DB, productID uint, since, now time.Time) (*favoriteWindow, error) {
+	var latest models.EngagementSnapshot
+	result := db.Where("product_id = ?", productID).Order("captured_at DESC").Limit(1).Find(&latest)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &favoriteWindow{}, nil
+	}
+
+	var first models.EngagementSnapshot
+	result = db.Where("product_id = ? AND captured_at <= ?", productID, since).
+		Order("captured_at DESC").Limit(1).Find(&first)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	observedFrom := since
+	if result.RowsAffected == 0 {
+		if err := db.Where("product_id = ? AND captured_at > ?", productID, since).
+			Order("captured_at ASC").Limit(1).Find(&first).Error; err != nil {
+			return nil, err
+		}
+		observedFrom = first.CapturedAt
+	}
+
+	gained := latest.FavoriteCount - first.FavoriteCount
+	return &favoriteWindow{
+		Gained:      gained,
+		RatePerDay:  float64(gained) / observedDays(observedFrom, now),
+		LatestCount: latest.FavoriteCount,
+	}, nil
+}
+
+func percentChange(from, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return (to - from) / from * 100
+}
+
+func meanOf[K comparable](values map[K]float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// observedDays is the length of [from, now] in days, at least one so a
+// product seen for a few hours does not report inflated rates.
+func observedDays(from, now time.Time) float64 {
+	return math.Max(now.Sub(from).Hours()/24, 1)
+}
@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+const (
+	// analyzeChunkSize is how many products are analysed in one transaction,
+	// and the most AnalyzeProductsBatch accepts.
+	analyzeChunkSize = 250
+	// insertBatchSize bounds the rows of one multi-row INSERT.
+	insertBatchSize = 1000
+)
+
+// productAnalysis follows one product through a batch analysis. Once err is
+// set the product is skipped by the remaining steps.
+type productAnalysis struct {
+	product   *pb.ProductData
+	productID uint
+	sellerID  *uint
+	// prices holds each variant's reported prices, in the order reported.
+	prices        []priceObservation
+	notifications []string
+	err           error
+}
+
+func (s *ProductAnalysisService) AnalyzeProduct(ctx context.Context, req *pb.AnalyzeProductRequest) (*pb.AnalyzeProductResponse, error) {
+	analysis := s.analyzeProducts(ctx, []*pb.ProductData{req.GetProduct()})[0]
+	if analysis.err != nil {
+		return nil, analysis.err
+	}
+
+	return &pb.AnalyzeProductResponse{
+		Status:        "success",
+		Notifications: analysis.notifications,
+	}, nil
+}
+
+func (s *ProductAnalysisService) AnalyzeProductsBatch(ctx context.Context, req *pb.AnalyzeProductsBatchRequest) (*pb.AnalyzeProductsResponse, error) {
+	if len(req.Products) > analyzeChunkSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d products per batch, stream larger sets with AnalyzeProducts", analyzeChunkSize)
+	}
+	return analysisResults(s.analyzeProducts(ctx, req.Products)), nil
+}
+
+func (s *ProductAnalysisService) AnalyzeProducts(stream pb.ProductAnalysisService_AnalyzeProductsServer) error {
+	ctx := stream.Context()
+
+	var analyses []*productAnalysis
+	var pending []*pb.ProductData
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		pending = append(pending, req.GetProduct())
+		if len(pending) == analyzeChunkSize {
+			analyses = append(analyses, s.analyzeProducts(ctx, pending)...)
+			pending = nil
+		}
+	}
+	analyses = append(analyses, s.analyzeProducts(ctx, pending)...)
+
+	return stream.SendAndClose(analysisResults(analyses))
+}
+
+// analyzeProducts analyses a chunk of products in one transaction. Products
+// that are malformed or unknown fail on their own; a database error fails
+// every product still in the chunk.
+func (s *ProductAnalysisService) analyzeProducts(ctx context.Context, products []*pb.ProductData) []*productAnalysis {
+	analyses := make([]*productAnalysis, len(products))
+	// A product may be reported once per seller
+	type offer struct{ productID, sellerID string }
+	seen := make(map[offer]bool, len(products))
+	for i, product := range products {
+		analyses[i] = &productAnalysis{product: product}
+		analyses[i].prices, analyses[i].err = parseProduct(product)
+		if analyses[i].err != nil {
+			continue
+		}
+		key := offer{product.Id, product.SellerId}
+		if seen[key] {
+			analyses[i].err = status.Errorf(codes.InvalidArgument, "product %q of seller %q is listed more than once", product.Id, product.SellerId)
+		}
+		seen[key] = true
+	}
+	if len(pendingAnalyses(analyses)) == 0 {
+		return analyses
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProducts(tx, pendingAnalyses(analyses)); err != nil {
+			return err
+		}
+		if err := resolveSellers(tx, pendingAnalyses(analyses)); err != nil {
+			return err
+		}
+		if err := resolveVariants(tx, pendingAnalyses(analyses)); err != nil {
+			return err
+		}
+		return recordAnalyses(tx, pendingAnalyses(analyses), time.Now())
+	})
+	if err != nil {
+		for _, analysis := range pendingAnalyses(analyses) {
+			analysis.err = status.Errorf(codes.Internal, "failed to analyze product %q: %v", analysis.product.Id, err)
+			analysis.notifications = nil
+		}
+	}
+	return analyses
+}
+
+// parseProduct checks a reported product and reads its variants' prices.
+func parseProduct(product *pb.ProductData) ([]priceObservation, error) {
+	if product == nil || product.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "product external ID is required")
+	}
+
+	prices := make([]priceObservation, len(product.Variants))
+	seen := make(map[string]bool, len(product.Variants))
+	for i, variant := range product.Variants {
+		if variant.Id == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "variant %d of product %q has no external ID", i, product.Id)
+		}
+		if seen[variant.Id] {
+			return nil, status.Errorf(codes.InvalidArgument, "variant %q of product %q is listed more than once", variant.Id, product.Id)
+		}
+		seen[variant.Id] = true
+
+		var err error
+		if prices[i], err = parsePrices(variant, product.Currency); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "variant %q of product %q: %v", variant.Id, product.Id, err)
+		}
+	}
+	return prices, nil
+}
+
+func pendingAnalyses(analyses []*productAnalysis) []*productAnalysis {
+	var pending []*productAnalysis
+	for _, analysis := range analyses {
+		if analysis.err == nil {
+			pending = append(pending, analysis)
+		}
+	}
+	return pending
+}
+
+// lockProducts resolves each product's external ID to its internal ID and
+// locks the product rows until the transaction ends. Concurrent analyses of
+// the same product are serialised, so each sees the history the one before
+// it wrote. Rows are locked in ID order so overlapping batches cannot
+// deadlock, and NO KEY UPDATE leaves inserts that reference the products
+// unblocked.
+func lockProducts(tx *gorm.DB, analyses []*productAnalysis) error {
+	externalIDs := make([]string, len(analyses))
+	for i, analysis := range analyses {
+		externalIDs[i] = analysis.product.Id
+	}
+
+	var rows []struct {
+		ID         uint
+		ExternalID string
+	}
+	if err := tx.Table("products").Select("id, external_id").
+		Where("external_id IN ?", externalIDs).
+		Order("id").
+		Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).
+		Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to lock products: %v", err)
+	}
+	internal := make(map[string]uint, len(rows))
+	for _, row := range rows {
+		internal[row.ExternalID] = row.ID
+	}
+
+	for _, analysis := range analyses {
+		id, ok := internal[analysis.product.Id]
+		if !ok {
+			analysis.err = status.Errorf(codes.NotFound, "product %q not found", analysis.product.Id)
+			continue
+		}
+		analysis.productID = id
+	}
+	return nil
+}
+
+// resolveSellers resolves each product's external seller ID to the internal
+// ID. Products without a seller keep a nil seller.
+func resolveSellers(tx *gorm.DB, analyses []*productAnalysis) error {
+	var externalIDs []string
+	for _, analysis := range analyses {
+		if analysis.product.SellerId != "" {
+			externalIDs = append(externalIDs, analysis.product.SellerId)
+		}
+	}
+	if len(externalIDs) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		ID         uint
+		ExternalID string
+	}
+	if err := tx.Table("sellers").Select("id, external_id").
+		Where("external_id IN ?", externalIDs).
+		Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to resolve sellers: %v", err)
+	}
+	internal := make(map[string]uint, len(rows))
+	for _, row := range rows {
+		internal[row.ExternalID] = row.ID
+	}
+
+	for _, analysis := range analyses {
+		if analysis.product.SellerId == "" {
+			continue
+		}
+		id, ok := internal[analysis.product.SellerId]
+		if !ok {
+			analysis.err = status.Errorf(codes.NotFound, "seller %q of product %q not found", analysis.product.SellerId, analysis.product.Id)
+			continue
+		}
+		analysis.sellerID = &id
+	}
+	return nil
+}
+
+// resolveVariants maps each reported variant's external ID to the internal
+// ID of that variant of its product, in the order reported.
+func resolveVariants(tx *gorm.DB, analyses []*productAnalysis) error {
+	productIDs := make([]uint, len(analyses))
+	for i, analysis := range analyses {
+		productIDs[i] = analysis.productID
+	}
+
+	type variantKey struct {
+		productID  uint
+		externalID string
+	}
+	var rows []struct {
+		ID                uint
+		ProductID         uint
+		ExternalVariantID string
+	}
+	if err := tx.Table("product_variants").Select("id, product_id, external_variant_id").
+		Where("product_id IN ? AND external_variant_id IS NOT NULL", productIDs).
+		Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to resolve variants: %v", err)
+	}
+	internal := make(map[variantKey]uint, len(rows))
+	for _, row := range rows {
+		internal[variantKey{row.ProductID, row.ExternalVariantID}] = row.ID
+	}
+
+	for _, analysis := range analyses {
+		for i, variant := range analysis.product.Variants {
+			id, ok := internal[variantKey{analysis.productID, variant.Id}]
+			if !ok {
+				analysis.err = status.Errorf(codes.NotFound, "variant %q of product %q not found", variant.Id, analysis.product.Id)
+				break
+			}
+			analysis.prices[i].VariantID = id
+			analysis.prices[i].SellerID = analysis.sellerID
+		}
+	}
+	return nil
+}
+
+// variantStates is the last recorded price of every seller of a set of
+// variants and the last recorded stock of each variant.
+type variantStates struct {
+	prices map[offerKey]models.PriceHistory
+	stocks map[uint]int
+}
+
+// loadVariantStates reads the last recorded prices and stock of every
+// variant in one query. A variant yields one row per seller it has prices
+// from, or a single row without a price when it has none.
+func loadVariantStates(tx *gorm.DB, variantIDs []uint) (*variantStates, error) {
+	query := `SELECT v.id AS variant_id, s.new_quantity AS quantity,
+			p.id AS price_id, p.seller_id, p.currency, p.new_price, p.list_price, p.basket_price, p.promotions
+		FROM product_variants v
+		LEFT JOIN LATERAL (
+			SELECT new_quantity FROM stock_history
+			WHERE variant_id = v.id
+			ORDER BY changed_at DESC, id DESC
+			LIMIT 1
+		) s ON true
+		LEFT JOIN LATERAL (
+			SELECT DISTINCT ON (seller_id) id, seller_id, currency, new_price, list_price, basket_price, promotions
+			FROM price_history
+			WHERE variant_id = v.id
+			ORDER BY seller_id, changed_at DESC, id DESC
+		) p ON true
+		WHERE v.id IN ?`
+
+	var rows []struct {
+		VariantID   uint
+		Quantity    *int
+		PriceID     *uint
+		SellerID    *uint
+		Currency    string
+		NewPrice    decimal.Decimal
+		ListPrice   decimal.NullDecimal
+		BasketPrice decimal.NullDecimal
+		Promotions  models.Labels
+	}
+	if err := tx.Raw(query, variantIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	states := &variantStates{
+		prices: make(map[offerKey]models.PriceHistory, len(rows)),
+		stocks: make(map[uint]int, len(rows)),
+	}
+	for _, row := range rows {
+		if row.Quantity != nil {
+			states.stocks[row.VariantID] = *row.Quantity
+		}
+		if row.PriceID != nil {
+			states.prices[newOfferKey(row.VariantID, row.SellerID)] = models.PriceHistory{
+				VariantID:   row.VariantID,
+				SellerID:    row.SellerID,
+				Currency:    row.Currency,
+				NewPrice:    row.NewPrice,
+				ListPrice:   row.ListPrice,
+				BasketPrice: row.BasketPrice,
+				Promotions:  row.Promotions,
+			}
+		}
+	}
+	return states, nil
+}
+
+// recordAnalyses writes the price and stock changes, anomaly flags, analytics
+// counters and engagement snapshots of the analysed products, and collects
+// the notifications they raise.
+func recordAnalyses(tx *gorm.DB, analyses []*productAnalysis, now time.Time) error {
+	if len(analyses) == 0 {
+		return nil
+	}
+
+	var variantIDs []uint
+	for _, analysis := range analyses {
+		for _, o := range analysis.prices {
+			variantIDs = append(variantIDs, o.VariantID)
+		}
+	}
+	states := &variantStates{}
+	if len(variantIDs) > 0 {
+		var err error
+		if states, err = loadVariantStates(tx, variantIDs); err != nil {
+			return fmt.Errorf("failed to load variant history: %v", err)
+		}
+	}
+
+	var prices []models.PriceHistory
+	var stocks []models.StockHistory
+	var observations []priceObservation
+	for _, analysis := range analyses {
+		for i, variant := range analysis.product.Variants {
+			o := analysis.prices[i]
+
+			// Check for price changes. The first record has no previous
+			// price, so it repeats the current one.
+			last, seen := states.prices[o.key()]
+			if !seen || !o.sameAs(last) {
+				oldPrice := o.SalePrice
+				if seen {
+					oldPrice = last.NewPrice
+				}
+				prices = append(prices, models.PriceHistory{
+					VariantID:   o.VariantID,
+					SellerID:    o.SellerID,
+					Currency:    o.Currency,
+					OldPrice:    oldPrice,
+					NewPrice:    o.SalePrice,
+					ListPrice:   o.ListPrice,
+					BasketPrice: o.BasketPrice,
+					Promotions:  o.Promotions,
+					ChangedAt:   now,
+				})
+				if seen && o.Currency == strings.TrimSpace(last.Currency) && o.SalePrice.LessThan(oldPrice) {
+					analysis.notifications = append(analysis.notifications, fmt.Sprintf("price_drop:variant_id=%d:old_price=%s:new_price=%s:currency=%s%s",
+						o.VariantID, oldPrice.StringFixed(2), o.SalePrice.StringFixed(2), o.Currency, sellerSuffix(o.SellerID)))
+				}
+			}
+
+			// Check for stock changes
+			quantity := int(variant.StockQuantity)
+			lastQuantity, stocked := states.stocks[o.VariantID]
+			if !stocked || lastQuantity != quantity {
+				oldQuantity := quantity
+				if stocked {
+					oldQuantity = lastQuantity
+				}
+				stocks = append(stocks, models.StockHistory{
+					VariantID:   o.VariantID,
+					OldQuantity: oldQuantity,
+					NewQuantity: quantity,
+					ChangedAt:   now,
+				})
+				// Another seller's report may carry the same stock change
+				states.stocks[o.VariantID] = quantity
+				if quantity == 0 && stocked {
+					analysis.notifications = append(analysis.notifications, fmt.Sprintf("out_of_stock:variant_id=%d", o.VariantID))
+				}
+			}
+
+			observations = append(observations, o)
+		}
+	}
+
+	if len(prices) > 0 {
+		if err := tx.CreateInBatches(&prices, insertBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to record price history: %v", err)
+		}
+	}
+	if len(stocks) > 0 {
+		if err := tx.CreateInBatches(&stocks, insertBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to record stock history: %v", err)
+		}
+	}
+
+	// Check the prices against their history for fake discounts and outliers
+	anomalies, err := detectPriceAnomalies(tx, observations, now)
+	if err != nil {
+		return fmt.Errorf("failed to check price anomalies: %v", err)
+	}
+
+	// See whether the cheapest in-stock offer moved to another seller
+	buyBoxChanges, err := trackBuyBoxes(tx, variantIDs, now)
+	if err != nil {
+		return fmt.Errorf("failed to track buy boxes: %v", err)
+	}
+
+	// A product reported by several sellers gets one analytics update and
+	// one snapshot, from its last report
+	latest := make(map[uint]*productAnalysis, len(analyses))
+	var order []uint
+	for _, analysis := range analyses {
+		for _, o := range analysis.prices {
+			analysis.notifications = append(analysis.notifications, anomalies[o.key()]...)
+		}
+		if _, ok := latest[analysis.productID]; !ok {
+			order = append(order, analysis.productID)
+		}
+		latest[analysis.productID] = analysis
+	}
+	// Buy box changes concern the variant, not the reporting seller, so
+	// each is reported once
+	for _, productID := range order {
+		analysis := latest[productID]
+		for _, o := range analysis.prices {
+			analysis.notifications = append(analysis.notifications, buyBoxChanges[o.VariantID]...)
+		}
+	}
+
+	analytics := make([]models.ProductAnalytics, len(order))
+	snapshots := make([]models.EngagementSnapshot, len(order))
+	for i, productID := range order {
+		// PopularityScore is left to the scoring job, which ranks products
+		// against the rest of their category
+		product := latest[productID].product
+		analytics[i] = models.ProductAnalytics{
+			ProductID:      productID,
+			ViewCount:      int(product.ViewCount),
+			FavoriteCount:  int(product.FavoriteCount),
+			AddToCartCount: int(product.AddToCartCount),
+			OrderCount:     int(product.OrderCount),
+			LastAnalyzedAt: now,
+		}
+		// Keep the counters' history for windowed analytics
+		snapshots[i] = models.EngagementSnapshot{
+			ProductID:      productID,
+			ViewCount:      int(product.ViewCount),
+			FavoriteCount:  int(product.FavoriteCount),
+			AddToCartCount: int(product.AddToCartCount),
+			OrderCount:     int(product.OrderCount),
+			CapturedAt:     now,
+		}
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "product_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"view_count", "favorite_count", "add_to_cart_count", "order_count", "last_analyzed_at", "updated_at",
+		}),
+	}).CreateInBatches(&analytics, insertBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to save product analytics: %v", err)
+	}
+	if err := tx.CreateInBatches(&snapshots, insertBatchSize).Error; err != nil {
+		return fmt.Errorf("failed to record engagement snapshots: %v", err)
+	}
+	return nil
+}
+
+// sellerSuffix is the seller_id part of a notification, empty when the
+// seller is unknown.
+func sellerSuffix(sellerID *uint) string {
+	if sellerID == nil {
+		return ""
+	}
+	return fmt.Sprintf(":seller_id=%d", *sellerID)
+}
+
+// analysisResults reports each product's outcome in the order analysed.
+func analysisResults(analyses []*productAnalysis) *pb.AnalyzeProductsResponse {
+	resp := &pb.AnalyzeProductsResponse{Results: make([]*pb.AnalyzeProductResult, len(analyses))}
+	for i, analysis := range analyses {
+		result := &pb.AnalyzeProductResult{
+			ProductId:     analysis.product.GetId(),
+			Status:        "success",
+			Notifications: analysis.notifications,
+		}
+		if analysis.err != nil {
+			st := status.Convert(analysis.err)
+			result.Status = "error"
+			result.ErrorCode = st.Code().String()
+			result.Error = st.Message()
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results[i] = result
+	}
+	return resp
+}
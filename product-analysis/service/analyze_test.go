@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// testDB connects to the scratch Postgres database in TEST_DATABASE_URL
+// and migrates it, skipping the test without one.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database handle: %v", err)
+	}
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	return db
+}
+
+// TestAnalyzeProductSerialisesPriceHistory reports two prices for the same
+// variant at once, many times over. lockProducts must serialise the
+// analyses, so the history is one chain: a single first record, and every
+// later record's old price is the new price of the one before it.
+func TestAnalyzeProductSerialisesPriceHistory(t *testing.T) {
+	db := testDB(t)
+	s := NewProductAnalysisService(db)
+
+	externalID := fmt.Sprintf("analyze-test-%d", time.Now().UnixNano())
+	var productID, variantID uint
+	if err := db.Raw("INSERT INTO products (external_id, name) VALUES (?, 'Test product') RETURNING id", externalID).
+		Scan(&productID).Error; err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+	if err := db.Raw("INSERT INTO product_variants (product_id, external_variant_id, price) VALUES (?, 'v1', 0) RETURNING id", productID).
+		Scan(&variantID).Error; err != nil {
+		t.Fatalf("failed to create variant: %v", err)
+	}
+
+	report := func(price string) *pb.AnalyzeProductRequest {
+		return &pb.AnalyzeProductRequest{Product: &pb.ProductData{
+			Id:       externalID,
+			Variants: []*pb.ProductVariant{{Id: "v1", SalePrice: price, StockQuantity: 5}},
+		}}
+	}
+	const rounds = 20
+	for i := 0; i < rounds; i++ {
+		var wg sync.WaitGroup
+		for _, price := range []string{"100.00", "120.00"} {
+			wg.Add(1)
+			go func(price string) {
+				defer wg.Done()
+				if _, err := s.AnalyzeProduct(context.Background(), report(price)); err != nil {
+					t.Errorf("AnalyzeProduct(%s): %v", price, err)
+				}
+			}(price)
+		}
+		wg.Wait()
+	}
+
+	var history []struct {
+		OldPrice decimal.Decimal
+		NewPrice decimal.Decimal
+	}
+	if err := db.Raw("SELECT old_price, new_price FROM price_history WHERE variant_id = ? ORDER BY id", variantID).
+		Scan(&history).Error; err != nil {
+		t.Fatalf("failed to read price history: %v", err)
+	}
+	if len(history) < 2 {
+		t.Fatalf("got %d price records, want at least 2", len(history))
+	}
+	if !history[0].OldPrice.Equal(history[0].NewPrice) {
+		t.Errorf("first record %s -> %s does not repeat its price", history[0].OldPrice, history[0].NewPrice)
+	}
+	for i := 1; i < len(history); i++ {
+		prev, cur := history[i-1], history[i]
+		if !cur.OldPrice.Equal(prev.NewPrice) {
+			t.Errorf("record %d: old price %s, want %s from the record before", i, cur.OldPrice, prev.NewPrice)
+		}
+		if cur.OldPrice.Equal(cur.NewPrice) {
+			t.Errorf("record %d: %s -> %s is a second first record or no change", i, cur.OldPrice, cur.NewPrice)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/anomaly"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// detectPriceAnomalies checks each observed sale price against the history
+// of the same variant and seller, keeps the open anomaly flags in step with
+// what is found, and returns a price_anomaly notification for each newly
+// opened flag, keyed by offer. History and flags for all variants are loaded
+// up front and flags that are merely still present are touched in one
+// statement.
+func detectPriceAnomalies(db *gorm.DB, observations []priceObservation, now time.Time) (map[offerKey][]string, error) {
+	if len(observations) == 0 {
+		return nil, nil
+	}
+	cfg := anomaly.DefaultConfig()
+
+	variantIDs := make([]uint, len(observations))
+	for i, o := range observations {
+		variantIDs[i] = o.VariantID
+	}
+
+	cutoff := now.Add(-cfg.Lookback)
+	var history []models.PriceHistory
+	if err := db.Where("variant_id IN ? AND changed_at >= ?", variantIDs, cutoff).
+		Order("variant_id, changed_at ASC, id ASC").
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to load price history: %v", err)
+	}
+	histories := make(map[offerKey][]models.PriceHistory)
+	for _, h := range history {
+		key := newOfferKey(h.VariantID, h.SellerID)
+		histories[key] = append(histories[key], h)
+	}
+	// The price each offer had when the lookback started
+	var before []models.PriceHistory
+	if err := db.Select("DISTINCT ON (variant_id, seller_id) *").
+		Where("variant_id IN ? AND changed_at < ?", variantIDs, cutoff).
+		Order("variant_id, seller_id, changed_at DESC, id DESC").
+		Find(&before).Error; err != nil {
+		return nil, fmt.Errorf("failed to load price history: %v", err)
+	}
+	seeds := make(map[offerKey]*models.PriceHistory, len(before))
+	for i := range before {
+		seeds[newOfferKey(before[i].VariantID, before[i].SellerID)] = &before[i]
+	}
+
+	var open []models.PriceAnomaly
+	if err := db.Where("variant_id IN ? AND resolved_at IS NULL", variantIDs).Find(&open).Error; err != nil {
+		return nil, fmt.Errorf("failed to load open price anomalies: %v", err)
+	}
+	openFlags := make(map[offerKey]map[string]*models.PriceAnomaly)
+	for i := range open {
+		a := &open[i]
+		key := newOfferKey(a.VariantID, a.SellerID)
+		if openFlags[key] == nil {
+			openFlags[key] = make(map[string]*models.PriceAnomaly)
+		}
+		openFlags[key][a.Kind] = a
+	}
+
+	notifications := make(map[offerKey][]string)
+	var created []models.PriceAnomaly
+	var stillOpen, resolved []uint
+	for _, o := range observations {
+		key := o.key()
+		var originalPrice float64
+		if o.ListPrice.Valid {
+			originalPrice = o.ListPrice.Decimal.InexactFloat64()
+		}
+		flags := anomaly.Detect(anomaly.Input{
+			CurrentPrice:  o.SalePrice.InexactFloat64(),
+			OriginalPrice: originalPrice,
+			History:       pricePoints(seeds[key], histories[key], cutoff, o.SalePrice.InexactFloat64(), now),
+			Now:           now,
+		}, cfg)
+
+		found := make(map[anomaly.Kind]anomaly.Flag, len(flags))
+		for _, flag := range flags {
+			found[flag.Kind] = flag
+		}
+
+		for _, kind := range anomaly.Kinds {
+			existing := openFlags[key][string(kind)]
+			flag, flagged := found[kind]
+			switch {
+			case flagged && existing != nil:
+				if existing.Score == flag.Score && existing.Detail == flag.Detail &&
+					existing.Price.Equal(o.SalePrice) && sameNullDecimal(existing.OriginalPrice, o.ListPrice) {
+					stillOpen = append(stillOpen, existing.ID)
+					continue
+				}
+				err := db.Model(existing).Updates(map[string]interface{}{
+					"score":          flag.Score,
+					"detail":         flag.Detail,
+					"price":          o.SalePrice,
+					"original_price": o.ListPrice,
+					"last_seen_at":   now,
+				}).Error
+				if err != nil {
+					return nil, fmt.Errorf("failed to update price anomaly: %v", err)
+				}
+			case flagged:
+				created = append(created, models.PriceAnomaly{
+					VariantID:     o.VariantID,
+					SellerID:      o.SellerID,
+					Kind:          string(kind),
+					Score:         flag.Score,
+					Detail:        flag.Detail,
+					Price:         o.SalePrice,
+					OriginalPrice: o.ListPrice,
+					DetectedAt:    now,
+					LastSeenAt:    now,
+				})
+				notifications[key] = append(notifications[key], fmt.Sprintf("price_anomaly:variant_id=%d:kind=%s:price=%s:score=%.2f%s",
+					o.VariantID, kind, o.SalePrice.StringFixed(2), flag.Score, sellerSuffix(o.SellerID)))
+			case existing != nil:
+				resolved = append(resolved, existing.ID)
+			}
+		}
+	}
+
+	if len(stillOpen) > 0 {
+		if err := db.Model(&models.PriceAnomaly{}).Where("id IN ?", stillOpen).
+			Update("last_seen_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to update price anomalies: %v", err)
+		}
+	}
+	if len(resolved) > 0 {
+		if err := db.Model(&models.PriceAnomaly{}).Where("id IN ?", resolved).
+			Update("resolved_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to resolve price anomalies: %v", err)
+		}
+	}
+	if len(created) > 0 {
+		if err := db.CreateInBatches(&created, insertBatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to record price anomalies: %v", err)
+		}
+	}
+	return notifications, nil
+}
+
+// pricePoints lists the sale prices in effect over a price series from
+// cutoff to now. seed is the last row before cutoff, whose price was in
+// effect at cutoff, or nil for an offer first seen since. Each later row
+// contributes the price it set, and the first one without a seed the price
+// it replaced; rows that only changed the list or basket price or the
+// promotions add nothing. The series ends with the current price.
+func pricePoints(seed *models.PriceHistory, history []models.PriceHistory, cutoff time.Time, current float64, now time.Time) []anomaly.PricePoint {
+	points := make([]anomaly.PricePoint, 0, len(history)+2)
+	if seed != nil {
+		points = append(points, anomaly.PricePoint{Price: seed.NewPrice.InexactFloat64(), At: cutoff})
+	}
+	for _, h := range history {
+		if len(points) == 0 {
+			points = append(points, anomaly.PricePoint{Price: h.OldPrice.InexactFloat64(), At: h.ChangedAt})
+		}
+		if price := h.NewPrice.InexactFloat64(); price != points[len(points)-1].Price {
+			points = append(points, anomaly.PricePoint{Price: price, At: h.ChangedAt})
+		}
+	}
+	if len(points) == 0 || points[len(points)-1].Price != current {
+		points = append(points, anomaly.PricePoint{Price: current, At: now})
+	}
+	return points
+}
+
+// openPriceAnomalies returns the open anomaly flags on a product's variants,
+// optionally only those of one seller.
+func openPriceAnomalies(db *gorm.DB, productID uint, sellerID *uint) ([]*pb.PriceAnomaly, error) {
+	var anomalies []models.PriceAnomaly
+	if err := whereSeller(db, sellerID).
+		Where("variant_id IN (?) AND resolved_at IS NULL", variantIDsQuery(db, productID)).
+		Order("detected_at DESC").
+		Find(&anomalies).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.PriceAnomaly, len(anomalies))
+	for i, a := range anomalies {
+		result[i] = &pb.PriceAnomaly{
+			VariantId:  fmt.Sprint(a.VariantID),
+			SellerId:   formatSellerID(a.SellerID),
+			Kind:       a.Kind,
+			Score:      float32(a.Score),
+			Detail:     a.Detail,
+			Price:      float32(a.Price.InexactFloat64()),
+			DetectedAt: a.DetectedAt.Format(time.RFC3339),
+			LastSeenAt: a.LastSeenAt.Format(time.RFC3339),
+		}
+		if a.OriginalPrice.Valid {
+			result[i].OriginalPrice = float32(a.OriginalPrice.Decimal.InexactFloat64())
+		}
+	}
+	return result, nil
+}
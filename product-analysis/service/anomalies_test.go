@@ -0,0 +1,89 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/anomaly"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+)
+
+func priceRow(oldPrice, newPrice float64, at time.Time) models.PriceHistory {
+	return models.PriceHistory{
+		OldPrice:  decimal.NewFromFloat(oldPrice),
+		NewPrice:  decimal.NewFromFloat(newPrice),
+		ChangedAt: at,
+	}
+}
+
+func TestPricePoints(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-anomaly.DefaultConfig().Lookback)
+	day := func(daysAgo int) time.Time { return now.AddDate(0, 0, -daysAgo) }
+	seed := priceRow(90, 100, day(200))
+
+	tests := []struct {
+		name    string
+		seed    *models.PriceHistory
+		history []models.PriceHistory
+		current float64
+		want    []anomaly.PricePoint
+	}{
+		{
+			name:    "price unchanged since before the lookback",
+			seed:    &seed,
+			current: 100,
+			want:    []anomaly.PricePoint{{Price: 100, At: cutoff}},
+		},
+		{
+			name:    "changes after the seed",
+			seed:    &seed,
+			history: []models.PriceHistory{priceRow(100, 120, day(30)), priceRow(120, 120, day(20)), priceRow(120, 100, day(0))},
+			current: 100,
+			want:    []anomaly.PricePoint{{Price: 100, At: cutoff}, {Price: 120, At: day(30)}, {Price: 100, At: day(0)}},
+		},
+		{
+			name:    "first seen within the lookback",
+			history: []models.PriceHistory{priceRow(100, 100, day(10)), priceRow(100, 80, day(0))},
+			current: 80,
+			want:    []anomaly.PricePoint{{Price: 100, At: day(10)}, {Price: 80, At: day(0)}},
+		},
+		{
+			name:    "current price not yet recorded",
+			seed:    &seed,
+			current: 90,
+			want:    []anomaly.PricePoint{{Price: 100, At: cutoff}, {Price: 90, At: now}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pricePoints(tt.seed, tt.history, cutoff, tt.current, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("points = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStablePriceWithInflatedListPrice is the common fake discount: the
+// sale price has not moved in longer than the lookback while the list
+// price claims a higher original.
+func TestStablePriceWithInflatedListPrice(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	cfg := anomaly.DefaultConfig()
+	cutoff := now.Add(-cfg.Lookback)
+	seed := priceRow(100, 100, now.AddDate(0, 0, -200))
+
+	flags := anomaly.Detect(anomaly.Input{
+		CurrentPrice:  100,
+		OriginalPrice: 150,
+		History:       pricePoints(&seed, nil, cutoff, 100, now),
+		Now:           now,
+	}, cfg)
+	if len(flags) != 1 || flags[0].Kind != anomaly.NeverChargedOriginal {
+		t.Fatalf("flags = %+v, want one %s", flags, anomaly.NeverChargedOriginal)
+	}
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// defaultBuyBoxRange is how far back GetBuyBoxHistory looks by default.
+const defaultBuyBoxRange = 30 * 24 * time.Hour
+
+// buyBoxOffer is an in-stock offer competing for a variant's buy box.
+type buyBoxOffer struct {
+	VariantID    uint
+	SellerID     uint
+	Currency     string
+	Price        decimal.Decimal
+	ShippingCost decimal.Decimal
+}
+
+func (o buyBoxOffer) total() decimal.Decimal {
+	return o.Price.Add(o.ShippingCost)
+}
+
+// trackBuyBoxes works out which seller holds each variant's buy box from the
+// offers the crawler stored, records a new tenure whenever the holder
+// changes, and returns a buy_box_change notification per change, keyed by
+// variant. The first holder of a variant is recorded without a
+// notification.
+func trackBuyBoxes(db *gorm.DB, variantIDs []uint, now time.Time) (map[uint][]string, error) {
+	if len(variantIDs) == 0 {
+		return nil, nil
+	}
+
+	var offers []buyBoxOffer
+	if err := db.Table("offers").
+		Select("variant_id, seller_id, currency, price, shipping_cost").
+		Where("variant_id IN ? AND is_active AND stock_quantity > 0", variantIDs).
+		Order("variant_id, seller_id").
+		Scan(&offers).Error; err != nil {
+		return nil, fmt.Errorf("failed to load offers: %v", err)
+	}
+	competing := make(map[uint][]buyBoxOffer)
+	for _, o := range offers {
+		o.Currency = strings.TrimSpace(o.Currency)
+		competing[o.VariantID] = append(competing[o.VariantID], o)
+	}
+
+	var current []models.BuyBoxTenure
+	if err := db.Where("variant_id IN ? AND lost_at IS NULL", variantIDs).Find(&current).Error; err != nil {
+		return nil, fmt.Errorf("failed to load buy box holders: %v", err)
+	}
+	holders := make(map[uint]*models.BuyBoxTenure, len(current))
+	for i := range current {
+		holders[current[i].VariantID] = &current[i]
+	}
+
+	notifications := make(map[uint][]string)
+	var lost []uint
+	var won []models.BuyBoxTenure
+	seen := make(map[uint]bool, len(variantIDs))
+	for _, variantID := range variantIDs {
+		if seen[variantID] {
+			continue
+		}
+		seen[variantID] = true
+
+		holder := holders[variantID]
+		winner := pickBuyBox(competing[variantID], holder)
+		if winner == nil && holder == nil {
+			continue
+		}
+		if winner != nil && holder != nil && winner.SellerID == holder.SellerID {
+			continue
+		}
+
+		if holder != nil {
+			lost = append(lost, holder.ID)
+		}
+		if winner != nil {
+			won = append(won, models.BuyBoxTenure{
+				VariantID:    variantID,
+				SellerID:     winner.SellerID,
+				Currency:     winner.Currency,
+				Price:        winner.Price,
+				ShippingCost: winner.ShippingCost,
+				OfferCount:   len(competing[variantID]),
+				WonAt:        now,
+			})
+		}
+		if holder != nil {
+			notification := fmt.Sprintf("buy_box_change:variant_id=%d:previous_seller_id=%d", variantID, holder.SellerID)
+			if winner != nil {
+				notification += fmt.Sprintf(":seller_id=%d:price=%s:currency=%s",
+					winner.SellerID, winner.total().StringFixed(2), winner.Currency)
+			}
+			notifications[variantID] = append(notifications[variantID], notification)
+		}
+	}
+
+	// Close the old tenures first; a variant has one open tenure at a time
+	if len(lost) > 0 {
+		if err := db.Model(&models.BuyBoxTenure{}).Where("id IN ?", lost).
+			Update("lost_at", now).Error; err != nil {
+			return nil, fmt.Errorf("failed to close buy box tenures: %v", err)
+		}
+	}
+	if len(won) > 0 {
+		if err := db.CreateInBatches(&won, insertBatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to record buy box tenures: %v", err)
+		}
+	}
+	return notifications, nil
+}
+
+// pickBuyBox returns the cheapest offer, shipping included. The current
+// holder keeps the buy box on a tie, so equal prices do not make it flap;
+// other ties go to the lowest seller ID.
+func pickBuyBox(offers []buyBoxOffer, holder *models.BuyBoxTenure) *buyBoxOffer {
+	var best *buyBoxOffer
+	for i := range offers {
+		o := &offers[i]
+		if best == nil || o.total().LessThan(best.total()) {
+			best = o
+			continue
+		}
+		if holder != nil && o.total().Equal(best.total()) && o.SellerID == holder.SellerID {
+			best = o
+		}
+	}
+	return best
+}
+
+func (s *ProductAnalysisService) GetBuyBoxHistory(ctx context.Context, req *pb.GetBuyBoxHistoryRequest) (*pb.GetBuyBoxHistoryResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+
+	to := time.Now()
+	if req.To != "" {
+		if to, err = time.Parse(time.RFC3339, req.To); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to: %v", err)
+		}
+	}
+	from := to.Add(-defaultBuyBoxRange)
+	if req.From != "" {
+		if from, err = time.Parse(time.RFC3339, req.From); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from: %v", err)
+		}
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	db := s.db.WithContext(ctx)
+	query := db.Table("product_variants").Where("product_id = ?", productID)
+	if req.VariantId != "" {
+		variantID, err := strconv.ParseUint(req.VariantId, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid variant ID: %v", err)
+		}
+		query = query.Where("id = ?", variantID)
+	}
+	var variantIDs []uint
+	if err := query.Order("id").Pluck("id", &variantIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get variants: %v", err)
+	}
+	if len(variantIDs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no variants found for product %d", productID)
+	}
+
+	var tenures []models.BuyBoxTenure
+	if err := db.Where("variant_id IN ? AND won_at < ? AND (lost_at IS NULL OR lost_at > ?)", variantIDs, to, from).
+		Order("won_at ASC, id ASC").
+		Find(&tenures).Error; err != nil {
+		return nil, fmt.Errorf("failed to get buy box history: %v", err)
+	}
+	byVariant := make(map[uint][]models.BuyBoxTenure)
+	for _, t := range tenures {
+		byVariant[t.VariantID] = append(byVariant[t.VariantID], t)
+	}
+
+	resp := &pb.GetBuyBoxHistoryResponse{}
+	for _, variantID := range variantIDs {
+		variant := &pb.VariantBuyBox{VariantId: fmt.Sprint(variantID)}
+		held := make(map[uint]time.Duration)
+		for _, t := range byVariant[variantID] {
+			tenure := buyBoxTenureToProto(t)
+			variant.Tenures = append(variant.Tenures, tenure)
+			if t.LostAt == nil {
+				variant.Current = tenure
+			}
+
+			// The part of the tenure inside the range
+			start, end := t.WonAt, to
+			if t.LostAt != nil && t.LostAt.Before(end) {
+				end = *t.LostAt
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(start) {
+				held[t.SellerID] += end.Sub(start)
+			}
+		}
+
+		sellers := make([]uint, 0, len(held))
+		for sellerID := range held {
+			sellers = append(sellers, sellerID)
+		}
+		sort.Slice(sellers, func(a, b int) bool {
+			if held[sellers[a]] != held[sellers[b]] {
+				return held[sellers[a]] > held[sellers[b]]
+			}
+			return sellers[a] < sellers[b]
+		})
+		for _, sellerID := range sellers {
+			variant.Shares = append(variant.Shares, &pb.BuyBoxShare{
+				SellerId: fmt.Sprint(sellerID),
+				Share:    float32(held[sellerID].Seconds() / to.Sub(from).Seconds()),
+			})
+		}
+		resp.Variants = append(resp.Variants, variant)
+	}
+	return resp, nil
+}
+
+func buyBoxTenureToProto(t models.BuyBoxTenure) *pb.BuyBoxTenure {
+	tenure := &pb.BuyBoxTenure{
+		SellerId:     fmt.Sprint(t.SellerID),
+		Currency:     strings.TrimSpace(t.Currency),
+		Price:        t.Price.StringFixed(2),
+		ShippingCost: t.ShippingCost.StringFixed(2),
+		TotalPrice:   t.Price.Add(t.ShippingCost).StringFixed(2),
+		OfferCount:   int32(t.OfferCount),
+		WonAt:        t.WonAt.Format(time.RFC3339),
+	}
+	if t.LostAt != nil {
+		tenure.LostAt = t.LostAt.Format(time.RFC3339)
+	}
+	return tenure
+}
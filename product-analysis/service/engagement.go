@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+const (
+	// rawRetention is how long raw snapshots are kept before being
+	// compacted into hourly buckets.
+	rawRetention = 7 * 24 * time.Hour
+	// hourlyRetention is how long hourly buckets are kept before being
+	// compacted into daily buckets. Daily buckets are kept forever.
+	hourlyRetention = 90 * 24 * time.Hour
+
+	defaultSeriesRange = 30 * 24 * time.Hour
+)
+
+// truncUnits maps a resolution to its Postgres date_trunc unit.
+var truncUnits = map[string]string{
+	models.ResolutionHourly: "hour",
+	models.ResolutionDaily:  "day",
+}
+
+// StartSnapshotCompaction compacts engagement snapshots once an hour.
+func (s *ProductAnalysisService) StartSnapshotCompaction() {
+	log.Println("Starting engagement snapshot compaction...")
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.CompactEngagementSnapshots(context.Background(), time.Now()); err != nil {
+				log.Printf("Engagement snapshot compaction failed: %v", err)
+			}
+		}
+	}
+}
+
+// CompactEngagementSnapshots folds raw snapshots older than rawRetention into
+// hourly buckets and hourly buckets older than hourlyRetention into daily
+// ones. Only whole buckets before the cutoff are compacted, so running it
+// repeatedly is safe.
+func (s *ProductAnalysisService) CompactEngagementSnapshots(ctx context.Context, now time.Time) error {
+	if err := s.compactSnapshots(ctx, models.ResolutionRaw, models.ResolutionHourly, now.Add(-rawRetention)); err != nil {
+		return err
+	}
+	return s.compactSnapshots(ctx, models.ResolutionHourly, models.ResolutionDaily, now.Add(-hourlyRetention))
+}
+
+func (s *ProductAnalysisService) compactSnapshots(ctx context.Context, from, to string, cutoff time.Time) error {
+	unit := truncUnits[to]
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The last counters seen in each bucket stand for the whole bucket
+		insert := fmt.Sprintf(`INSERT INTO engagement_snapshots
+				(product_id, view_count, favorite_count, add_to_cart_count, order_count, captured_at, resolution)
+			SELECT DISTINCT ON (product_id, date_trunc('%[1]s', captured_at))
+				product_id, view_count, favorite_count, add_to_cart_count, order_count,
+				date_trunc('%[1]s', captured_at), ?
+			FROM engagement_snapshots
+			WHERE resolution = ? AND captured_at < date_trunc('%[1]s', ?::timestamp)
+			ORDER BY product_id, date_trunc('%[1]s', captured_at), captured_at DESC
+			ON CONFLICT (product_id, resolution, captured_at) WHERE resolution <> 'raw' DO NOTHING`, unit)
+		if err := tx.Exec(insert, to, from, cutoff).Error; err != nil {
+			return fmt.Errorf("failed to compact %s snapshots: %v", from, err)
+		}
+
+		remove := fmt.Sprintf(`DELETE FROM engagement_snapshots
+			WHERE resolution = ? AND captured_at < date_trunc('%s', ?::timestamp)`, unit)
+		if err := tx.Exec(remove, from, cutoff).Error; err != nil {
+			return fmt.Errorf("failed to remove compacted %s snapshots: %v", from, err)
+		}
+		return nil
+	})
+}
+
+func (s *ProductAnalysisService) GetEngagementSeries(ctx context.Context, req *pb.GetEngagementSeriesRequest) (*pb.GetEngagementSeriesResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+
+	to := time.Now()
+	if req.To != "" {
+		if to, err = time.Parse(time.RFC3339, req.To); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to: %v", err)
+		}
+	}
+	from := to.Add(-defaultSeriesRange)
+	if req.From != "" {
+		if from, err = time.Parse(time.RFC3339, req.From); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from: %v", err)
+		}
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	resolution := req.Resolution
+	if resolution == "" {
+		resolution = seriesResolution(to.Sub(from))
+	}
+
+	db := s.db.WithContext(ctx)
+	var snapshots []models.EngagementSnapshot
+	switch resolution {
+	case models.ResolutionRaw:
+		err = db.Where("product_id = ? AND captured_at >= ? AND captured_at < ?", productID, from, to).
+			Order("captured_at ASC").
+			Find(&snapshots).Error
+	case models.ResolutionHourly, models.ResolutionDaily:
+		// Bucket whatever is stored, so recent raw snapshots line up with
+		// older compacted ones
+		unit := truncUnits[resolution]
+		err = db.Raw(fmt.Sprintf(`SELECT * FROM (
+				SELECT DISTINCT ON (date_trunc('%[1]s', captured_at))
+					product_id, view_count, favorite_count, add_to_cart_count, order_count,
+					date_trunc('%[1]s', captured_at) AS captured_at
+				FROM engagement_snapshots
+				WHERE product_id = ? AND captured_at >= ? AND captured_at < ?
+				ORDER BY date_trunc('%[1]s', captured_at), captured_at DESC
+			) buckets ORDER BY captured_at ASC`, unit), productID, from, to).
+			Scan(&snapshots).Error
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown resolution %q", resolution)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get engagement series: %v", err)
+	}
+
+	points := make([]*pb.EngagementPoint, len(snapshots))
+	for i, snapshot := range snapshots {
+		points[i] = &pb.EngagementPoint{
+			CapturedAt:     snapshot.CapturedAt.Format(time.RFC3339),
+			ViewCount:      int32(snapshot.ViewCount),
+			FavoriteCount:  int32(snapshot.FavoriteCount),
+			AddToCartCount: int32(snapshot.AddToCartCount),
+			OrderCount:     int32(snapshot.OrderCount),
+		}
+	}
+
+	return &pb.GetEngagementSeriesResponse{Resolution: resolution, Points: points}, nil
+}
+
+// seriesResolution picks the finest resolution that is still stored for the
+// whole of a range of the given length.
+func seriesResolution(span time.Duration) string {
+	switch {
+	case span <= rawRetention:
+		return models.ResolutionRaw
+	case span <= hourlyRetention:
+		return models.ResolutionHourly
+	default:
+		return models.ResolutionDaily
+	}
+}
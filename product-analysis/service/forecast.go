@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/forecast"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// forecastHistory is how much price history a forecast is fitted to.
+const forecastHistory = 180 * 24 * time.Hour
+
+var forecastHorizons = []int{7, 30}
+
+func (s *ProductAnalysisService) GetPriceForecast(ctx context.Context, req *pb.GetPriceForecastRequest) (*pb.GetPriceForecastResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+
+	db := s.db.WithContext(ctx)
+	query := db.Table("product_variants").Where("product_id = ?", productID)
+	if req.VariantId != "" {
+		variantID, err := strconv.ParseUint(req.VariantId, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid variant ID: %v", err)
+		}
+		query = query.Where("id = ?", variantID)
+	}
+	sellerID, err := parseSellerFilter(req.SellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var variantIDs []uint
+	if err := query.Order("id").Pluck("id", &variantIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get variants: %v", err)
+	}
+	if len(variantIDs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no variants found for product %d", productID)
+	}
+
+	// Each seller's prices of a variant form their own series
+	var offers []offerPrice
+	if err := whereSeller(db.Table("price_history"), sellerID).
+		Select("DISTINCT variant_id, seller_id").
+		Where("variant_id IN ?", variantIDs).
+		Scan(&offers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get price series: %v", err)
+	}
+	sellers := make(map[uint][]*uint, len(variantIDs))
+	for _, o := range offers {
+		sellers[o.VariantID] = append(sellers[o.VariantID], o.SellerID)
+	}
+
+	now := time.Now().UTC()
+	resp := &pb.GetPriceForecastResponse{}
+	for _, variantID := range variantIDs {
+		if len(sellers[variantID]) == 0 {
+			resp.Forecasts = append(resp.Forecasts, &pb.VariantForecast{
+				VariantId:         fmt.Sprint(variantID),
+				SellerId:          formatSellerID(sellerID),
+				UnavailableReason: "no price history",
+			})
+			continue
+		}
+		// Unknown seller first, then by seller ID
+		offerSellers := sellers[variantID]
+		sort.Slice(offerSellers, func(a, b int) bool {
+			return newOfferKey(variantID, offerSellers[a]).SellerID < newOfferKey(variantID, offerSellers[b]).SellerID
+		})
+		for _, seller := range offerSellers {
+			f, err := forecastOffer(db, variantID, seller, now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to forecast variant %d: %v", variantID, err)
+			}
+			resp.Forecasts = append(resp.Forecasts, f)
+		}
+	}
+	return resp, nil
+}
+
+// forecastOffer forecasts one seller's sale price of a variant.
+func forecastOffer(db *gorm.DB, variantID uint, sellerID *uint, now time.Time) (*pb.VariantForecast, error) {
+	today := now.Truncate(24 * time.Hour)
+	start := today.Add(-forecastHistory)
+
+	offerHistory := func() *gorm.DB {
+		if sellerID == nil {
+			return db.Where("variant_id = ? AND seller_id IS NULL", variantID)
+		}
+		return db.Where("variant_id = ? AND seller_id = ?", variantID, *sellerID)
+	}
+
+	var initial models.PriceHistory
+	found := offerHistory().Where("changed_at < ?", start).
+		Order("changed_at DESC").Limit(1).Find(&initial)
+	if found.Error != nil {
+		return nil, found.Error
+	}
+	var history []models.PriceHistory
+	if err := offerHistory().Where("changed_at >= ?", start).
+		Order("changed_at ASC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	unavailable := &pb.VariantForecast{VariantId: fmt.Sprint(variantID), SellerId: formatSellerID(sellerID)}
+	var initialPrice float64
+	switch {
+	case found.RowsAffected > 0:
+		initialPrice = initial.NewPrice.InexactFloat64()
+	case len(history) > 0:
+		// First seen inside the window; start the series on that day
+		initialPrice = history[0].OldPrice.InexactFloat64()
+		start = history[0].ChangedAt.UTC().Truncate(24 * time.Hour)
+	default:
+		unavailable.UnavailableReason = "no price history"
+		return unavailable, nil
+	}
+
+	changes := make([]forecast.Change, len(history))
+	for i, h := range history {
+		changes[i] = forecast.Change{Price: h.NewPrice.InexactFloat64(), At: h.ChangedAt}
+	}
+	// The series runs up to and including today
+	series := forecast.DailySeries(initialPrice, changes, start, today.AddDate(0, 0, 1))
+
+	prediction, err := forecast.Forecast(series, start, forecastHorizons, forecast.DefaultConfig())
+	if errors.Is(err, forecast.ErrInsufficientHistory) {
+		unavailable.UnavailableReason = err.Error()
+		return unavailable, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f := &pb.VariantForecast{
+		VariantId:    fmt.Sprint(variantID),
+		SellerId:     formatSellerID(sellerID),
+		Model:        prediction.Model,
+		CurrentPrice: float32(prediction.CurrentPrice),
+	}
+	for _, r := range prediction.Ranges {
+		f.Ranges = append(f.Ranges, &pb.ForecastRange{
+			HorizonDays:     int32(r.Horizon),
+			ExpectedPrice:   float32(r.Expected),
+			LowPrice:        float32(r.Low),
+			HighPrice:       float32(r.High),
+			DropProbability: float32(r.DropProbability),
+		})
+	}
+	for _, b := range prediction.Backtests {
+		f.Backtests = append(f.Backtests, &pb.BacktestMetrics{
+			Model:            b.Model,
+			Mae:              float32(b.MAE),
+			Mape:             float32(b.MAPE),
+			IntervalCoverage: float32(b.Coverage),
+			Points:           int32(b.Points),
+		})
+	}
+	return f, nil
+}
@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/matching"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// MatchingJob groups the listings of the same item into canonical products.
+type MatchingJob struct {
+	db *gorm.DB
+}
+
+func NewMatchingJob(db *gorm.DB) *MatchingJob {
+	return &MatchingJob{db: db}
+}
+
+// Start runs the job immediately and then every interval.
+func (j *MatchingJob) Start(interval time.Duration) {
+	log.Println("Starting canonical product matching job...")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Run(context.Background(), time.Now()); err != nil {
+			log.Printf("Canonical product matching failed: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Run clusters every product and saves the clusters as canonical products.
+// A cluster keeps the canonical product most of its listings were already
+// in, so IDs stay stable between runs; locked listings are never moved.
+func (j *MatchingJob) Run(ctx context.Context, now time.Time) error {
+	db := j.db.WithContext(ctx)
+	var products []struct {
+		ID                 uint
+		Name               string
+		BrandID            *uint
+		CanonicalProductID *uint
+		Locked             *bool
+	}
+	if err := db.Table("products p").
+		Select("p.id, p.name, p.brand_id, m.canonical_product_id, m.locked").
+		Joins("LEFT JOIN canonical_memberships m ON m.product_id = p.id").
+		Order("p.id").
+		Scan(&products).Error; err != nil {
+		return fmt.Errorf("failed to load products: %v", err)
+	}
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	attributes, err := loadAttributes(db, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load product attributes: %v", err)
+	}
+	var images []struct {
+		ProductID uint
+		ImageHash int64
+	}
+	if err := db.Table("product_images").
+		Select("product_id, image_hash").
+		Where("image_hash IS NOT NULL").
+		Scan(&images).Error; err != nil {
+		return fmt.Errorf("failed to load image hashes: %v", err)
+	}
+	hashes := make(map[uint][]uint64)
+	for _, image := range images {
+		hashes[image.ProductID] = append(hashes[image.ProductID], uint64(image.ImageHash))
+	}
+
+	current := make(map[uint]uint, len(products))
+	brands := make(map[uint]*uint, len(products))
+	listings := make([]matching.Listing, len(products))
+	for i, p := range products {
+		listings[i] = matching.Listing{
+			ProductID:   p.ID,
+			Name:        p.Name,
+			Attributes:  attributes[p.ID],
+			ImageHashes: hashes[p.ID],
+		}
+		if p.BrandID != nil {
+			listings[i].BrandID = *p.BrandID
+		}
+		brands[p.ID] = p.BrandID
+		if p.CanonicalProductID != nil {
+			current[p.ID] = *p.CanonicalProductID
+			if p.Locked != nil && *p.Locked {
+				listings[i].Group = *p.CanonicalProductID
+			}
+		}
+	}
+	clusters := matching.Cluster(listings)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Locked groups claim their canonical product before any other
+		// cluster can take it over
+		assigned := make([]uint, len(clusters))
+		claimed := make(map[uint]bool)
+		for i, cluster := range clusters {
+			for _, l := range cluster {
+				if l.Group != 0 {
+					assigned[i] = l.Group
+					claimed[l.Group] = true
+					break
+				}
+			}
+		}
+		for i, cluster := range clusters {
+			if assigned[i] != 0 {
+				continue
+			}
+			counts := make(map[uint]int)
+			for _, l := range cluster {
+				if id, ok := current[l.ProductID]; ok && !claimed[id] {
+					counts[id]++
+				}
+			}
+			for id, count := range counts {
+				if assigned[i] == 0 || count > counts[assigned[i]] || count == counts[assigned[i]] && id < assigned[i] {
+					assigned[i] = id
+				}
+			}
+			if assigned[i] == 0 {
+				canonical := models.CanonicalProduct{Name: cluster[0].Name, BrandID: brands[cluster[0].ProductID]}
+				if err := tx.Create(&canonical).Error; err != nil {
+					return fmt.Errorf("failed to create canonical product: %v", err)
+				}
+				assigned[i] = canonical.ID
+			}
+			claimed[assigned[i]] = true
+		}
+
+		var memberships []models.CanonicalMembership
+		for i, cluster := range clusters {
+			for _, l := range cluster {
+				if l.Group != 0 {
+					continue
+				}
+				if id, ok := current[l.ProductID]; ok && id == assigned[i] {
+					continue
+				}
+				memberships = append(memberships, models.CanonicalMembership{
+					ProductID:          l.ProductID,
+					CanonicalProductID: assigned[i],
+					MatchedAt:          now,
+				})
+			}
+		}
+		if len(memberships) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "product_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"canonical_product_id", "matched_at"}),
+			}).CreateInBatches(memberships, 500).Error; err != nil {
+				return fmt.Errorf("failed to save canonical memberships: %v", err)
+			}
+		}
+		if err := deleteEmptyCanonicalProducts(tx); err != nil {
+			return err
+		}
+		log.Printf("Matched %d products into %d canonical products (%d moved)", len(products), len(clusters), len(memberships))
+		return nil
+	})
+}
+
+// GetCanonicalProduct returns a canonical product with its listings, its
+// lowest price across them and the sentiment of all their reviews.
+func (s *ProductAnalysisService) GetCanonicalProduct(ctx context.Context, req *pb.GetCanonicalProductRequest) (*pb.CanonicalProduct, error) {
+	canonicalID, err := strconv.ParseUint(req.CanonicalProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid canonical product ID: %v", err)
+	}
+	return loadCanonicalProduct(s.db.WithContext(ctx), uint(canonicalID))
+}
+
+// ConfirmCanonicalProduct locks products into a canonical product so the
+// matcher keeps them there.
+func (s *ProductAnalysisService) ConfirmCanonicalProduct(ctx context.Context, req *pb.ConfirmCanonicalProductRequest) (*pb.CanonicalProduct, error) {
+	canonicalID, err := strconv.ParseUint(req.CanonicalProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid canonical product ID: %v", err)
+	}
+	productIDs, err := parseProductIDs(req.ProductIds)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := findCanonicalProduct(tx, uint(canonicalID)); err != nil {
+			return err
+		}
+		if len(productIDs) == 0 {
+			if err := tx.Model(&models.CanonicalMembership{}).
+				Where("canonical_product_id = ?", canonicalID).
+				Updates(map[string]interface{}{"locked": true, "matched_at": now}).Error; err != nil {
+				return fmt.Errorf("failed to lock canonical memberships: %v", err)
+			}
+			return nil
+		}
+
+		var found int64
+		if err := tx.Table("products").Where("id IN ?", productIDs).Count(&found).Error; err != nil {
+			return fmt.Errorf("failed to get products: %v", err)
+		}
+		if int(found) != len(productIDs) {
+			return status.Errorf(codes.NotFound, "%d of the products were not found", len(productIDs)-int(found))
+		}
+		memberships := make([]models.CanonicalMembership, len(productIDs))
+		for i, id := range productIDs {
+			memberships[i] = models.CanonicalMembership{
+				ProductID:          id,
+				CanonicalProductID: uint(canonicalID),
+				Locked:             true,
+				MatchedAt:          now,
+			}
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"canonical_product_id", "locked", "matched_at"}),
+		}).Create(&memberships).Error; err != nil {
+			return fmt.Errorf("failed to save canonical memberships: %v", err)
+		}
+		return deleteEmptyCanonicalProducts(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loadCanonicalProduct(s.db.WithContext(ctx), uint(canonicalID))
+}
+
+// SplitCanonicalProduct moves some listings of a canonical product into a
+// new one, and locks both groups so the matcher does not merge them again.
+func (s *ProductAnalysisService) SplitCanonicalProduct(ctx context.Context, req *pb.SplitCanonicalProductRequest) (*pb.SplitCanonicalProductResponse, error) {
+	canonicalID, err := strconv.ParseUint(req.CanonicalProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid canonical product ID: %v", err)
+	}
+	productIDs, err := parseProductIDs(req.ProductIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(productIDs) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "product_ids is required")
+	}
+
+	now := time.Now()
+	var split models.CanonicalProduct
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		canonical, err := findCanonicalProduct(tx, uint(canonicalID))
+		if err != nil {
+			return err
+		}
+		var members []uint
+		if err := tx.Model(&models.CanonicalMembership{}).
+			Where("canonical_product_id = ?", canonicalID).
+			Pluck("product_id", &members).Error; err != nil {
+			return fmt.Errorf("failed to get canonical memberships: %v", err)
+		}
+		isMember := make(map[uint]bool, len(members))
+		for _, id := range members {
+			isMember[id] = true
+		}
+		for _, id := range productIDs {
+			if !isMember[id] {
+				return status.Errorf(codes.InvalidArgument, "product %d is not a listing of canonical product %d", id, canonicalID)
+			}
+		}
+		if len(productIDs) == len(members) {
+			return status.Errorf(codes.InvalidArgument, "cannot split every listing off canonical product %d", canonicalID)
+		}
+
+		var name string
+		if err := tx.Table("products").Select("name").Where("id = ?", productIDs[0]).Row().Scan(&name); err != nil {
+			return fmt.Errorf("failed to get product name: %v", err)
+		}
+		split = models.CanonicalProduct{Name: name, BrandID: canonical.BrandID}
+		if err := tx.Create(&split).Error; err != nil {
+			return fmt.Errorf("failed to create canonical product: %v", err)
+		}
+		if err := tx.Model(&models.CanonicalMembership{}).
+			Where("product_id IN ?", productIDs).
+			Updates(map[string]interface{}{"canonical_product_id": split.ID, "locked": true, "matched_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to move canonical memberships: %v", err)
+		}
+		if err := tx.Model(&models.CanonicalMembership{}).
+			Where("canonical_product_id = ?", canonicalID).
+			Updates(map[string]interface{}{"locked": true, "matched_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to lock canonical memberships: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	remaining, err := loadCanonicalProduct(db, uint(canonicalID))
+	if err != nil {
+		return nil, err
+	}
+	splitOff, err := loadCanonicalProduct(db, split.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SplitCanonicalProductResponse{Remaining: remaining, Split: splitOff}, nil
+}
+
+func findCanonicalProduct(db *gorm.DB, id uint) (*models.CanonicalProduct, error) {
+	var canonical models.CanonicalProduct
+	result := db.Take(&canonical, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "canonical product %d not found", id)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get canonical product: %v", result.Error)
+	}
+	return &canonical, nil
+}
+
+func loadCanonicalProduct(db *gorm.DB, id uint) (*pb.CanonicalProduct, error) {
+	canonical, err := findCanonicalProduct(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var listings []struct {
+		ID         uint
+		ExternalID string
+		Name       string
+		Locked     bool
+		MatchedAt  time.Time
+	}
+	if err := db.Table("canonical_memberships m").
+		Select("p.id, p.external_id, p.name, m.locked, m.matched_at").
+		Joins("JOIN products p ON p.id = m.product_id").
+		Where("m.canonical_product_id = ?", id).
+		Order("p.id").
+		Scan(&listings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get canonical listings: %v", err)
+	}
+	ids := make([]uint, len(listings))
+	for i, l := range listings {
+		ids[i] = l.ID
+	}
+	prices, err := loadLowestPrices(db, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get listing prices: %v", err)
+	}
+	reviewSentiment, err := loadReviewSentiment(db,
+		db.Model(&models.CanonicalMembership{}).Select("product_id").Where("canonical_product_id = ?", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review sentiment: %v", err)
+	}
+
+	resp := &pb.CanonicalProduct{
+		Id:              fmt.Sprint(canonical.ID),
+		Name:            canonical.Name,
+		ReviewSentiment: reviewSentiment,
+	}
+	if canonical.BrandID != nil {
+		resp.BrandId = fmt.Sprint(*canonical.BrandID)
+	}
+	// The cheapest listing is only looked for among listings priced in the
+	// currency most of them use, as other prices cannot be compared
+	currencies := make(map[string]int)
+	for _, p := range prices {
+		currencies[p.Currency]++
+	}
+	var currency string
+	for c, count := range currencies {
+		if currency == "" || count > currencies[currency] || count == currencies[currency] && c < currency {
+			currency = c
+		}
+	}
+	var cheapest *lowestPrice
+	for _, l := range listings {
+		listing := &pb.CanonicalListing{
+			ProductId:  fmt.Sprint(l.ID),
+			ExternalId: l.ExternalID,
+			Name:       l.Name,
+			Locked:     l.Locked,
+			MatchedAt:  l.MatchedAt.Format(time.RFC3339),
+		}
+		if price, ok := prices[l.ID]; ok {
+			listing.Price = price.Price.StringFixed(2)
+			listing.Currency = strings.TrimSpace(price.Currency)
+			if price.Currency == currency && (cheapest == nil || price.Price.LessThan(cheapest.Price)) {
+				price := price
+				cheapest = &price
+			}
+		}
+		resp.Listings = append(resp.Listings, listing)
+	}
+	if cheapest != nil {
+		resp.Price = cheapest.Price.StringFixed(2)
+		resp.Currency = strings.TrimSpace(cheapest.Currency)
+		resp.CheapestProductId = fmt.Sprint(cheapest.ProductID)
+	}
+	return resp, nil
+}
+
+func deleteEmptyCanonicalProducts(tx *gorm.DB) error {
+	if err := tx.Where("NOT EXISTS (SELECT 1 FROM canonical_memberships m WHERE m.canonical_product_id = canonical_products.id)").
+		Delete(&models.CanonicalProduct{}).Error; err != nil {
+		return fmt.Errorf("failed to delete empty canonical products: %v", err)
+	}
+	return nil
+}
+
+func parseProductIDs(values []string) ([]uint, error) {
+	ids := make([]uint, 0, len(values))
+	seen := make(map[uint]bool, len(values))
+	for _, value := range values {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid product ID %q: %v", value, err)
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+const (
+	defaultMoversWindowDays = 7
+	defaultMoversLimit      = 10
+	maxMoversLimit          = 100
+)
+
+// trendingMetrics maps a ListTrending metric to the growth it ranks by.
+var trendingMetrics = map[string]func(g *engagementGrowth) (growth, start int){
+	"views":        func(g *engagementGrowth) (int, int) { return g.ViewGrowth, g.StartViewCount },
+	"favorites":    func(g *engagementGrowth) (int, int) { return g.FavoriteGrowth, g.StartFavoriteCount },
+	"add_to_carts": func(g *engagementGrowth) (int, int) { return g.AddToCartGrowth, g.StartAddToCartCount },
+	"orders":       func(g *engagementGrowth) (int, int) { return g.OrderGrowth, g.StartOrderCount },
+}
+
+// engagementGrowth is how much a product's counters grew over a window.
+type engagementGrowth struct {
+	ProductID           uint
+	CategoryID          *uint
+	ViewGrowth          int
+	FavoriteGrowth      int
+	AddToCartGrowth     int
+	OrderGrowth         int
+	StartViewCount      int
+	StartFavoriteCount  int
+	StartAddToCartCount int
+	StartOrderCount     int
+}
+
+// priceMove is a product's average variant price at the start of a window
+// and now.
+type priceMove struct {
+	ProductID    uint
+	CategoryID   *uint
+	StartPrice   float64
+	CurrentPrice float64
+}
+
+func (s *ProductAnalysisService) ListTrending(ctx context.Context, req *pb.ListTrendingRequest) (*pb.ListTrendingResponse, error) {
+	categoryID, windowDays, limit, err := parseMoversRequest(req.CategoryId, req.WindowDays, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	metric := req.Metric
+	if metric == "" {
+		metric = "views"
+	}
+	growthOf, ok := trendingMetrics[metric]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown metric %q", metric)
+	}
+
+	since := time.Now().AddDate(0, 0, -windowDays)
+	growths, err := loadEngagementGrowth(s.db.WithContext(ctx), categoryID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load engagement growth: %v", err)
+	}
+
+	ranked := rankPerCategory(len(growths), limit,
+		func(i int) *uint { return growths[i].CategoryID },
+		func(a, b int) bool {
+			ga, _ := growthOf(&growths[a])
+			gb, _ := growthOf(&growths[b])
+			if ga != gb {
+				return ga > gb
+			}
+			return growths[a].ProductID < growths[b].ProductID
+		})
+
+	products := make([]*pb.TrendingProduct, 0, len(ranked))
+	for _, r := range ranked {
+		g := &growths[r.index]
+		growth, start := growthOf(g)
+		products = append(products, &pb.TrendingProduct{
+			ProductId:       fmt.Sprint(g.ProductID),
+			CategoryId:      formatCategoryID(g.CategoryID),
+			Rank:            int32(r.rank),
+			ViewGrowth:      int32(g.ViewGrowth),
+			FavoriteGrowth:  int32(g.FavoriteGrowth),
+			AddToCartGrowth: int32(g.AddToCartGrowth),
+			OrderGrowth:     int32(g.OrderGrowth),
+			GrowthPercent:   float32(percentChange(float64(start), float64(start+growth))),
+		})
+	}
+
+	return &pb.ListTrendingResponse{
+		WindowDays: int32(windowDays),
+		Metric:     metric,
+		Products:   products,
+	}, nil
+}
+
+func (s *ProductAnalysisService) ListTopMovers(ctx context.Context, req *pb.ListTopMoversRequest) (*pb.ListTopMoversResponse, error) {
+	categoryID, windowDays, limit, err := parseMoversRequest(req.CategoryId, req.WindowDays, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	direction := req.Direction
+	if direction == "" {
+		direction = "down"
+	}
+	if direction != "down" && direction != "up" {
+		return nil, status.Errorf(codes.InvalidArgument, "direction must be down or up")
+	}
+	sellerID, err := parseSellerFilter(req.SellerId)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -windowDays)
+	moves, err := loadPriceMoves(s.db.WithContext(ctx), categoryID, sellerID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price changes: %v", err)
+	}
+
+	// Only keep moves in the requested direction
+	filtered := moves[:0]
+	for _, m := range moves {
+		if (direction == "down" && m.CurrentPrice < m.StartPrice) || (direction == "up" && m.CurrentPrice > m.StartPrice) {
+			filtered = append(filtered, m)
+		}
+	}
+	moves = filtered
+
+	change := func(i int) float64 { return percentChange(moves[i].StartPrice, moves[i].CurrentPrice) }
+	ranked := rankPerCategory(len(moves), limit,
+		func(i int) *uint { return moves[i].CategoryID },
+		func(a, b int) bool {
+			ca, cb := change(a), change(b)
+			if ca != cb {
+				if direction == "down" {
+					return ca < cb
+				}
+				return ca > cb
+			}
+			return moves[a].ProductID < moves[b].ProductID
+		})
+
+	products := make([]*pb.PriceMover, 0, len(ranked))
+	for _, r := range ranked {
+		m := &moves[r.index]
+		products = append(products, &pb.PriceMover{
+			ProductId:     fmt.Sprint(m.ProductID),
+			CategoryId:    formatCategoryID(m.CategoryID),
+			Rank:          int32(r.rank),
+			StartPrice:    float32(m.StartPrice),
+			CurrentPrice:  float32(m.CurrentPrice),
+			ChangePercent: float32(change(r.index)),
+		})
+	}
+
+	return &pb.ListTopMoversResponse{
+		WindowDays: int32(windowDays),
+		Direction:  direction,
+		Products:   products,
+	}, nil
+}
+
+// loadEngagementGrowth compares each analysed product's latest counters with
+// the last snapshot taken at or before since, or the first one after it for
+// products first seen inside the window.
+func loadEngagementGrowth(db *gorm.DB, categoryID *uint, since time.Time) ([]engagementGrowth, error) {
+	query := `WITH before_window AS (
+			SELECT DISTINCT ON (product_id) product_id, view_count, favorite_count, add_to_cart_count, order_count
+			FROM engagement_snapshots
+			WHERE captured_at <= @since
+			ORDER BY product_id, captured_at DESC
+		), in_window AS (
+			SELECT DISTINCT ON (product_id) product_id, view_count, favorite_count, add_to_cart_count, order_count
+			FROM engagement_snapshots
+			WHERE captured_at > @since
+			ORDER BY product_id, captured_at ASC
+		), start AS (
+			SELECT COALESCE(b.product_id, w.product_id) AS product_id,
+				COALESCE(b.view_count, w.view_count) AS view_count,
+				COALESCE(b.favorite_count, w.favorite_count) AS favorite_count,
+				COALESCE(b.add_to_cart_count, w.add_to_cart_count) AS add_to_cart_count,
+				COALESCE(b.order_count, w.order_count) AS order_count
+			FROM before_window b
+			FULL JOIN in_window w ON w.product_id = b.product_id
+		)
+		SELECT pa.product_id, p.category_id,
+			COALESCE(pa.view_count, 0) - s.view_count AS view_growth,
+			COALESCE(pa.favorite_count, 0) - s.favorite_count AS favorite_growth,
+			COALESCE(pa.add_to_cart_count, 0) - s.add_to_cart_count AS add_to_cart_growth,
+			COALESCE(pa.order_count, 0) - s.order_count AS order_growth,
+			s.view_count AS start_view_count,
+			s.favorite_count AS start_favorite_count,
+			s.add_to_cart_count AS start_add_to_cart_count,
+			s.order_count AS start_order_count
+		FROM product_analytics pa
+		JOIN products p ON p.id = pa.product_id
+		JOIN start s ON s.product_id = pa.product_id
+		WHERE @category_id::integer IS NULL OR p.category_id = @category_id`
+
+	var growths []engagementGrowth
+	err := db.Raw(query, map[string]interface{}{"since": since, "category_id": categoryID}).Scan(&growths).Error
+	return growths, err
+}
+
+// loadPriceMoves compares each product's average offer price at the start
+// of the window with its average price now, the same way GetProductAnalytics
+// computes its price trend. An offer is a variant's sale price from one
+// seller; sellerID restricts the comparison to one seller's offers. Products
+// whose price did not change are left out.
+func loadPriceMoves(db *gorm.DB, categoryID, sellerID *uint, since time.Time) ([]priceMove, error) {
+	query := `WITH offers AS (
+			SELECT variant_id, seller_id, old_price, new_price, changed_at
+			FROM price_history
+			WHERE @seller_id::integer IS NULL OR seller_id = @seller_id
+		), before_window AS (
+			SELECT DISTINCT ON (variant_id, seller_id) variant_id, seller_id, new_price AS price
+			FROM offers
+			WHERE changed_at <= @since
+			ORDER BY variant_id, seller_id, changed_at DESC
+		), in_window AS (
+			SELECT DISTINCT ON (variant_id, seller_id) variant_id, seller_id, old_price AS price
+			FROM offers
+			WHERE changed_at > @since
+			ORDER BY variant_id, seller_id, changed_at ASC
+		), latest AS (
+			SELECT DISTINCT ON (variant_id, seller_id) variant_id, seller_id, new_price AS price
+			FROM offers
+			ORDER BY variant_id, seller_id, changed_at DESC
+		)
+		SELECT v.product_id, p.category_id,
+			AVG(COALESCE(b.price, w.price)) AS start_price,
+			AVG(l.price) AS current_price
+		FROM latest l
+		LEFT JOIN before_window b ON b.variant_id = l.variant_id AND b.seller_id IS NOT DISTINCT FROM l.seller_id
+		LEFT JOIN in_window w ON w.variant_id = l.variant_id AND w.seller_id IS NOT DISTINCT FROM l.seller_id
+		JOIN product_variants v ON v.id = l.variant_id
+		JOIN products p ON p.id = v.product_id
+		WHERE @category_id::integer IS NULL OR p.category_id = @category_id
+		GROUP BY v.product_id, p.category_id
+		HAVING AVG(COALESCE(b.price, w.price)) > 0
+			AND AVG(l.price) <> AVG(COALESCE(b.price, w.price))`
+
+	var moves []priceMove
+	err := db.Raw(query, map[string]interface{}{"since": since, "category_id": categoryID, "seller_id": sellerID}).Scan(&moves).Error
+	return moves, err
+}
+
+// rankedEntry is the position of an item in its category's ranking.
+type rankedEntry struct {
+	index int
+	rank  int
+}
+
+// rankPerCategory orders n items within their categories by less and keeps
+// the first limit of each. Categories are returned in ascending id order,
+// uncategorised items last.
+func rankPerCategory(n, limit int, categoryOf func(i int) *uint, less func(a, b int) bool) []rankedEntry {
+	groups := make(map[uint][]int)
+	for i := 0; i < n; i++ {
+		var category uint
+		if id := categoryOf(i); id != nil {
+			category = *id
+		}
+		groups[category] = append(groups[category], i)
+	}
+
+	categories := make([]uint, 0, len(groups))
+	for category := range groups {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(a, b int) bool {
+		// 0 holds uncategorised items and sorts last
+		if categories[a] == 0 || categories[b] == 0 {
+			return categories[b] == 0 && categories[a] != 0
+		}
+		return categories[a] < categories[b]
+	})
+
+	var ranked []rankedEntry
+	for _, category := range categories {
+		items := groups[category]
+		sort.SliceStable(items, func(a, b int) bool { return less(items[a], items[b]) })
+		for rank, i := range items {
+			if rank == limit {
+				break
+			}
+			ranked = append(ranked, rankedEntry{index: i, rank: rank + 1})
+		}
+	}
+	return ranked
+}
+
+func parseMoversRequest(categoryID string, windowDays, limit int32) (*uint, int, int, error) {
+	var category *uint
+	if categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 64)
+		if err != nil {
+			return nil, 0, 0, status.Errorf(codes.InvalidArgument, "invalid category ID: %v", err)
+		}
+		c := uint(id)
+		category = &c
+	}
+
+	days := int(windowDays)
+	if days == 0 {
+		days = defaultMoversWindowDays
+	}
+	if days < 0 || days > maxWindowDays {
+		return nil, 0, 0, status.Errorf(codes.InvalidArgument, "window_days must be between 1 and %d", maxWindowDays)
+	}
+
+	n := int(limit)
+	if n == 0 {
+		n = defaultMoversLimit
+	}
+	if n < 0 || n > maxMoversLimit {
+		return nil, 0, 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxMoversLimit)
+	}
+
+	return category, days, n, nil
+}
+
+func formatCategoryID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprint(*id)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+// defaultCurrency is assumed when the crawler does not report one.
+const defaultCurrency = "TRY"
+
+// offerKey identifies one seller's price series for a variant. SellerID is
+// 0 for prices whose seller is unknown.
+type offerKey struct {
+	VariantID uint
+	SellerID  uint
+}
+
+func newOfferKey(variantID uint, sellerID *uint) offerKey {
+	key := offerKey{VariantID: variantID}
+	if sellerID != nil {
+		key.SellerID = *sellerID
+	}
+	return key
+}
+
+// priceObservation is a variant's prices as reported to one analysis.
+type priceObservation struct {
+	VariantID   uint
+	SellerID    *uint
+	Currency    string
+	SalePrice   decimal.Decimal
+	ListPrice   decimal.NullDecimal
+	BasketPrice decimal.NullDecimal
+	Promotions  models.Labels
+}
+
+func (o priceObservation) key() offerKey {
+	return newOfferKey(o.VariantID, o.SellerID)
+}
+
+// sameAs reports whether the observation repeats the last recorded one, so
+// no new history row is needed.
+func (o priceObservation) sameAs(last models.PriceHistory) bool {
+	return o.Currency == strings.TrimSpace(last.Currency) &&
+		o.SalePrice.Equal(last.NewPrice) &&
+		sameNullDecimal(o.ListPrice, last.ListPrice) &&
+		sameNullDecimal(o.BasketPrice, last.BasketPrice) &&
+		o.Promotions.Equal(last.Promotions)
+}
+
+// parsePrices reads a reported variant's prices. The exact decimal strings
+// take precedence over the float fields, which are rounded to cents.
+func parsePrices(variant *pb.ProductVariant, currency string) (priceObservation, error) {
+	o := priceObservation{Currency: strings.ToUpper(currency), Promotions: variant.Promotions}
+	if o.Currency == "" {
+		o.Currency = defaultCurrency
+	}
+	if len(o.Currency) != 3 {
+		return o, fmt.Errorf("invalid currency %q", currency)
+	}
+
+	var err error
+	if o.SalePrice, err = parsePrice(variant.SalePrice, variant.Price); err != nil {
+		return o, fmt.Errorf("invalid sale price: %v", err)
+	}
+	if o.SalePrice.IsNegative() {
+		return o, fmt.Errorf("negative sale price %s", o.SalePrice)
+	}
+
+	listPrice, err := parsePrice(variant.ListPrice, variant.OriginalPrice)
+	if err != nil {
+		return o, fmt.Errorf("invalid list price: %v", err)
+	}
+	if listPrice.IsPositive() {
+		o.ListPrice = decimal.NewNullDecimal(listPrice)
+	}
+
+	if variant.BasketPrice != "" {
+		basketPrice, err := decimal.NewFromString(variant.BasketPrice)
+		if err != nil {
+			return o, fmt.Errorf("invalid basket price: %v", err)
+		}
+		o.BasketPrice = decimal.NewNullDecimal(basketPrice.Round(2))
+	}
+	return o, nil
+}
+
+func parsePrice(exact string, approximate float32) (decimal.Decimal, error) {
+	if exact != "" {
+		price, err := decimal.NewFromString(exact)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		return price.Round(2), nil
+	}
+	return decimal.NewFromFloat32(approximate).Round(2), nil
+}
+
+// parseSellerFilter reads an optional internal seller ID.
+func parseSellerFilter(sellerID string) (*uint, error) {
+	if sellerID == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(sellerID, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid seller ID: %v", err)
+	}
+	seller := uint(id)
+	return &seller, nil
+}
+
+// whereSeller restricts a price_history or price_anomalies query to one
+// seller when a filter is set.
+func whereSeller(db *gorm.DB, sellerID *uint) *gorm.DB {
+	if sellerID == nil {
+		return db
+	}
+	return db.Where("seller_id = ?", *sellerID)
+}
+
+func formatSellerID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprint(*id)
+}
+
+func formatNullDecimal(d decimal.NullDecimal) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Decimal.StringFixed(2)
+}
+
+func sameNullDecimal(a, b decimal.NullDecimal) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	return a.Decimal.Equal(b.Decimal)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+)
+
+const (
+	defaultReviewsPageSize = 20
+	maxReviewsPageSize     = 100
+)
+
+// reviewTrendUnits maps a trend interval to its Postgres date_trunc unit.
+var reviewTrendUnits = map[string]string{
+	"week":  "week",
+	"month": "month",
+}
+
+// ListReviews lists a product's crawled reviews with its rating
+// distribution and rating trend.
+func (s *ProductAnalysisService) ListReviews(ctx context.Context, req *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+	if req.Page < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page %d", req.Page)
+	}
+	perPage := int(req.PerPage)
+	if perPage == 0 {
+		perPage = defaultReviewsPageSize
+	}
+	if perPage < 0 || perPage > maxReviewsPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "per_page must be between 1 and %d", maxReviewsPageSize)
+	}
+	if req.Rating < 0 || req.Rating > 5 {
+		return nil, status.Errorf(codes.InvalidArgument, "rating must be between 1 and 5")
+	}
+	interval := req.TrendInterval
+	if interval == "" {
+		interval = "month"
+	}
+	unit, ok := reviewTrendUnits[interval]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid trend interval %q", req.TrendInterval)
+	}
+
+	db := s.db.WithContext(ctx)
+	var products int64
+	if err := db.Table("products").Where("id = ?", productID).Count(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get product: %v", err)
+	}
+	if products == 0 {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productID)
+	}
+
+	reviews := db.Table("reviews").Where("product_id = ?", productID)
+	if req.Rating != 0 {
+		reviews = reviews.Where("rating = ?", req.Rating)
+	}
+	var total int64
+	if err := reviews.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count reviews: %v", err)
+	}
+	var rows []struct {
+		ID           uint
+		Rating       int
+		Comment      string
+		ReviewerName string
+		ReviewDate   *time.Time
+		IsTopReview  bool
+	}
+	if err := reviews.
+		Select("id, rating, COALESCE(comment, '') AS comment, COALESCE(reviewer_name, '') AS reviewer_name, review_date, COALESCE(is_top_review, false) AS is_top_review").
+		Order("review_date DESC NULLS LAST, id DESC").
+		Offset(int(req.Page) * perPage).
+		Limit(perPage).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %v", err)
+	}
+
+	resp := &pb.ListReviewsResponse{Total: int32(total)}
+	for _, r := range rows {
+		review := &pb.Review{
+			Id:           fmt.Sprint(r.ID),
+			Rating:       int32(r.Rating),
+			Comment:      r.Comment,
+			ReviewerName: r.ReviewerName,
+			IsTopReview:  r.IsTopReview,
+		}
+		if r.ReviewDate != nil {
+			review.ReviewDate = r.ReviewDate.Format(time.RFC3339)
+		}
+		resp.Reviews = append(resp.Reviews, review)
+	}
+
+	var counts []struct {
+		Rating int
+		Count  int64
+	}
+	if err := db.Table("reviews").
+		Select("rating, COUNT(*) AS count").
+		Where("product_id = ?", productID).
+		Group("rating").
+		Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rating distribution: %v", err)
+	}
+	distribution := make([]int64, 5)
+	var sum int64
+	for _, c := range counts {
+		// Ratings outside 1-5 are counted but left out of the distribution
+		if c.Rating >= 1 && c.Rating <= 5 {
+			distribution[c.Rating-1] = c.Count
+		}
+		resp.ReviewCount += int32(c.Count)
+		sum += int64(c.Rating) * c.Count
+	}
+	if resp.ReviewCount > 0 {
+		resp.AverageRating = float32(float64(sum) / float64(resp.ReviewCount))
+	}
+	for i, count := range distribution {
+		resp.Distribution = append(resp.Distribution, &pb.RatingCount{Rating: int32(i + 1), Count: int32(count)})
+	}
+
+	var trend []struct {
+		PeriodStart   time.Time
+		ReviewCount   int64
+		AverageRating float64
+	}
+	if err := db.Table("reviews").
+		Select("date_trunc(?, review_date) AS period_start, COUNT(*) AS review_count, AVG(rating) AS average_rating", unit).
+		Where("product_id = ? AND review_date IS NOT NULL", productID).
+		Group("period_start").
+		Order("period_start").
+		Scan(&trend).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rating trend: %v", err)
+	}
+	for _, t := range trend {
+		resp.Trend = append(resp.Trend, &pb.RatingTrendPoint{
+			PeriodStart:   t.PeriodStart.Format(time.RFC3339),
+			ReviewCount:   int32(t.ReviewCount),
+			AverageRating: float32(t.AverageRating),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/scoring"
+)
+
+// ScoringJob recomputes every product's popularity scores and ranks them
+// within their category.
+type ScoringJob struct {
+	db      *gorm.DB
+	scorers []scoring.Scorer
+	// primary names the scorer copied into product_analytics.popularity_score.
+	primary string
+	history time.Duration
+}
+
+func NewScoringJob(db *gorm.DB, scorers []scoring.Scorer, primary string, history time.Duration) *ScoringJob {
+	return &ScoringJob{
+		db:      db,
+		scorers: scorers,
+		primary: primary,
+		history: history,
+	}
+}
+
+// Start runs the job immediately and then every interval.
+func (j *ScoringJob) Start(interval time.Duration) {
+	log.Println("Starting popularity scoring job...")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Run(context.Background(), time.Now()); err != nil {
+			log.Printf("Popularity scoring failed: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Run scores all analysed products with every scorer.
+func (j *ScoringJob) Run(ctx context.Context, now time.Time) error {
+	db := j.db.WithContext(ctx)
+
+	categories, err := j.loadSignals(db, now)
+	if err != nil {
+		return err
+	}
+
+	var scores []models.ProductScore
+	for categoryID, products := range categories {
+		var category *uint
+		if categoryID != 0 {
+			id := categoryID
+			category = &id
+		}
+
+		for _, scorer := range j.scorers {
+			values := scorer.Score(products, now)
+			ranks := scoring.Rank(values)
+			for i, p := range products {
+				scores = append(scores, models.ProductScore{
+					ProductID:    p.ProductID,
+					Scorer:       scorer.Name(),
+					CategoryID:   category,
+					Score:        values[i],
+					CategoryRank: ranks[i],
+					ComputedAt:   now,
+				})
+			}
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(scores, 500).Error; err != nil {
+			return fmt.Errorf("failed to save product scores: %v", err)
+		}
+		if err := tx.Exec(`UPDATE product_analytics pa
+			SET popularity_score = ps.score, updated_at = ?
+			FROM product_scores ps
+			WHERE ps.product_id = pa.product_id AND ps.scorer = ?`,
+			now, j.primary).Error; err != nil {
+			return fmt.Errorf("failed to update popularity scores: %v", err)
+		}
+		return nil
+	})
+}
+
+// loadSignals returns the signals of every analysed product, grouped by
+// category. Products without a category are grouped under 0.
+func (j *ScoringJob) loadSignals(db *gorm.DB, now time.Time) (map[uint][]scoring.Signals, error) {
+	var totals []struct {
+		ProductID      uint
+		CategoryID     *uint
+		ViewCount      int
+		FavoriteCount  int
+		AddToCartCount int
+		OrderCount     int
+	}
+	if err := db.Raw(`SELECT pa.product_id, p.category_id,
+			COALESCE(pa.view_count, 0) AS view_count, COALESCE(pa.favorite_count, 0) AS favorite_count,
+			COALESCE(pa.add_to_cart_count, 0) AS add_to_cart_count, COALESCE(pa.order_count, 0) AS order_count
+		FROM product_analytics pa
+		JOIN products p ON p.id = pa.product_id
+		ORDER BY pa.product_id`).
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to load engagement totals: %v", err)
+	}
+
+	// Growth between consecutive snapshots; counters that went down (for
+	// example after a site-side reset) count as no growth
+	var increments []struct {
+		ProductID      uint
+		CapturedAt     time.Time
+		ViewCount      int
+		FavoriteCount  int
+		AddToCartCount int
+		OrderCount     int
+	}
+	if err := db.Raw(`SELECT product_id, captured_at,
+			GREATEST(view_count - LAG(view_count) OVER w, 0) AS view_count,
+			GREATEST(favorite_count - LAG(favorite_count) OVER w, 0) AS favorite_count,
+			GREATEST(add_to_cart_count - LAG(add_to_cart_count) OVER w, 0) AS add_to_cart_count,
+			GREATEST(order_count - LAG(order_count) OVER w, 0) AS order_count
+		FROM engagement_snapshots
+		WHERE captured_at >= ?
+		WINDOW w AS (PARTITION BY product_id ORDER BY captured_at)
+		ORDER BY product_id, captured_at`, now.Add(-j.history)).
+		Scan(&increments).Error; err != nil {
+		return nil, fmt.Errorf("failed to load engagement history: %v", err)
+	}
+
+	byProduct := make(map[uint][]scoring.Increment)
+	for _, inc := range increments {
+		byProduct[inc.ProductID] = append(byProduct[inc.ProductID], scoring.Increment{
+			At: inc.CapturedAt,
+			Counters: scoring.Counters{
+				Views:      float64(inc.ViewCount),
+				Favorites:  float64(inc.FavoriteCount),
+				AddToCarts: float64(inc.AddToCartCount),
+				Orders:     float64(inc.OrderCount),
+			},
+		})
+	}
+
+	categories := make(map[uint][]scoring.Signals)
+	for _, t := range totals {
+		var categoryID uint
+		if t.CategoryID != nil {
+			categoryID = *t.CategoryID
+		}
+		categories[categoryID] = append(categories[categoryID], scoring.Signals{
+			ProductID:  t.ProductID,
+			CategoryID: categoryID,
+			Totals: scoring.Counters{
+				Views:      float64(t.ViewCount),
+				Favorites:  float64(t.FavoriteCount),
+				AddToCarts: float64(t.AddToCartCount),
+				Orders:     float64(t.OrderCount),
+			},
+			Increments: byProduct[t.ProductID],
+		})
+	}
+	return categories, nil
+}
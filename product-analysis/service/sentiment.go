@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/sentiment"
+)
+
+const (
+	// sentimentBatchSize is how many reviews SentimentJob analyses per
+	// transaction.
+	sentimentBatchSize   = 500
+	maxSentimentKeywords = 10
+)
+
+// reviewSentimentQuery, reviewAspectsQuery and reviewKeywordsQuery aggregate
+// the analysed reviews of the products selected by the @products subquery.
+const reviewSentimentQuery = `
+SELECT COUNT(*) AS review_count, COALESCE(AVG(score), 0) AS average_score,
+    COUNT(*) FILTER (WHERE score > @band) AS positive_count,
+    COUNT(*) FILTER (WHERE score < -@band) AS negative_count
+FROM review_sentiments
+WHERE product_id IN @products`
+
+const reviewAspectsQuery = `
+SELECT aspect, COUNT(*) AS mention_count,
+    COUNT(*) FILTER (WHERE score < -@band) AS negative_count,
+    AVG(score) AS average_score
+FROM review_aspects
+WHERE product_id IN @products
+GROUP BY aspect
+ORDER BY negative_count DESC, mention_count DESC, aspect`
+
+const reviewKeywordsQuery = `
+SELECT k.keyword, COUNT(*) AS count
+FROM review_sentiments s, jsonb_array_elements_text(s.keywords) AS k(keyword)
+WHERE s.product_id IN @products
+GROUP BY k.keyword
+ORDER BY count DESC, k.keyword
+LIMIT @limit`
+
+// SentimentJob runs the offline sentiment analysis on crawled reviews that
+// have not been analysed yet.
+type SentimentJob struct {
+	db *gorm.DB
+}
+
+func NewSentimentJob(db *gorm.DB) *SentimentJob {
+	return &SentimentJob{db: db}
+}
+
+// Start runs the job immediately and then every interval.
+func (j *SentimentJob) Start(interval time.Duration) {
+	log.Println("Starting review sentiment job...")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.Run(context.Background(), time.Now()); err != nil {
+			log.Printf("Review sentiment analysis failed: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Run analyses every review without a sentiment yet, a batch at a time.
+func (j *SentimentJob) Run(ctx context.Context, now time.Time) error {
+	db := j.db.WithContext(ctx)
+	analysed := 0
+	for {
+		var reviews []struct {
+			ID        uint
+			ProductID uint
+			Comment   string
+		}
+		if err := db.Table("reviews r").
+			Select("r.id, r.product_id, COALESCE(r.comment, '') AS comment").
+			Joins("LEFT JOIN review_sentiments s ON s.review_id = r.id").
+			Where("s.review_id IS NULL AND r.product_id IS NOT NULL").
+			Order("r.id").
+			Limit(sentimentBatchSize).
+			Scan(&reviews).Error; err != nil {
+			return fmt.Errorf("failed to load reviews: %v", err)
+		}
+		if len(reviews) == 0 {
+			break
+		}
+
+		sentiments := make([]models.ReviewSentiment, len(reviews))
+		var aspects []models.ReviewAspect
+		for i, r := range reviews {
+			result := sentiment.Analyze(r.Comment)
+			sentiments[i] = models.ReviewSentiment{
+				ReviewID:   r.ID,
+				ProductID:  r.ProductID,
+				Language:   string(result.Language),
+				Score:      result.Score,
+				Keywords:   models.Labels(result.Keywords),
+				AnalysedAt: now,
+			}
+			for _, mention := range result.Aspects {
+				aspects = append(aspects, models.ReviewAspect{
+					ReviewID:  r.ID,
+					ProductID: r.ProductID,
+					Aspect:    string(mention.Aspect),
+					Score:     mention.Score,
+				})
+			}
+		}
+
+		// Another instance may have analysed some of them meanwhile
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				CreateInBatches(&sentiments, insertBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to save review sentiments: %v", err)
+			}
+			if len(aspects) > 0 {
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+					CreateInBatches(&aspects, insertBatchSize).Error; err != nil {
+					return fmt.Errorf("failed to save review aspects: %v", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		analysed += len(reviews)
+		if len(reviews) < sentimentBatchSize {
+			break
+		}
+	}
+
+	if analysed > 0 {
+		log.Printf("Analysed the sentiment of %d reviews", analysed)
+	}
+	return nil
+}
+
+// GetBrandSentiment aggregates the review sentiment of a brand's products.
+func (s *ProductAnalysisService) GetBrandSentiment(ctx context.Context, req *pb.GetBrandSentimentRequest) (*pb.GetBrandSentimentResponse, error) {
+	brandID, err := strconv.ParseUint(req.BrandId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid brand ID: %v", err)
+	}
+
+	db := s.db.WithContext(ctx)
+	var brand struct{ ID uint }
+	result := db.Table("brands").Select("id").Where("id = ?", brandID).Take(&brand)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "brand %d not found", brandID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get brand: %v", result.Error)
+	}
+
+	brandSentiment, err := loadReviewSentiment(db, db.Table("products").Select("id").Where("brand_id = ?", brandID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand sentiment: %v", err)
+	}
+	return &pb.GetBrandSentimentResponse{BrandId: fmt.Sprint(brandID), Sentiment: brandSentiment}, nil
+}
+
+// loadReviewSentiment aggregates the analysed reviews of the products the
+// products subquery selects.
+func loadReviewSentiment(db *gorm.DB, products *gorm.DB) (*pb.ReviewSentiment, error) {
+	params := map[string]interface{}{
+		"products": products,
+		"band":     sentiment.NeutralBand,
+		"limit":    maxSentimentKeywords,
+	}
+
+	var totals struct {
+		ReviewCount   int64
+		AverageScore  float64
+		PositiveCount int64
+		NegativeCount int64
+	}
+	if err := db.Raw(reviewSentimentQuery, params).Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	result := &pb.ReviewSentiment{
+		ReviewCount:   int32(totals.ReviewCount),
+		AverageScore:  float32(totals.AverageScore),
+		PositiveCount: int32(totals.PositiveCount),
+		NeutralCount:  int32(totals.ReviewCount - totals.PositiveCount - totals.NegativeCount),
+		NegativeCount: int32(totals.NegativeCount),
+	}
+
+	var aspects []struct {
+		Aspect        string
+		MentionCount  int64
+		NegativeCount int64
+		AverageScore  float64
+	}
+	if err := db.Raw(reviewAspectsQuery, params).Scan(&aspects).Error; err != nil {
+		return nil, err
+	}
+	for _, a := range aspects {
+		result.Aspects = append(result.Aspects, &pb.AspectSentiment{
+			Aspect:        a.Aspect,
+			MentionCount:  int32(a.MentionCount),
+			NegativeCount: int32(a.NegativeCount),
+			AverageScore:  float32(a.AverageScore),
+		})
+	}
+
+	var keywords []struct {
+		Keyword string
+		Count   int64
+	}
+	if err := db.Raw(reviewKeywordsQuery, params).Scan(&keywords).Error; err != nil {
+		return nil, err
+	}
+	for _, k := range keywords {
+		result.Keywords = append(result.Keywords, &pb.KeywordCount{Keyword: k.Keyword, Count: int32(k.Count)})
+	}
+	return result, nil
+}
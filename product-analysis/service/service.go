@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 
 	"github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
@@ -27,191 +31,182 @@ func (s *ProductAnalysisService) Health(ctx context.Context, req *pb.HealthReque
 	return &pb.HealthResponse{Status: "healthy"}, nil
 }
 
-func (s *ProductAnalysisService) AnalyzeProduct(ctx context.Context, req *pb.AnalyzeProductRequest) (*pb.AnalyzeProductResponse, error) {
-	productID := req.Product.Id
-	var notifications []string
+// priorityLockClass namespaces the advisory locks that serialise priority
+// updates of a product, taken as (priorityLockClass, product ID).
+const priorityLockClass int32 = 7461932
 
-	// Get or create product analytics
-	var analytics models.ProductAnalytics
-	productIDUint, err := strconv.ParseUint(productID, 10, 64)
-if err != nil {
-	return nil, fmt.Errorf("invalid product ID: %v", err)
-}
-result := s.db.FirstOrCreate(&analytics, models.ProductAnalytics{ProductID: uint(productIDUint)})
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get/create product analytics: %v", result.Error)
+// UpdateProductPriority recomputes a product's update priority from the
+// interest in it: a product someone has favorited or set a notification
+// preference on is favorited. The priority is derived here from committed
+// state under a per-product advisory lock, so updates racing in from
+// different services or replicas all leave the same result; the request's
+// is_favorited is ignored.
+func (s *ProductAnalysisService) UpdateProductPriority(ctx context.Context, req *pb.UpdateProductPriorityRequest) (*pb.UpdateProductPriorityResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
 	}
 
-	// Update analytics
-	analytics.ViewCount = int(req.Product.ViewCount)
-	analytics.FavoriteCount = int(req.Product.FavoriteCount)
-	analytics.AddToCartCount = int(req.Product.AddToCartCount)
-	analytics.OrderCount = int(req.Product.OrderCount)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", priorityLockClass, int32(productID)).Error; err != nil {
+			return fmt.Errorf("failed to lock product priority: %v", err)
+		}
 
-	// Calculate popularity score (simple weighted average)
-	analytics.PopularityScore = calculatePopularityScore(
-		float64(req.Product.ViewCount),
-		float64(req.Product.FavoriteCount),
-		float64(req.Product.AddToCartCount),
-		float64(req.Product.OrderCount),
-	)
+		// Favorites are counted by the gateway, preferences kept by notification
+		var interested bool
+		if err := tx.Raw(`SELECT EXISTS (SELECT 1 FROM product_favorite_counts WHERE product_id = ? AND favorite_count > 0)
+			OR EXISTS (SELECT 1 FROM notification_preferences WHERE product_id = ?)`, productID, productID).
+			Scan(&interested).Error; err != nil {
+			return fmt.Errorf("failed to check interest in product: %v", err)
+		}
 
-	// Check variants for price and stock changes
-	for _, variant := range req.Product.Variants {
-		notifications = append(notifications, s.analyzeVariant(variant)...)
+		var priority models.UpdatePriority
+		if err := tx.FirstOrCreate(&priority, models.UpdatePriority{ProductID: uint(productID)}).Error; err != nil {
+			return fmt.Errorf("failed to get/create update priority: %v", err)
+		}
+		priority.Priority = 1 // Normal priority
+		if interested {
+			priority.Priority = 2 // Favorited products get higher priority
+		}
+		priority.LastUpdated = time.Now()
+		if err := tx.Save(&priority).Error; err != nil {
+			return fmt.Errorf("failed to save update priority: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Update last analyzed time
-	analytics.LastAnalyzedAt = time.Now()
-	s.db.Save(&analytics)
-
-	return &pb.AnalyzeProductResponse{
-		Status:        "success",
-		Notifications: notifications,
-	}, nil
+	return &pb.UpdateProductPriorityResponse{Status: "success"}, nil
 }
 
-func (s *ProductAnalysisService) UpdateProductPriority(ctx context.Context, req *pb.UpdateProductPriorityRequest) (*pb.UpdateProductPriorityResponse, error) {
-	var priority models.UpdatePriority
-	productIDUint, err := strconv.ParseUint(req.ProductId, 10, 64)
-if err != nil {
-	return nil, fmt.Errorf("invalid product ID: %v", err)
-}
-result := s.db.FirstOrCreate(&priority, models.UpdatePriority{ProductID: uint(productIDUint)})
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to get/create update priority: %v", result.Error)
+func (s *ProductAnalysisService) GetProductAnalytics(ctx context.Context, req *pb.GetProductAnalyticsRequest) (*pb.GetProductAnalyticsResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
 	}
 
-	// Set priority based on favorited status
-	if req.IsFavorited {
-		priority.Priority = 2 // Favorited products get higher priority
-	} else {
-		priority.Priority = 1 // Normal priority
+	windowDays := int(req.WindowDays)
+	if windowDays == 0 {
+		windowDays = defaultWindowDays
+	}
+	if windowDays < 0 || windowDays > maxWindowDays {
+		return nil, status.Errorf(codes.InvalidArgument, "window_days must be between 1 and %d", maxWindowDays)
+	}
+	sellerID, err := parseSellerFilter(req.SellerId)
+	if err != nil {
+		return nil, err
 	}
 
-	priority.LastUpdated = time.Now()
-	s.db.Save(&priority)
-
-	return &pb.UpdateProductPriorityResponse{Status: "success"}, nil
-}
-
-func (s *ProductAnalysisService) GetProductAnalytics(ctx context.Context, req *pb.GetProductAnalyticsRequest) (*pb.GetProductAnalyticsResponse, error) {
+	db := s.db.WithContext(ctx)
 	var analytics models.ProductAnalytics
-	result := s.db.First(&analytics, "product_id = ?", req.ProductId)
+	result := db.First(&analytics, "product_id = ?", productID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no analytics for product %d", productID)
+	}
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get product analytics: %v", result.Error)
 	}
 
-	// Get price history
-	var priceHistory []models.PriceHistory
-	s.db.Where("variant_id IN (SELECT id FROM product_variants WHERE product_id = ?)", req.ProductId).
-		Order("changed_at DESC").
-		Limit(10).
-		Find(&priceHistory)
+	now := time.Now()
+	since := now.AddDate(0, 0, -windowDays)
 
-	pbPriceHistory := make([]*pb.PriceHistory, len(priceHistory))
-	for i, ph := range priceHistory {
-		pbPriceHistory[i] = &pb.PriceHistory{
-			VariantId: fmt.Sprint(ph.VariantID),
-			OldPrice:  float32(ph.OldPrice),
-			NewPrice:  float32(ph.NewPrice),
-			ChangedAt: ph.ChangedAt.Format(time.RFC3339),
-		}
+	price, err := computePriceWindow(db, uint(productID), sellerID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute price trend: %v", err)
+	}
+	stock, err := computeStockWindow(db, uint(productID), since, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute stock trend: %v", err)
+	}
+	favorites, err := computeFavoriteWindow(db, uint(productID), since, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute favorite trend: %v", err)
 	}
 
-	return &pb.GetProductAnalyticsResponse{
-		PriceTrend:        calculatePriceTrend(priceHistory),
-		StockTrend:        0, // TODO: Implement stock trend calculation
-		FavoriteCountTrend: int32(analytics.FavoriteCount),
-		PopularityScore:    float32(analytics.PopularityScore),
-		PriceHistory:       pbPriceHistory,
-	}, nil
-}
-
-func (s *ProductAnalysisService) analyzeVariant(variant *pb.ProductVariant) []string {
-	var notifications []string
-
-	// Check for price changes
-	var lastPriceHistory models.PriceHistory
-	result := s.db.Where("variant_id = ?", variant.Id).
-		Order("changed_at DESC").
-		First(&lastPriceHistory)
-
-	variantIDUint, err := strconv.ParseUint(variant.Id, 10, 64)
-if err != nil {
-	return []string{fmt.Sprintf("error:invalid_variant_id=%s", variant.Id)}
-}
-
-if result.Error == nil {
-		if float64(variant.Price) != lastPriceHistory.NewPrice {
-			// Price has changed
-			priceHistory := models.PriceHistory{
-				VariantID: uint(variantIDUint),
-				OldPrice:  lastPriceHistory.NewPrice,
-				NewPrice:  float64(variant.Price),
-			}
-			s.db.Create(&priceHistory)
-
-			if variant.Price < float32(lastPriceHistory.NewPrice) {
-				notifications = append(notifications, fmt.Sprintf("price_drop:variant_id=%s:old_price=%.2f:new_price=%.2f",
-					variant.Id, lastPriceHistory.NewPrice, variant.Price))
+	// Fixed windows for comparison, whatever window was requested
+	var priceChanges []*pb.PriceChange
+	for _, days := range []int{7, 30, 90} {
+		change := price.ChangePercent
+		if days != windowDays {
+			fixed, err := computePriceWindow(db, uint(productID), sellerID, now.AddDate(0, 0, -days))
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute %d-day price change: %v", days, err)
 			}
+			change = fixed.ChangePercent
 		}
-	} else {
-		// First price record
-		priceHistory := models.PriceHistory{
-			VariantID: uint(variantIDUint),
-			OldPrice:  float64(variant.Price),
-			NewPrice:  float64(variant.Price),
-		}
-		s.db.Create(&priceHistory)
-	}
-
-	// Check for stock changes
-	var lastStockHistory models.StockHistory
-	result = s.db.Where("variant_id = ?", variantIDUint).
-		Order("changed_at DESC").
-		First(&lastStockHistory)
-
-	if result.Error == nil {
-		if int(variant.StockQuantity) != lastStockHistory.NewQuantity {
-			// Stock has changed
-			stockHistory := models.StockHistory{
-				VariantID:   uint(variantIDUint),
-				OldQuantity: lastStockHistory.NewQuantity,
-				NewQuantity: int(variant.StockQuantity),
-			}
-			s.db.Create(&stockHistory)
+		priceChanges = append(priceChanges, &pb.PriceChange{WindowDays: int32(days), ChangePercent: float32(change)})
+	}
 
-			if variant.StockQuantity == 0 {
-				notifications = append(notifications, fmt.Sprintf("out_of_stock:variant_id=%s", variant.Id))
-			}
-		}
-	} else {
-		// First stock record
-		stockHistory := models.StockHistory{
-			VariantID:   uint(variantIDUint),
-			OldQuantity: int(variant.StockQuantity),
-			NewQuantity: int(variant.StockQuantity),
+	var scores []models.ProductScore
+	if err := db.Where("product_id = ?", productID).Order("scorer").Find(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to get product scores: %v", err)
+	}
+	pbScores := make([]*pb.ProductScore, len(scores))
+	for i, score := range scores {
+		pbScores[i] = &pb.ProductScore{
+			Scorer:       score.Scorer,
+			Score:        float32(score.Score),
+			CategoryRank: int32(score.CategoryRank),
+			ComputedAt:   score.ComputedAt.Format(time.RFC3339),
 		}
-		s.db.Create(&stockHistory)
 	}
 
-	return notifications
-}
+	anomalies, err := openPriceAnomalies(db, uint(productID), sellerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price anomalies: %v", err)
+	}
 
-func calculatePopularityScore(views, favorites, addToCarts, orders float64) float64 {
-	// Simple weighted average
-	return (views*0.1 + favorites*0.2 + addToCarts*0.3 + orders*0.4) / 1000
-}
+	reviewSentiment, err := loadReviewSentiment(db, db.Table("products").Select("id").Where("id = ?", productID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review sentiment: %v", err)
+	}
 
-func calculatePriceTrend(history []models.PriceHistory) float32 {
-	if len(history) < 2 {
-		return 0
+	var canonicalID []uint
+	if err := db.Model(&models.CanonicalMembership{}).
+		Where("product_id = ?", productID).
+		Pluck("canonical_product_id", &canonicalID).Error; err != nil {
+		return nil, fmt.Errorf("failed to get canonical product: %v", err)
+	}
+	var canonicalProductID string
+	if len(canonicalID) > 0 {
+		canonicalProductID = fmt.Sprint(canonicalID[0])
 	}
 
-	// Calculate percentage change between first and last price
-	firstPrice := history[len(history)-1].NewPrice
-	lastPrice := history[0].NewPrice
-	return float32((lastPrice - firstPrice) / firstPrice * 100)
+	pbPriceHistory := make([]*pb.PriceHistory, len(price.History))
+	for i, ph := range price.History {
+		pbPriceHistory[i] = &pb.PriceHistory{
+			VariantId:    fmt.Sprint(ph.VariantID),
+			OldPrice:     float32(ph.OldPrice.InexactFloat64()),
+			NewPrice:     float32(ph.NewPrice.InexactFloat64()),
+			ChangedAt:    ph.ChangedAt.Format(time.RFC3339),
+			SellerId:     formatSellerID(ph.SellerID),
+			Currency:     strings.TrimSpace(ph.Currency),
+			OldSalePrice: ph.OldPrice.StringFixed(2),
+			SalePrice:    ph.NewPrice.StringFixed(2),
+			ListPrice:    formatNullDecimal(ph.ListPrice),
+			BasketPrice:  formatNullDecimal(ph.BasketPrice),
+			Promotions:   ph.Promotions,
+		}
+	}
+
+	return &pb.GetProductAnalyticsResponse{
+		PriceTrend:         float32(price.ChangePercent),
+		StockTrend:         float32(stock.ChangePercent),
+		FavoriteCountTrend: int32(favorites.Gained),
+		PopularityScore:    float32(analytics.PopularityScore),
+		PriceHistory:       pbPriceHistory,
+		WindowDays:         int32(windowDays),
+		PriceChanges:       priceChanges,
+		MinPrice:           float32(price.Min),
+		MaxPrice:           float32(price.Max),
+		AvgPrice:           float32(price.Avg),
+		StockVelocity:      float32(stock.Velocity),
+		FavoriteGrowthRate: float32(favorites.RatePerDay),
+		Scores:             pbScores,
+		Anomalies:          anomalies,
+		ReviewSentiment:    reviewSentiment,
+		CanonicalProductId: canonicalProductID,
+	}, nil
 }
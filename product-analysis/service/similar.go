@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	pb "github.com/faisaloncode/ecommerce-crawler/product-analysis/proto"
+	"github.com/faisaloncode/ecommerce-crawler/product-analysis/similarity"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+	// maxSimilarCandidates bounds how many products of the category are
+	// scored for a product.
+	maxSimilarCandidates = 1000
+
+	similarSourceCrawled  = "crawled"
+	similarSourceComputed = "computed"
+)
+
+// lowestPricesQuery returns the cheapest in-stock offer of each product.
+const lowestPricesQuery = `
+SELECT DISTINCT ON (v.product_id) v.product_id, o.price, o.currency
+FROM offers o
+JOIN product_variants v ON v.id = o.variant_id
+WHERE v.product_id IN @ids AND o.is_active AND o.stock_quantity > 0
+ORDER BY v.product_id, o.price, o.currency`
+
+type lowestPrice struct {
+	ProductID uint
+	Price     decimal.Decimal
+	Currency  string
+}
+
+type similarCandidate struct {
+	ID         uint
+	Name       string
+	CategoryID *uint
+}
+
+// GetSimilarProducts returns the products the site lists as similar to a
+// product, topped up with computed neighbours from the same category and
+// price band, each compared with the product's price.
+func (s *ProductAnalysisService) GetSimilarProducts(ctx context.Context, req *pb.GetSimilarProductsRequest) (*pb.GetSimilarProductsResponse, error) {
+	productID, err := strconv.ParseUint(req.ProductId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid product ID: %v", err)
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSimilarLimit
+	}
+	if limit < 0 || limit > maxSimilarLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxSimilarLimit)
+	}
+
+	db := s.db.WithContext(ctx)
+	var product similarCandidate
+	result := db.Table("products").Select("id, name, category_id").Where("id = ?", productID).Take(&product)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, status.Errorf(codes.NotFound, "product %d not found", productID)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get product: %v", result.Error)
+	}
+	prices, err := loadLowestPrices(db, []uint{product.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product price: %v", err)
+	}
+	price, priced := prices[product.ID]
+
+	var crawled []similarCandidate
+	if err := db.Table("similar_products sp").
+		Select("p.id, p.name, p.category_id").
+		Joins("JOIN products p ON p.id = sp.similar_product_id").
+		Where("sp.product_id = ?", product.ID).
+		Order("p.id").
+		Scan(&crawled).Error; err != nil {
+		return nil, fmt.Errorf("failed to get similar products: %v", err)
+	}
+
+	// Computed neighbours only make up for missing crawled ones
+	var computed []similarCandidate
+	if len(crawled) < limit && product.CategoryID != nil {
+		exclude := []uint{product.ID}
+		for _, c := range crawled {
+			exclude = append(exclude, c.ID)
+		}
+		query := db.Table("products p").
+			Select("p.id, p.name, p.category_id").
+			Where("p.category_id = ? AND p.is_active AND p.id NOT IN ?", *product.CategoryID, exclude)
+		if priced {
+			band := decimal.NewFromFloat(similarity.PriceBand)
+			query = query.Where(`EXISTS (
+SELECT 1 FROM offers o JOIN product_variants v ON v.id = o.variant_id
+WHERE v.product_id = p.id AND o.is_active AND o.stock_quantity > 0
+    AND o.currency = ? AND o.price BETWEEN ? AND ?)`,
+				price.Currency,
+				price.Price.Mul(decimal.NewFromInt(1).Sub(band)),
+				price.Price.Mul(decimal.NewFromInt(1).Add(band)))
+		}
+		if err := query.Order("p.id").Limit(maxSimilarCandidates).Scan(&computed).Error; err != nil {
+			return nil, fmt.Errorf("failed to get similar product candidates: %v", err)
+		}
+	}
+
+	ids := []uint{product.ID}
+	for _, group := range [][]similarCandidate{crawled, computed} {
+		for _, c := range group {
+			ids = append(ids, c.ID)
+		}
+	}
+	attributes, err := loadAttributes(db, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product attributes: %v", err)
+	}
+	if prices, err = loadLowestPrices(db, ids); err != nil {
+		return nil, fmt.Errorf("failed to get similar product prices: %v", err)
+	}
+
+	// Prices in another currency cannot be compared and count as unknown
+	toScore := func(c similarCandidate) similarity.Product {
+		p := similarity.Product{ID: c.ID, Attributes: attributes[c.ID]}
+		if c.CategoryID != nil {
+			p.CategoryID = *c.CategoryID
+		}
+		if other, ok := prices[c.ID]; ok && priced && other.Currency == price.Currency {
+			p.Price = other.Price.InexactFloat64()
+		}
+		return p
+	}
+	reference := toScore(product)
+
+	resp := &pb.GetSimilarProductsResponse{}
+	if priced {
+		resp.Price = price.Price.StringFixed(2)
+		resp.Currency = strings.TrimSpace(price.Currency)
+	}
+	names := make(map[uint]string, len(crawled)+len(computed))
+	for _, group := range [][]similarCandidate{crawled, computed} {
+		for _, c := range group {
+			names[c.ID] = c.Name
+		}
+	}
+	for _, group := range []struct {
+		source     string
+		candidates []similarCandidate
+	}{
+		{similarSourceCrawled, crawled},
+		{similarSourceComputed, computed},
+	} {
+		candidates := make([]similarity.Product, len(group.candidates))
+		for i, c := range group.candidates {
+			candidates[i] = toScore(c)
+		}
+		var ranked []similarity.Scored
+		if group.source == similarSourceCrawled {
+			// The site's own list is kept whole, whatever it scores
+			for _, c := range candidates {
+				ranked = append(ranked, similarity.Scored{Product: c, Score: similarity.Score(reference, c)})
+			}
+			similarity.Sort(ranked)
+		} else {
+			ranked = similarity.Rank(reference, candidates, maxSimilarCandidates)
+		}
+
+		for _, r := range ranked {
+			if len(resp.Similar) == limit {
+				break
+			}
+			neighbour := &pb.SimilarProduct{
+				ProductId: fmt.Sprint(r.ID),
+				Name:      names[r.ID],
+				Source:    group.source,
+				Score:     float32(r.Score),
+			}
+			if other, ok := prices[r.ID]; ok {
+				neighbour.Price = other.Price.StringFixed(2)
+				neighbour.Currency = strings.TrimSpace(other.Currency)
+				if priced && other.Currency == price.Currency {
+					difference := other.Price.Sub(price.Price)
+					neighbour.PriceDifference = difference.StringFixed(2)
+					neighbour.Cheaper = difference.IsNegative()
+					if price.Price.IsPositive() {
+						neighbour.PriceDifferencePercent = float32(difference.Div(price.Price).InexactFloat64() * 100)
+					}
+				}
+			}
+			if req.CheaperOnly && !neighbour.Cheaper {
+				continue
+			}
+			resp.Similar = append(resp.Similar, neighbour)
+		}
+	}
+	return resp, nil
+}
+
+func loadLowestPrices(db *gorm.DB, productIDs []uint) (map[uint]lowestPrice, error) {
+	var rows []lowestPrice
+	if err := db.Raw(lowestPricesQuery, map[string]interface{}{"ids": productIDs}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	prices := make(map[uint]lowestPrice, len(rows))
+	for _, r := range rows {
+		prices[r.ProductID] = r
+	}
+	return prices, nil
+}
+
+func loadAttributes(db *gorm.DB, productIDs []uint) (map[uint]map[string]string, error) {
+	var rows []struct {
+		ProductID      uint
+		AttributeName  string
+		AttributeValue string
+	}
+	if err := db.Table("product_attributes").
+		Select("product_id, attribute_name, attribute_value").
+		Where("product_id IN ?", productIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	attributes := make(map[uint]map[string]string)
+	for _, r := range rows {
+		if attributes[r.ProductID] == nil {
+			attributes[r.ProductID] = make(map[string]string)
+		}
+		attributes[r.ProductID][r.AttributeName] = r.AttributeValue
+	}
+	return attributes, nil
+}
@@ -0,0 +1,113 @@
+// Package similarity scores how alike two products are from their category,
+// attributes and price. It stands in for the similar-product lists the site
+// shows when a product has none.
+package similarity
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// Weights of the parts of a score. A product always shares its
+	// candidates' category, so that part is a floor that lets products
+	// with no attributes or prices still rank.
+	categoryWeight  = 0.2
+	attributeWeight = 0.5
+	priceWeight     = 0.3
+
+	// PriceBand is how far, as a fraction of a product's price, a
+	// candidate's price may be for the candidate to count as similar.
+	PriceBand = 0.5
+)
+
+// Product is what similarity looks at for one product.
+type Product struct {
+	ID         uint
+	CategoryID uint
+	// Attributes maps attribute names to values.
+	Attributes map[string]string
+	// Price is the product's lowest price, or 0 when it is unknown or in
+	// another currency than the product compared with.
+	Price float64
+}
+
+type Scored struct {
+	Product
+	Score float64
+}
+
+// Score returns how alike a and b are, in [0, 1]. Products in different
+// categories, or priced outside each other's price band, score 0.
+func Score(a, b Product) float64 {
+	if a.CategoryID != b.CategoryID {
+		return 0
+	}
+	score := categoryWeight + attributeWeight*sharedAttributes(a.Attributes, b.Attributes)
+	if a.Price > 0 && b.Price > 0 {
+		difference := math.Abs(a.Price-b.Price) / a.Price
+		if difference > PriceBand {
+			return 0
+		}
+		score += priceWeight * (1 - difference/PriceBand)
+	}
+	return score
+}
+
+// Rank scores candidates against product and returns the limit most
+// similar in Sort order. Candidates scoring 0 are left out.
+func Rank(product Product, candidates []Product, limit int) []Scored {
+	var ranked []Scored
+	for _, c := range candidates {
+		if c.ID == product.ID {
+			continue
+		}
+		if score := Score(product, c); score > 0 {
+			ranked = append(ranked, Scored{Product: c, Score: score})
+		}
+	}
+	Sort(ranked)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// Sort orders scored products most similar first, then by ID.
+func Sort(scored []Scored) {
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].ID < scored[j].ID
+	})
+}
+
+// sharedAttributes is the Jaccard index of two attribute sets: the
+// name-value pairs they share over all the pairs either has. Names and
+// values are compared case-insensitively.
+func sharedAttributes(a, b map[string]string) float64 {
+	pairsA, pairsB := attributePairs(a), attributePairs(b)
+	union := len(pairsA)
+	shared := 0
+	for pair := range pairsB {
+		if pairsA[pair] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+func attributePairs(attributes map[string]string) map[string]bool {
+	pairs := make(map[string]bool, len(attributes))
+	for name, value := range attributes {
+		pairs[strings.ToLower(name)+"\x00"+strings.ToLower(value)] = true
+	}
+	return pairs
+}
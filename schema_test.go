@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	crawlermodels "github.com/faisaloncode/ecommerce-crawler/crawler/models"
+	"github.com/faisaloncode/ecommerce-crawler/migrations"
+	gatewaymodels "github.com/faisaloncode/ecommerce-crawler/models"
+	notificationmodels "github.com/faisaloncode/ecommerce-crawler/notification/models"
+	analysismodels "github.com/faisaloncode/ecommerce-crawler/product-analysis/models"
+)
+
+// TestServiceSchemas boots the schema of every service against one
+// database: it applies all migrations, checks that no table is claimed by
+// more than one service and that each service's models agree with the
+// tables they map to. It needs a scratch Postgres database, given as a
+// DSN in TEST_DATABASE_URL, and is skipped without one.
+func TestServiceSchemas(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database handle: %v", err)
+	}
+	if err := migrations.Run(context.Background(), sqlDB); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	services := map[string][]interface{}{
+		"gateway":          gatewaymodels.Tables(),
+		"crawler":          crawlermodels.Tables(),
+		"product-analysis": analysismodels.Tables(),
+		"notification":     notificationmodels.Tables(),
+	}
+
+	owners := make(map[string]string)
+	for service, models := range services {
+		for _, model := range models {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err != nil {
+				t.Fatalf("failed to parse model %T: %v", model, err)
+			}
+			table := stmt.Schema.Table
+			if owner, ok := owners[table]; ok {
+				t.Errorf("table %s is owned by both %s and %s", table, owner, service)
+			}
+			owners[table] = service
+		}
+	}
+
+	for service, models := range services {
+		t.Run(service, func(t *testing.T) {
+			if err := migrations.VerifyModels(db, models...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}